@@ -28,7 +28,10 @@ type App struct {
 
 func (app *App) debugPinger() {
 	var testChannel *pb.Channel = &pb.Channel{Id: []byte("testChannel")}
-	app.P2p.Subscribe(testChannel)
+	err := app.P2p.Subscribe(testChannel)
+	if !errors.IsEmpty(err) && app.Logger != nil {
+		app.Logger.Error(errors.E(errors.Op("Subscribe"), err))
+	}
 	testRequest := &pb.CreateRequest{ChannelID: testChannel.GetId(), Asset: string("ETH"), CounterAsset: string("BTC"), Amount: 52153, Price: 0.2}
 
 	for {
@@ -45,8 +48,8 @@ func (app *App) debugPinger() {
 	}
 }
 
-// InitServices ties the services together before running
-func (app *App) InitServices(config interfaces.Config, Logger interfaces.Logger) {
+// InitServices ties the services together before running. An error is returned if the p2p network can't be started.
+func (app *App) InitServices(config interfaces.Config, Logger interfaces.Logger) error {
 	app.config = config
 	app.Logger = Logger
 	errors.SetDebug(app.config.GetBool("errors.enableStackTrace"))
@@ -89,15 +92,32 @@ func (app *App) InitServices(config interfaces.Config, Logger interfaces.Logger)
 	// Run the P2P process
 	app.P2p = p2p.NewP2p(Logger, config, privateKey, publicKey)
 
-	// Construct the server struct
-	app.Server = service.NewServer(Logger, app.Storage, app.P2p)
+	// Construct the server struct. Bootstrap and relay nodes don't trade, so they neither serve nor store orders.
+	if app.P2p.IsInfrastructure() {
+		app.Server = service.NewNodeServer(Logger, app.P2p)
+	} else {
+		app.Server = service.NewServer(Logger, app.Storage, app.P2p)
 
-	// Connect the order and channel services with p2p
-	app.P2p.RegisterOrderService(app.Server.Orders)
-	app.P2p.RegisterChannelService(app.Server.Channels)
+		// Connect the order and channel services with p2p
+		app.P2p.RegisterOrderService(app.Server.Orders)
+		app.P2p.RegisterChannelService(app.Server.Channels)
+
+		// Index the orders stored before the secondary indexes existed or changed
+		err = app.Server.Orders.UpdateIndexes()
+		if !errors.IsEmpty(err) && app.Logger != nil {
+			app.Logger.Error(errors.E(errors.Op("Rebuild indexes"), err))
+		}
+	}
+
+	// Persist the banned and the recently connected peers
+	app.P2p.RegisterStorage(app.Storage)
 
 	// Run the P2p service before running the gRPC server
-	app.P2p.Run()
+	err = app.P2p.Run()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Init services"), err)
+	}
+	return nil
 }
 
 // Run is a separated main-function to ease testing
@@ -108,7 +128,7 @@ func (app *App) Run() {
 	defer app.Storage.Close()
 	defer app.P2p.Close()
 
-	if app.config.GetBool("p2p.debug") {
+	if app.config.GetBool("p2p.debug") && app.Server.Orders != nil {
 		if app.Logger != nil {
 			app.Logger.Info("Running the debug pinger on channel \"testChannel\"!")
 		}
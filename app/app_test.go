@@ -18,6 +18,7 @@ const testPrice = 0.1
 const p2pDebugEnvVar string = "SPRAWL_P2P_DEBUG"
 const envTestP2PDebug string = "true"
 const testConfigPath = "../config/test"
+const p2pModeEnvVar string = "SPRAWL_P2P_MODE"
 
 var appConfig *config.Config
 var logger *zap.Logger
@@ -32,7 +33,7 @@ func init() {
 
 func TestApp(t *testing.T) {
 	app := &App{}
-	app.InitServices(appConfig, log)
+	assert.NoError(t, app.InitServices(appConfig, log))
 
 	assert.NotNil(t, app.Storage)
 
@@ -70,11 +71,27 @@ func TestApp(t *testing.T) {
 	defer app.P2p.Close()
 }
 
+func TestInfrastructureApp(t *testing.T) {
+	app := &App{}
+	os.Setenv(p2pModeEnvVar, "bootstrap")
+	defer os.Unsetenv(p2pModeEnvVar)
+	assert.NoError(t, app.InitServices(appConfig, log))
+	defer app.Storage.Close()
+	defer app.P2p.Close()
+
+	// Bootstrap nodes only serve the node API, and don't store orders
+	assert.NotNil(t, app.Server.Nodes)
+	assert.Nil(t, app.Server.Orders)
+	assert.Nil(t, app.Server.Channels)
+	assert.Nil(t, app.P2p.Orders)
+	assert.Nil(t, app.P2p.Channels)
+}
+
 // TODO: doesn't test now that the debugPinger actually joins any channel. Needs refactoring of the debugPinger functionality itself to make it more testable.
 func TestDebugPinger(t *testing.T) {
 	app := &App{}
 	os.Setenv(p2pDebugEnvVar, string(envTestP2PDebug))
-	app.InitServices(appConfig, log)
+	assert.NoError(t, app.InitServices(appConfig, log))
 
 	go app.debugPinger()
 
@@ -82,3 +82,8 @@ func (c *Config) GetUint(variable string) uint {
 func (c *Config) GetBool(variable string) bool {
 	return c.v.GetBool(variable)
 }
+
+// GetStringSlice is a proxy for viper.GetStringSlice()
+func (c *Config) GetStringSlice(variable string) []string {
+	return c.v.GetStringSlice(variable)
+}
@@ -30,6 +30,10 @@ const envTestAPIPort uint = 9001
 const envTestP2PDebug string = "true"
 const envTestErrorsEnableStackTrace string = "true"
 const envTestUseInMemory string = "true"
+const p2pBootstrapPeersVar string = "p2p.bootstrapPeers"
+const p2pBootstrapPeersEnvVar string = "SPRAWL_P2P_BOOTSTRAPPEERS"
+const envTestBootstrapPeer1 string = "/ip4/10.0.0.1/tcp/4001/p2p/QmaCpDMGvV2BGHeYERUEnRQAwe3N8SzbUtfsmvsqQLuvuJ"
+const envTestBootstrapPeer2 string = "/ip4/10.0.0.2/tcp/4001/p2p/QmNnooDu7bfjPFoTZYxMNLWUQJyrVwtbZg5gBMjTezGAJN"
 
 var logger *zap.Logger
 var log *zap.SugaredLogger
@@ -52,6 +56,7 @@ func resetEnv() {
 	os.Unsetenv(p2pDebugEnvVar)
 	os.Unsetenv(errorsEnableStackTraceEnvVar)
 	os.Unsetenv(useInMemoryEnvVar)
+	os.Unsetenv(p2pBootstrapPeersEnvVar)
 }
 
 func TestPanics(t *testing.T) {
@@ -119,3 +124,15 @@ func TestEnvironment(t *testing.T) {
 
 	resetEnv()
 }
+
+func TestStringSlice(t *testing.T) {
+	resetEnv()
+	config.ReadConfig(defaultConfigPath)
+	assert.Empty(t, config.GetStringSlice(p2pBootstrapPeersVar))
+
+	os.Setenv(p2pBootstrapPeersEnvVar, envTestBootstrapPeer1+" "+envTestBootstrapPeer2)
+	config.ReadConfig(defaultConfigPath)
+	assert.Equal(t, []string{envTestBootstrapPeer1, envTestBootstrapPeer2}, config.GetStringSlice(p2pBootstrapPeersVar))
+
+	resetEnv()
+}
@@ -0,0 +1,223 @@
+package inmemory
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// write is a single write of a batch or a transaction. A nil value removes the key.
+type write struct {
+	key   string
+	value *string
+}
+
+// Batch collects writes that are applied to the memory database at once
+type Batch struct {
+	storage *Storage
+	writes  []write
+}
+
+// Snapshot is a copy of the memory database
+type Snapshot struct {
+	db map[string]string
+}
+
+// Transaction keeps its writes apart from the memory database until it's committed.
+// Other writes wait until the transaction is committed or discarded.
+type Transaction struct {
+	storage *Storage
+	writes  map[string]*string
+	done    bool
+	once    sync.Once
+}
+
+// NewBatch returns an empty write batch
+func (storage *Storage) NewBatch() interfaces.Batch {
+	return &Batch{storage: storage}
+}
+
+// GetSnapshot copies the current state of the memory database
+func (storage *Storage) GetSnapshot() (interfaces.Snapshot, error) {
+	db, err := storage.GetAll()
+	return &Snapshot{db: db}, err
+}
+
+// OpenTransaction opens a transaction. Only one transaction can be open at a time.
+func (storage *Storage) OpenTransaction() (interfaces.Transaction, error) {
+	storage.writer.Lock()
+	return &Transaction{storage: storage, writes: make(map[string]*string)}, nil
+}
+
+// apply writes the changes to the memory database. The caller must hold storage.writer.
+func (storage *Storage) apply(writes []write) {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	for _, write := range writes {
+		if write.value == nil {
+			delete(storage.Db, write.key)
+		} else {
+			storage.Db[write.key] = *write.value
+		}
+	}
+}
+
+// Put adds a write to the batch
+func (batch *Batch) Put(key []byte, data []byte) {
+	value := string(data)
+	batch.writes = append(batch.writes, write{key: string(key), value: &value})
+}
+
+// Delete adds a removal to the batch
+func (batch *Batch) Delete(key []byte) {
+	batch.writes = append(batch.writes, write{key: string(key)})
+}
+
+// Len returns the amount of writes in the batch
+func (batch *Batch) Len() int {
+	return len(batch.writes)
+}
+
+// Commit applies the batch to the memory database at once and empties it
+func (batch *Batch) Commit() error {
+	batch.storage.writer.Lock()
+	defer batch.storage.writer.Unlock()
+	batch.storage.apply(batch.writes)
+	batch.writes = nil
+	return nil
+}
+
+// Discard empties the batch without writing it
+func (batch *Batch) Discard() {
+	batch.writes = nil
+}
+
+// Has checks whether the key existed when the snapshot was taken
+func (snapshot *Snapshot) Has(key []byte) (bool, error) {
+	_, ok := snapshot.db[string(key)]
+	return ok, nil
+}
+
+// Get fetches the data of a key as it was when the snapshot was taken
+func (snapshot *Snapshot) Get(key []byte) ([]byte, error) {
+	value, ok := snapshot.db[string(key)]
+	if !ok {
+		return []byte(value), errors.E(errors.Op("Get value from snapshot"), errors.NotFound, "key not found")
+	}
+	return []byte(value), nil
+}
+
+// GetAllWithPrefix returns all entries of the snapshot with the specified prefix
+func (snapshot *Snapshot) GetAllWithPrefix(prefix string) (map[string]string, error) {
+	entries := make(map[string]string)
+	for k, v := range snapshot.db {
+		if strings.HasPrefix(k, prefix) {
+			entries[k] = v
+		}
+	}
+	return entries, nil
+}
+
+// Release releases the snapshot
+func (snapshot *Snapshot) Release() {
+	snapshot.db = nil
+}
+
+func (transaction *Transaction) checkOpen(op errors.Op) error {
+	if transaction.done {
+		return errors.E(op, errors.Invalid, "transaction already committed or discarded")
+	}
+	return nil
+}
+
+// Has checks whether the key exists, including the writes of the transaction
+func (transaction *Transaction) Has(key []byte) (bool, error) {
+	_, err := transaction.Get(key)
+	if errors.Is(errors.NotFound, err) {
+		return false, nil
+	}
+	return errors.IsEmpty(err), err
+}
+
+// Get fetches the data of a key, including the writes of the transaction
+func (transaction *Transaction) Get(key []byte) ([]byte, error) {
+	if err := transaction.checkOpen(errors.Op("Get value in transaction")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	value, ok := transaction.writes[string(key)]
+	if !ok {
+		return transaction.storage.Get(key)
+	}
+	if value == nil {
+		return []byte{}, errors.E(errors.Op("Get value in transaction"), errors.NotFound, "key not found")
+	}
+	return []byte(*value), nil
+}
+
+// GetAllWithPrefix returns all entries with the specified prefix, including the writes of the transaction
+func (transaction *Transaction) GetAllWithPrefix(prefix string) (map[string]string, error) {
+	if err := transaction.checkOpen(errors.Op("Get all with prefix in transaction")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	entries, err := transaction.storage.GetAllWithPrefix(prefix)
+	for k, v := range transaction.writes {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		if v == nil {
+			delete(entries, k)
+		} else {
+			entries[k] = *v
+		}
+	}
+	return entries, err
+}
+
+// Put writes data in the transaction
+func (transaction *Transaction) Put(key []byte, data []byte) error {
+	if err := transaction.checkOpen(errors.Op("Put in transaction")); !errors.IsEmpty(err) {
+		return err
+	}
+	value := string(data)
+	transaction.writes[string(key)] = &value
+	return nil
+}
+
+// Delete removes a key in the transaction
+func (transaction *Transaction) Delete(key []byte) error {
+	if err := transaction.checkOpen(errors.Op("Delete in transaction")); !errors.IsEmpty(err) {
+		return err
+	}
+	transaction.writes[string(key)] = nil
+	return nil
+}
+
+// Commit applies the writes of the transaction to the memory database at once
+func (transaction *Transaction) Commit() error {
+	if err := transaction.checkOpen(errors.Op("Commit transaction")); !errors.IsEmpty(err) {
+		return err
+	}
+	writes := make([]write, 0, len(transaction.writes))
+	for k, v := range transaction.writes {
+		writes = append(writes, write{key: k, value: v})
+	}
+	transaction.storage.apply(writes)
+	transaction.close()
+	return nil
+}
+
+// Discard drops the writes of the transaction. Discarding a committed transaction does nothing.
+func (transaction *Transaction) Discard() {
+	transaction.close()
+}
+
+// close lets other writes continue
+func (transaction *Transaction) close() {
+	transaction.once.Do(func() {
+		transaction.done = true
+		transaction.writes = nil
+		transaction.storage.writer.Unlock()
+	})
+}
@@ -0,0 +1,171 @@
+package inmemory
+
+import (
+	"bytes"
+	"container/heap"
+	"sort"
+	"strings"
+
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// entry is a single key and value of the memory database
+type entry struct {
+	key   []byte
+	value []byte
+}
+
+// pendingEntries is a heap of the entries an iterator hasn't reached yet, ordered in its direction
+type pendingEntries struct {
+	keys    []string
+	values  map[string]string
+	reverse bool
+}
+
+func (pending *pendingEntries) Len() int {
+	return len(pending.keys)
+}
+
+func (pending *pendingEntries) Less(i, j int) bool {
+	if pending.reverse {
+		return pending.keys[i] > pending.keys[j]
+	}
+	return pending.keys[i] < pending.keys[j]
+}
+
+func (pending *pendingEntries) Swap(i, j int) {
+	pending.keys[i], pending.keys[j] = pending.keys[j], pending.keys[i]
+}
+
+func (pending *pendingEntries) Push(key interface{}) {
+	pending.keys = append(pending.keys, key.(string))
+}
+
+func (pending *pendingEntries) Pop() interface{} {
+	last := len(pending.keys) - 1
+	key := pending.keys[last]
+	pending.keys = pending.keys[:last]
+	return key
+}
+
+// Iterator walks over the entries of the memory database that matched its options when it was created.
+// The entries are only ordered as the iterator reaches them, so limited iterations over large ranges
+// don't sort the whole range. Seeking orders every entry left.
+type Iterator struct {
+	entries []entry
+	pending *pendingEntries
+	reverse bool
+	limit   int
+	count   int
+	pos     int
+}
+
+// inRange tells whether a key matches both the prefix and the bounds of the options
+func inRange(key string, options *interfaces.IteratorOptions) bool {
+	if !strings.HasPrefix(key, string(options.Prefix)) {
+		return false
+	}
+	if options.Start != nil && key < string(options.Start) {
+		return false
+	}
+	if options.End != nil && key >= string(options.End) {
+		return false
+	}
+	return true
+}
+
+// NewIterator returns an iterator over the entries matching the options
+func (storage *Storage) NewIterator(options *interfaces.IteratorOptions) interfaces.Iterator {
+	if options == nil {
+		options = &interfaces.IteratorOptions{}
+	}
+	pending := &pendingEntries{keys: make([]string, 0), values: make(map[string]string), reverse: options.Reverse}
+	storage.lock.RLock()
+	for k, v := range storage.Db {
+		if inRange(k, options) {
+			pending.keys = append(pending.keys, k)
+			pending.values[k] = v
+		}
+	}
+	storage.lock.RUnlock()
+
+	heap.Init(pending)
+	return &Iterator{entries: make([]entry, 0), pending: pending, reverse: options.Reverse, limit: options.Limit, pos: -1}
+}
+
+// take orders the next pending entry after the entries already reached, returning false when none are left
+func (it *Iterator) take() bool {
+	if it.pending == nil || it.pending.Len() == 0 {
+		return false
+	}
+	key := heap.Pop(it.pending).(string)
+	it.entries = append(it.entries, entry{key: []byte(key), value: []byte(it.pending.values[key])})
+	delete(it.pending.values, key)
+	return true
+}
+
+func (it *Iterator) valid() bool {
+	return it.pos >= 0 && it.pos < len(it.entries)
+}
+
+// move positions the iterator at an entry, counting it against the limit
+func (it *Iterator) move(pos int) bool {
+	if it.limit > 0 && it.count >= it.limit {
+		it.pos = len(it.entries)
+		return false
+	}
+	for pos >= len(it.entries) && it.take() {
+	}
+	it.pos = pos
+	if !it.valid() {
+		it.pos = len(it.entries)
+		return false
+	}
+	it.count++
+	return true
+}
+
+// Next moves to the next entry in the direction of the iterator
+func (it *Iterator) Next() bool {
+	return it.move(it.pos + 1)
+}
+
+// Seek moves to the first entry at or after key, or at or before key in reverse
+func (it *Iterator) Seek(key []byte) bool {
+	for it.take() {
+	}
+	return it.move(sort.Search(len(it.entries), func(i int) bool {
+		if it.reverse {
+			return bytes.Compare(it.entries[i].key, key) <= 0
+		}
+		return bytes.Compare(it.entries[i].key, key) >= 0
+	}))
+}
+
+// Key returns the key of the current entry
+func (it *Iterator) Key() []byte {
+	if !it.valid() {
+		return nil
+	}
+	return it.entries[it.pos].key
+}
+
+// Value returns the value of the current entry
+func (it *Iterator) Value() []byte {
+	if !it.valid() {
+		return nil
+	}
+	return it.entries[it.pos].value
+}
+
+// Error always returns nil, since iterating the memory database can't fail
+func (it *Iterator) Error() error {
+	return nil
+}
+
+// Release drops the entries of the iterator
+func (it *Iterator) Release() {
+	it.entries = nil
+	it.pending = nil
+	it.pos = -1
+}
@@ -2,13 +2,18 @@ package inmemory
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/sprawl/sprawl/errors"
 )
 
-// Storage is a struct containing a database and its address
+// Storage is a struct containing a database and its address.
+// Its methods are safe to call concurrently.
 type Storage struct {
-	Db map[string]string
+	Db   map[string]string
+	lock sync.RWMutex
+	// writer is held by every write, and by open transactions until they're committed or discarded
+	writer sync.Mutex
 }
 
 var err error
@@ -29,39 +34,59 @@ func (storage *Storage) Close() {
 
 // Has uses LevelDB's method Has to check does the data exists in LevelDB
 func (storage *Storage) Has(key []byte) (bool, error) {
+	storage.lock.RLock()
+	defer storage.lock.RUnlock()
 	_, ok := storage.Db[string(key)]
 	return ok, nil
 }
 
 // Get uses LevelDB's method Get to fetch data from LevelDB
 func (storage *Storage) Get(key []byte) ([]byte, error) {
+	storage.lock.RLock()
+	defer storage.lock.RUnlock()
 	value, ok := storage.Db[string(key)]
 	var err error
 	if !ok {
-		err = errors.E(errors.Op("Get value from memory database"))
+		err = errors.E(errors.Op("Get value from memory database"), errors.NotFound, "key not found")
 	}
 	return []byte(value), err
 }
 
 // Put uses LevelDB's Put method to put data into LevelDB
 func (storage *Storage) Put(key []byte, data []byte) error {
+	storage.writer.Lock()
+	defer storage.writer.Unlock()
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
 	storage.Db[string(key)] = string(data)
 	return nil
 }
 
 // Delete uses LevelDB's Delete method to remove data from LevelDB
 func (storage *Storage) Delete(key []byte) error {
+	storage.writer.Lock()
+	defer storage.writer.Unlock()
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
 	delete(storage.Db, string(key))
 	return nil
 }
 
 // GetAll returns all entries in the database regardless of key or prefix
 func (storage *Storage) GetAll() (map[string]string, error) {
-	return storage.Db, nil
+	storage.lock.RLock()
+	defer storage.lock.RUnlock()
+	entries := make(map[string]string)
+	for k, v := range storage.Db {
+		entries[k] = v
+	}
+	return entries, nil
 }
 
 // GetAllWithPrefix returns all entries in the database with the specified prefix
 func (storage *Storage) GetAllWithPrefix(prefix string) (map[string]string, error) {
+	storage.lock.RLock()
+	defer storage.lock.RUnlock()
 	entries := make(map[string]string)
 	for k, v := range storage.Db {
 		if strings.HasPrefix(k, prefix) {
@@ -74,12 +99,20 @@ func (storage *Storage) GetAllWithPrefix(prefix string) (map[string]string, erro
 // DeleteAll deletes all entries from the database
 // USE CAREFULLY
 func (storage *Storage) DeleteAll() error {
+	storage.writer.Lock()
+	defer storage.writer.Unlock()
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
 	storage.Db = make(map[string]string)
 	return nil
 }
 
 // DeleteAllWithPrefix deletes all entries starting with a prefix
 func (storage *Storage) DeleteAllWithPrefix(prefix string) error {
+	storage.writer.Lock()
+	defer storage.writer.Unlock()
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
 	for k := range storage.Db {
 		if strings.HasPrefix(k, prefix) {
 			delete(storage.Db, k)
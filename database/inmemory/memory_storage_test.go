@@ -1,6 +1,8 @@
 package inmemory
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/sprawl/sprawl/config"
@@ -10,7 +12,10 @@ import (
 	"go.uber.org/zap"
 )
 
-const testConfigPath = "../config/test"
+// testConfigPath is relative to this package, two levels below the repository root where config/test lives.
+// "../config/test" pointed at database/config/test, which doesn't exist, so the tests silently ran without
+// the test config.
+const testConfigPath = "../../config/test"
 const dbPathVar = "database.path"
 const testID = "0"
 const testMessage = "testing"
@@ -67,6 +72,32 @@ func TestStorageCRUD(t *testing.T) {
 	assert.Empty(t, deleted)
 }
 
+func TestStorageGetNotFound(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	// Every way of reading a missing key reports it the same way
+	_, err := storage.Get([]byte(testID))
+	assert.True(t, errors.Is(errors.NotFound, err))
+
+	snapshot, err := storage.GetSnapshot()
+	assert.True(t, errors.IsEmpty(err))
+	defer snapshot.Release()
+	_, err = snapshot.Get([]byte(testID))
+	assert.True(t, errors.Is(errors.NotFound, err))
+
+	transaction, err := storage.OpenTransaction()
+	assert.True(t, errors.IsEmpty(err))
+	defer transaction.Discard()
+	_, err = transaction.Get([]byte(testID))
+	assert.True(t, errors.Is(errors.NotFound, err))
+	transaction.Put([]byte(testID), []byte(testMessage))
+	transaction.Delete([]byte(testID))
+	_, err = transaction.Get([]byte(testID))
+	assert.True(t, errors.Is(errors.NotFound, err))
+}
+
 func TestStorageGetAll(t *testing.T) {
 	storage.Run()
 	defer storage.Close()
@@ -134,6 +165,177 @@ func TestStorageDeleteAllWithPrefix(t *testing.T) {
 	assert.Equal(t, len(testMessages), len(allItems))
 }
 
+func TestStorageBatch(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	storage.Put([]byte(testID), []byte(testMessage))
+
+	batch := storage.NewBatch()
+	for key, value := range testMessages {
+		batch.Put([]byte(orderPrefix+key), []byte(value))
+	}
+	batch.Delete([]byte(testID))
+	assert.Equal(t, len(testMessages)+1, batch.Len())
+
+	// Nothing is written before committing
+	allItems, err := storage.GetAll()
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, 1, len(allItems))
+
+	assert.True(t, errors.IsEmpty(batch.Commit()))
+	assert.Zero(t, batch.Len())
+	prefixedItems, err := storage.GetAllWithPrefix(orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, testMessages, stripPrefix(prefixedItems, orderPrefix))
+	testBool, _ := storage.Has([]byte(testID))
+	assert.False(t, testBool)
+
+	// Discarded writes are never applied
+	batch.Put([]byte(testID), []byte(testMessage))
+	batch.Discard()
+	assert.True(t, errors.IsEmpty(batch.Commit()))
+	testBool, _ = storage.Has([]byte(testID))
+	assert.False(t, testBool)
+}
+
+func TestStorageSnapshot(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	storage.Put([]byte(orderPrefix+testID), []byte(testMessage))
+	snapshot, err := storage.GetSnapshot()
+	assert.True(t, errors.IsEmpty(err))
+	defer snapshot.Release()
+
+	storage.Delete([]byte(orderPrefix + testID))
+	storage.Put([]byte(orderPrefix+"new"), []byte(testMessage))
+
+	// The snapshot keeps the state it was taken in
+	testBytes, err := snapshot.Get([]byte(orderPrefix + testID))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, testMessage, string(testBytes))
+	testBool, err := snapshot.Has([]byte(orderPrefix + "new"))
+	assert.True(t, errors.IsEmpty(err))
+	assert.False(t, testBool)
+	prefixedItems, err := snapshot.GetAllWithPrefix(orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, map[string]string{orderPrefix + testID: testMessage}, prefixedItems)
+}
+
+func TestStorageTransaction(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	storage.Put([]byte(testID), []byte(testMessage))
+
+	transaction, err := storage.OpenTransaction()
+	assert.True(t, errors.IsEmpty(err))
+	assert.True(t, errors.IsEmpty(transaction.Put([]byte(orderPrefix+testID), []byte(testMessage))))
+	assert.True(t, errors.IsEmpty(transaction.Delete([]byte(testID))))
+
+	// The transaction sees its own writes
+	testBool, err := transaction.Has([]byte(testID))
+	assert.True(t, errors.IsEmpty(err))
+	assert.False(t, testBool)
+	prefixedItems, err := transaction.GetAllWithPrefix(orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, 1, len(prefixedItems))
+	assert.True(t, errors.IsEmpty(transaction.Commit()))
+
+	testBytes, err := storage.Get([]byte(orderPrefix + testID))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, testMessage, string(testBytes))
+	testBool, _ = storage.Has([]byte(testID))
+	assert.False(t, testBool)
+
+	// Discarded transactions leave the storage untouched
+	transaction, err = storage.OpenTransaction()
+	assert.True(t, errors.IsEmpty(err))
+	transaction.Delete([]byte(orderPrefix + testID))
+	transaction.Discard()
+	testBool, _ = storage.Has([]byte(orderPrefix + testID))
+	assert.True(t, testBool)
+}
+
+func iterate(iter interfaces.Iterator) []string {
+	defer iter.Release()
+	keys := []string{}
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	return keys
+}
+
+func TestStorageIterator(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		storage.Put([]byte(orderPrefix+key), []byte(value))
+		storage.Put([]byte(channelPrefix+key), []byte(value))
+	}
+
+	// Entries are iterated in key order
+	orderKeys := []string{orderPrefix + "test1", orderPrefix + "test2", orderPrefix + "test3", orderPrefix + "test4"}
+	assert.Equal(t, orderKeys, iterate(storage.NewIterator(&interfaces.IteratorOptions{Prefix: []byte(orderPrefix)})))
+	assert.Equal(t, len(testMessages)*2, len(iterate(storage.NewIterator(nil))))
+
+	// Bounds, reverse iteration and limits
+	assert.Equal(t, orderKeys[1:3], iterate(storage.NewIterator(&interfaces.IteratorOptions{
+		Prefix: []byte(orderPrefix),
+		Start:  []byte(orderPrefix + "test2"),
+		End:    []byte(orderPrefix + "test4"),
+	})))
+	assert.Equal(t, []string{orderKeys[3], orderKeys[2]}, iterate(storage.NewIterator(&interfaces.IteratorOptions{
+		Prefix:  []byte(orderPrefix),
+		Reverse: true,
+		Limit:   2,
+	})))
+
+	// Seeking moves to the nearest entry in the direction of the iteration
+	iter := storage.NewIterator(&interfaces.IteratorOptions{Prefix: []byte(orderPrefix)})
+	assert.True(t, iter.Seek([]byte(orderPrefix+"test25")))
+	assert.Equal(t, orderKeys[2], string(iter.Key()))
+	assert.Equal(t, "test3", string(iter.Value()))
+	assert.True(t, iter.Next())
+	assert.Equal(t, orderKeys[3], string(iter.Key()))
+	assert.False(t, iter.Next())
+	assert.True(t, errors.IsEmpty(iter.Error()))
+	iter.Release()
+
+	iter = storage.NewIterator(&interfaces.IteratorOptions{Prefix: []byte(orderPrefix), Reverse: true})
+	assert.True(t, iter.Seek([]byte(orderPrefix+"test25")))
+	assert.Equal(t, orderKeys[1], string(iter.Key()))
+	assert.True(t, iter.Next())
+	assert.Equal(t, orderKeys[0], string(iter.Key()))
+	assert.False(t, iter.Next())
+	assert.False(t, iter.Seek([]byte(orderPrefix)))
+	iter.Release()
+
+	// Entries are ordered as they're reached, seeking after walking a few of them still finds the rest
+	iter = storage.NewIterator(&interfaces.IteratorOptions{Prefix: []byte(orderPrefix)})
+	assert.True(t, iter.Next())
+	assert.Equal(t, orderKeys[0], string(iter.Key()))
+	assert.True(t, iter.Seek([]byte(orderPrefix+"test3")))
+	assert.Equal(t, orderKeys[2], string(iter.Key()))
+	assert.True(t, iter.Seek([]byte(orderPrefix+"test1")))
+	assert.Equal(t, orderKeys[0], string(iter.Key()))
+	iter.Release()
+}
+
+func stripPrefix(entries map[string]string, prefix string) map[string]string {
+	stripped := make(map[string]string)
+	for key, value := range entries {
+		stripped[strings.TrimPrefix(key, prefix)] = value
+	}
+	return stripped
+}
+
 func BenchmarkAdd(b *testing.B) {
 	storage.Run()
 	defer storage.Close()
@@ -141,7 +343,7 @@ func BenchmarkAdd(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 1; i < b.N; i++ {
-		storage.Put([]byte(string(i)), []byte(testMessage+string(i)))
+		storage.Put([]byte(strconv.Itoa(i)), []byte(testMessage+strconv.Itoa(i)))
 	}
 }
 
@@ -151,6 +353,6 @@ func BenchmarkRead(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 1; i < b.N; i++ {
-		storage.Get([]byte(string(i)))
+		storage.Get([]byte(strconv.Itoa(i)))
 	}
 }
@@ -0,0 +1,157 @@
+package leveldb
+
+import (
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	util "github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Batch is a LevelDB write batch
+type Batch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+// Snapshot is a LevelDB snapshot
+type Snapshot struct {
+	snapshot *leveldb.Snapshot
+}
+
+// Transaction is a LevelDB transaction
+type Transaction struct {
+	transaction *leveldb.Transaction
+}
+
+// NewBatch returns an empty write batch
+func (storage *Storage) NewBatch() interfaces.Batch {
+	return &Batch{db: storage.db, batch: new(leveldb.Batch)}
+}
+
+// GetSnapshot takes a snapshot of the current state of LevelDB
+func (storage *Storage) GetSnapshot() (interfaces.Snapshot, error) {
+	snapshot, err := storage.db.GetSnapshot()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get snapshot"), err)
+	}
+	return &Snapshot{snapshot: snapshot}, nil
+}
+
+// OpenTransaction opens a LevelDB transaction. Only one transaction can be open at a time.
+func (storage *Storage) OpenTransaction() (interfaces.Transaction, error) {
+	transaction, err := storage.db.OpenTransaction()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Open transaction"), err)
+	}
+	return &Transaction{transaction: transaction}, nil
+}
+
+// collect returns the entries of an iterator and releases it
+func collect(iter iterator.Iterator) (map[string]string, error) {
+	entries := make(map[string]string)
+	for iter.Next() {
+		entries[string(iter.Key())] = string(iter.Value())
+	}
+	iter.Release()
+	return entries, iter.Error()
+}
+
+// Put adds a write to the batch
+func (batch *Batch) Put(key []byte, data []byte) {
+	batch.batch.Put(key, data)
+}
+
+// Delete adds a removal to the batch
+func (batch *Batch) Delete(key []byte) {
+	batch.batch.Delete(key)
+}
+
+// Len returns the amount of writes in the batch
+func (batch *Batch) Len() int {
+	return batch.batch.Len()
+}
+
+// Commit writes the batch to LevelDB atomically and empties it
+func (batch *Batch) Commit() error {
+	err := batch.db.Write(batch.batch, nil)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Commit batch"), err)
+	}
+	batch.batch.Reset()
+	return nil
+}
+
+// Discard empties the batch without writing it
+func (batch *Batch) Discard() {
+	batch.batch.Reset()
+}
+
+// Has checks whether the key existed when the snapshot was taken
+func (snapshot *Snapshot) Has(key []byte) (bool, error) {
+	return snapshot.snapshot.Has(key, nil)
+}
+
+// Get fetches the data of a key as it was when the snapshot was taken
+func (snapshot *Snapshot) Get(key []byte) ([]byte, error) {
+	data, err := snapshot.snapshot.Get(key, nil)
+	return data, getError(errors.Op("Get value from snapshot"), err)
+}
+
+// GetAllWithPrefix returns all entries of the snapshot with the specified prefix
+func (snapshot *Snapshot) GetAllWithPrefix(prefix string) (map[string]string, error) {
+	entries, err := collect(snapshot.snapshot.NewIterator(util.BytesPrefix([]byte(prefix)), nil))
+	if !errors.IsEmpty(err) {
+		return entries, errors.E(errors.Op("Get all with prefix from snapshot"), err)
+	}
+	return entries, nil
+}
+
+// Release releases the snapshot
+func (snapshot *Snapshot) Release() {
+	snapshot.snapshot.Release()
+}
+
+// Has checks whether the key exists, including the writes of the transaction
+func (transaction *Transaction) Has(key []byte) (bool, error) {
+	return transaction.transaction.Has(key, nil)
+}
+
+// Get fetches the data of a key, including the writes of the transaction
+func (transaction *Transaction) Get(key []byte) ([]byte, error) {
+	data, err := transaction.transaction.Get(key, nil)
+	return data, getError(errors.Op("Get value in transaction"), err)
+}
+
+// GetAllWithPrefix returns all entries with the specified prefix, including the writes of the transaction
+func (transaction *Transaction) GetAllWithPrefix(prefix string) (map[string]string, error) {
+	entries, err := collect(transaction.transaction.NewIterator(util.BytesPrefix([]byte(prefix)), nil))
+	if !errors.IsEmpty(err) {
+		return entries, errors.E(errors.Op("Get all with prefix in transaction"), err)
+	}
+	return entries, nil
+}
+
+// Put writes data in the transaction
+func (transaction *Transaction) Put(key []byte, data []byte) error {
+	return transaction.transaction.Put(key, data, nil)
+}
+
+// Delete removes a key in the transaction
+func (transaction *Transaction) Delete(key []byte) error {
+	return transaction.transaction.Delete(key, nil)
+}
+
+// Commit applies the writes of the transaction to LevelDB atomically
+func (transaction *Transaction) Commit() error {
+	err := transaction.transaction.Commit()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Commit transaction"), err)
+	}
+	return nil
+}
+
+// Discard drops the writes of the transaction
+func (transaction *Transaction) Discard() {
+	transaction.transaction.Discard()
+}
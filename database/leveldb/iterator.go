@@ -0,0 +1,121 @@
+package leveldb
+
+import (
+	"bytes"
+
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	util "github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Iterator walks over a range of LevelDB in either direction
+type Iterator struct {
+	iter    iterator.Iterator
+	reverse bool
+	limit   int
+	count   int
+	started bool
+	valid   bool
+}
+
+// keyRange returns the range of keys that match both the prefix and the bounds of the options
+func keyRange(options *interfaces.IteratorOptions) *util.Range {
+	slice := &util.Range{}
+	if len(options.Prefix) > 0 {
+		slice = util.BytesPrefix(options.Prefix)
+	}
+	if options.Start != nil && bytes.Compare(options.Start, slice.Start) > 0 {
+		slice.Start = options.Start
+	}
+	if options.End != nil && (slice.Limit == nil || bytes.Compare(options.End, slice.Limit) < 0) {
+		slice.Limit = options.End
+	}
+	return slice
+}
+
+// NewIterator returns an iterator over the entries matching the options
+func (storage *Storage) NewIterator(options *interfaces.IteratorOptions) interfaces.Iterator {
+	if options == nil {
+		options = &interfaces.IteratorOptions{}
+	}
+	return &Iterator{
+		iter:    storage.db.NewIterator(keyRange(options), nil),
+		reverse: options.Reverse,
+		limit:   options.Limit,
+	}
+}
+
+func (it *Iterator) limitReached() bool {
+	return it.limit > 0 && it.count >= it.limit
+}
+
+// Next moves to the next entry in the direction of the iterator
+func (it *Iterator) Next() bool {
+	if it.limitReached() {
+		it.valid = false
+		return false
+	}
+	switch {
+	case !it.started && it.reverse:
+		it.valid = it.iter.Last()
+	case !it.started:
+		it.valid = it.iter.First()
+	case it.reverse:
+		it.valid = it.iter.Prev()
+	default:
+		it.valid = it.iter.Next()
+	}
+	it.started = true
+	if it.valid {
+		it.count++
+	}
+	return it.valid
+}
+
+// Seek moves to the first entry at or after key, or at or before key in reverse
+func (it *Iterator) Seek(key []byte) bool {
+	if it.limitReached() {
+		it.valid = false
+		return false
+	}
+	it.valid = it.iter.Seek(key)
+	if it.reverse {
+		if !it.valid {
+			it.valid = it.iter.Last()
+		} else if bytes.Compare(it.iter.Key(), key) > 0 {
+			it.valid = it.iter.Prev()
+		}
+	}
+	it.started = true
+	if it.valid {
+		it.count++
+	}
+	return it.valid
+}
+
+// Key returns the key of the current entry
+func (it *Iterator) Key() []byte {
+	if !it.valid {
+		return nil
+	}
+	return it.iter.Key()
+}
+
+// Value returns the value of the current entry
+func (it *Iterator) Value() []byte {
+	if !it.valid {
+		return nil
+	}
+	return it.iter.Value()
+}
+
+// Error returns the error that stopped the iteration, if any
+func (it *Iterator) Error() error {
+	return it.iter.Error()
+}
+
+// Release releases the underlying LevelDB iterator
+func (it *Iterator) Release() {
+	it.valid = false
+	it.iter.Release()
+}
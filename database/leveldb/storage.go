@@ -38,7 +38,19 @@ func (storage *Storage) Has(key []byte) (bool, error) {
 
 // Get uses LevelDB's method Get to fetch data from LevelDB
 func (storage *Storage) Get(key []byte) ([]byte, error) {
-	return storage.db.Get(key, nil)
+	data, err := storage.db.Get(key, nil)
+	return data, getError(errors.Op("Get value from LevelDB"), err)
+}
+
+// getError maps the not found error of LevelDB to the NotFound kind the other storages return
+func getError(op errors.Op, err error) error {
+	if err == leveldb.ErrNotFound {
+		return errors.E(op, errors.NotFound, "key not found")
+	}
+	if !errors.IsEmpty(err) {
+		return errors.E(op, err)
+	}
+	return nil
 }
 
 // Put uses LevelDB's Put method to put data into LevelDB
@@ -87,35 +99,35 @@ func (storage *Storage) GetAllWithPrefix(prefix string) (map[string]string, erro
 	return entries, err
 }
 
-// DeleteAll deletes all entries from the database
+// DeleteAll deletes all entries from the database in a single batch
 // USE CAREFULLY
 func (storage *Storage) DeleteAll() error {
-	iter := storage.db.NewIterator(nil, nil)
-
-	// Iterate over every key in the database, append to entries
-	for iter.Next() {
-		key := iter.Key()
-		err = errors.E(errors.Op("Delete from storage"), storage.Delete(key))
-	}
-
-	iter.Release()
-	err = errors.E(errors.Op("Delete all from storage"), iter.Error())
-
-	return err
+	return storage.deleteRange(nil, errors.Op("Delete all from storage"))
 }
 
-// DeleteAllWithPrefix deletes all entries starting with a prefix
+// DeleteAllWithPrefix deletes all entries starting with a prefix in a single batch
 func (storage *Storage) DeleteAllWithPrefix(prefix string) error {
-	iter := storage.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	return storage.deleteRange(util.BytesPrefix([]byte(prefix)), errors.Op("Delete all with prefix from storage"))
+}
 
-	// Iterate over every key in the database, append to entries
+// deleteRange deletes every key in a range atomically
+func (storage *Storage) deleteRange(slice *util.Range, op errors.Op) error {
+	batch := new(leveldb.Batch)
+	iter := storage.db.NewIterator(slice, nil)
+
+	// Iterate over every key in the range, append to the batch
 	for iter.Next() {
-		key := iter.Key()
-		err = errors.E(errors.Op("Delete with prefix from storage"), storage.Delete(key))
+		batch.Delete(iter.Key())
 	}
 
 	iter.Release()
-	err = errors.E(errors.Op("Delete all with prefix from storage"), iter.Error())
-
-	return err
+	err := iter.Error()
+	if !errors.IsEmpty(err) {
+		return errors.E(op, err)
+	}
+	err = storage.db.Write(batch, nil)
+	if !errors.IsEmpty(err) {
+		return errors.E(op, err)
+	}
+	return nil
 }
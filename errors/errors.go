@@ -22,6 +22,12 @@ var debug = false
 const (
 	Ignore Kind = iota //Unclassified
 	Placeholder
+	AlreadyExists // Item already exists
+	NotFound      // Item does not exist
+	Invalid       // Invalid data received from a peer or a user
+	Unavailable   // Resource temporarily unavailable, try again later
+	Unsupported   // Protocol version or feature not supported
+	Denied        // Operation not permitted on this node
 )
 
 func (e *Error) isZero() bool {
@@ -38,6 +44,18 @@ func (k Kind) String() string {
 		return "ignored kind of error"
 	case Placeholder:
 		return "placeholder error"
+	case AlreadyExists:
+		return "item already exists"
+	case NotFound:
+		return "item does not exist"
+	case Invalid:
+		return "invalid data"
+	case Unavailable:
+		return "temporarily unavailable"
+	case Unsupported:
+		return "unsupported version or feature"
+	case Denied:
+		return "permission denied"
 	}
 	return "unknown error kind"
 }
@@ -90,6 +108,22 @@ func IsEmpty(err error) bool{
 	}
 }
 
+// Is reports whether err is an *Error of the given Kind.
+// If err has no Kind, Is checks the errors it wraps.
+func Is(kind Kind, err error) bool {
+	e, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+	if e.Kind != Ignore {
+		return e.Kind == kind
+	}
+	if e.Err != nil {
+		return Is(kind, e.Err)
+	}
+	return false
+}
+
 // pad appends str to the buffer if the buffer already has some data.
 func pad(b *bytes.Buffer, str string) {
 	if b.Len() == 0 {
@@ -49,6 +49,21 @@ func TestIsEmpty(t *testing.T) {
 	assert.True(t, IsEmpty(e8))
 }
 
+func TestIs(t *testing.T) {
+	e1 := E(testOpGet, NotFound, testStringNetworkUnreachable)
+	e2 := E(testOpSet, e1)
+	e3 := E(testOpSet, AlreadyExists, e1)
+	e4 := errors.New(testStringNetworkUnreachable)
+
+	assert.True(t, Is(NotFound, e1))
+	assert.True(t, Is(NotFound, e2))
+	assert.False(t, Is(NotFound, e3))
+	assert.True(t, Is(AlreadyExists, e3))
+	assert.False(t, Is(NotFound, e4))
+	assert.False(t, Is(NotFound, nil))
+	assert.False(t, Is(NotFound, E(testOpGet)))
+}
+
 func TestContent(t *testing.T) {
 	e1 := E(testOpGet, Placeholder, testStringNetworkUnreachable)
 	e2 := E(testOpSet, Placeholder, e1)
@@ -27,14 +27,13 @@ func storeKeyPair(storage interfaces.Storage, privateKey crypto.PrivKey, publicK
 		return errors.E(errors.Op("Marshal Public Key"), err)
 	}
 
-	err = storage.Put([]byte(privateKeyDbKey), privateKeyBytes)
+	// Store both keys at once, so a crash can't leave half of the key pair behind
+	batch := storage.NewBatch()
+	batch.Put([]byte(privateKeyDbKey), privateKeyBytes)
+	batch.Put([]byte(publicKeyDbKey), publicKeyBytes)
+	err = batch.Commit()
 	if !errors.IsEmpty(err) {
-		return errors.E(errors.Op("Store Private Key"), err)
-	}
-
-	err = storage.Put([]byte(publicKeyDbKey), publicKeyBytes)
-	if !errors.IsEmpty(err) {
-		return errors.E(errors.Op("Store Public Key"), err)
+		return errors.E(errors.Op("Store Key Pair"), err)
 	}
 
 	return nil
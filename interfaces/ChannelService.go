@@ -14,4 +14,8 @@ type ChannelService interface {
 	Leave(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.GenericResponse, error)
 	GetChannel(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.Channel, error)
 	GetAllChannels(ctx context.Context, in *pb.Empty) (*pb.ChannelListResponse, error)
+	GetChannelPeers(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.PeerListResponse, error)
+	GetChannelStats(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.ChannelStats, error)
+	DiscoverChannels(ctx context.Context, in *pb.Empty) (*pb.ChannelDirectoryResponse, error)
+	Moderate(ctx context.Context, in *pb.ModerationRequest) (*pb.GenericResponse, error)
 }
@@ -7,4 +7,5 @@ type Config interface {
 	GetString(variable string) string
 	GetUint(variable string) uint
 	GetBool(variable string) bool
+	GetStringSlice(variable string) []string
 }
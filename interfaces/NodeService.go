@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/pb"
+)
+
+// NodeService is an interface to the Node endpoints in sprawl.proto
+type NodeService interface {
+	RegisterP2p(p2p P2p)
+	BanPeer(ctx context.Context, in *pb.PeerSpecificRequest) (*pb.GenericResponse, error)
+	UnbanPeer(ctx context.Context, in *pb.PeerSpecificRequest) (*pb.GenericResponse, error)
+	ListBannedPeers(ctx context.Context, in *pb.Empty) (*pb.PeerListResponse, error)
+	GetConnectionStats(ctx context.Context, in *pb.Empty) (*pb.ConnectionStats, error)
+	GetNodeInfo(ctx context.Context, in *pb.Empty) (*pb.NodeInfo, error)
+	ListPeers(ctx context.Context, in *pb.Empty) (*pb.PeerInfoListResponse, error)
+	ConnectPeer(ctx context.Context, in *pb.ConnectPeerRequest) (*pb.GenericResponse, error)
+	DisconnectPeer(ctx context.Context, in *pb.PeerSpecificRequest) (*pb.GenericResponse, error)
+	ListSubscriptions(ctx context.Context, in *pb.Empty) (*pb.SubscriptionListResponse, error)
+	GetNATStatus(ctx context.Context, in *pb.Empty) (*pb.NATStatus, error)
+}
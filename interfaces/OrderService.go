@@ -11,10 +11,15 @@ type OrderService interface {
 	RegisterStorage(db Storage)
 	RegisterP2p(p2p P2p)
 	Create(ctx context.Context, in *pb.CreateRequest) (*pb.CreateResponse, error)
-	Receive(in []byte) error
+	Receive(in []byte, from string) error
+	Validate(in []byte, from string) error
+	ValidateBatch(in [][]byte, from string) []error
 	Delete(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.GenericResponse, error)
 	Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.GenericResponse, error)
 	Unlock(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.GenericResponse, error)
 	GetOrder(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Order, error)
 	GetAllOrders(ctx context.Context, in *pb.Empty) (*pb.OrderListResponse, error)
+	QueryOrders(ctx context.Context, in *pb.OrderQuery) (*pb.OrderListResponse, error)
+	Take(ctx context.Context, in *pb.TakeRequest) (*pb.TakeResponse, error)
+	Negotiate(request *pb.NegotiationMessage, from string, respond func(*pb.NegotiationMessage) error) error
 }
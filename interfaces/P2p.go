@@ -1,15 +1,35 @@
 package interfaces
 
 import (
+	"context"
+
 	"github.com/sprawl/sprawl/pb"
 )
 
 type P2p interface {
 	RegisterOrderService(orders OrderService)
 	RegisterChannelService(channels ChannelService)
-	Send(message *pb.WireMessage)
-	Subscribe(channel *pb.Channel)
-	Unsubscribe(channel *pb.Channel)
-	Run()
+	Send(message *pb.WireMessage) error
+	Subscribe(channel *pb.Channel) error
+	Unsubscribe(channel *pb.Channel) error
+	GetChannelPeers(channel *pb.Channel) []string
+	GetChannelStats(channel *pb.Channel) *pb.ChannelStats
+	DiscoverChannels() []*pb.DirectoryEntry
+	Sign(data []byte) ([]byte, error)
+	GetPublicKey() ([]byte, error)
+	GetPeerID() string
+	ReadOnly() bool
+	BanPeer(peerID string) error
+	UnbanPeer(peerID string) error
+	GetBannedPeers() []string
+	GetConnectionStats() *pb.ConnectionStats
+	GetNodeInfo() *pb.NodeInfo
+	GetPeers() []*pb.PeerInfo
+	ConnectPeer(ctx context.Context, address string) error
+	DisconnectPeer(peerID string) error
+	GetSubscriptionInfo() []*pb.Subscription
+	GetNATStatus() *pb.NATStatus
+	RequestTake(ctx context.Context, creator string, request *pb.NegotiationMessage) (*pb.NegotiationMessage, error)
+	Run() error
 	Close()
 }
@@ -1,6 +1,7 @@
 package interfaces
 
-// Storage defines a database interface that works with Sprawl
+// Storage defines a database interface that works with Sprawl. Getting a missing key returns a NotFound error
+// from the Storage, its snapshots and its transactions alike.
 type Storage interface {
 	SetDbPath(dbPath string)
 	Run() error
@@ -13,6 +14,70 @@ type Storage interface {
 	GetAllWithPrefix(prefix string) (map[string]string, error)
 	DeleteAll() error
 	DeleteAllWithPrefix(prefix string) error
+	NewIterator(options *IteratorOptions) Iterator
+	NewBatch() Batch
+	GetSnapshot() (Snapshot, error)
+	OpenTransaction() (Transaction, error)
+}
+
+// IteratorOptions narrow down the entries an Iterator walks over. Nil options iterate the whole Storage.
+type IteratorOptions struct {
+	// Prefix limits the iteration to keys starting with it
+	Prefix []byte
+	// Start is the inclusive lower bound of the keys
+	Start []byte
+	// End is the exclusive upper bound of the keys
+	End []byte
+	// Reverse walks from the last key down to the first one
+	Reverse bool
+	// Limit is the maximum amount of entries returned, zero meaning no limit
+	Limit int
+}
+
+// Iterator walks over the entries of Storage in key order, without loading them all in memory.
+// The key and the value are only valid until the next call to Next or Seek, and must be copied to be kept.
+// An iterator must be released once it's no longer needed.
+type Iterator interface {
+	// Next moves to the next entry, returning false when there are no more entries
+	Next() bool
+	// Seek moves to the first entry at or after key, or at or before key when iterating in reverse.
+	// It returns whether such an entry exists.
+	Seek(key []byte) bool
+	Key() []byte
+	Value() []byte
+	Error() error
+	Release()
+}
+
+// Batch collects writes that are applied to Storage atomically on Commit.
+// A discarded batch is emptied and can be reused.
+type Batch interface {
+	Put(key []byte, data []byte)
+	Delete(key []byte)
+	Len() int
+	Commit() error
+	Discard()
+}
+
+// Snapshot is a read-only view of Storage at the moment it was taken.
+// It must be released once it's no longer needed.
+type Snapshot interface {
+	Has(key []byte) (bool, error)
+	Get(key []byte) ([]byte, error)
+	GetAllWithPrefix(prefix string) (map[string]string, error)
+	Release()
+}
+
+// Transaction reads and writes Storage, seeing its own writes, which are applied atomically on Commit.
+// Other writes to Storage block until the transaction is committed or discarded.
+type Transaction interface {
+	Has(key []byte) (bool, error)
+	Get(key []byte) ([]byte, error)
+	GetAllWithPrefix(prefix string) (map[string]string, error)
+	Put(key []byte, data []byte) error
+	Delete(key []byte) error
+	Commit() error
+	Discard()
 }
 
 // Prefix is a type used to prefix all entries in Storage
@@ -21,6 +86,16 @@ type Prefix string
 const (
 	// OrderPrefix is the prefix used to signify all orders in Storage
 	OrderPrefix Prefix = "order-"
+	// OrderIndexPrefix is the prefix used to signify all secondary index entries of orders
+	OrderIndexPrefix Prefix = "orderindex-"
 	// ChannelPrefix is the prefix used to signify all channels in Storage
 	ChannelPrefix Prefix = "channel-"
+	// BanPrefix is the prefix used to signify all peers banned from channels by channel admins
+	BanPrefix Prefix = "ban-"
+	// ModerationPrefix is the prefix used to signify the sequence numbers of the last moderation messages of channel admins
+	ModerationPrefix Prefix = "moderation-"
+	// DeniedPeerPrefix is the prefix used to signify all peers banned from connecting to this node
+	DeniedPeerPrefix Prefix = "deniedpeer-"
+	// KnownPeerPrefix is the prefix used to signify all recently connected peers and their addresses
+	KnownPeerPrefix Prefix = "peer-"
 )
@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/sprawl/sprawl/app"
 	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/errors"
 	"go.uber.org/zap"
 )
 
@@ -20,6 +21,9 @@ func init() {
 
 func main() {
 	app := &app.App{}
-	app.InitServices(appConfig, log)
+	err := app.InitServices(appConfig, log)
+	if !errors.IsEmpty(err) {
+		log.Fatal(err)
+	}
 	app.Run()
 }
@@ -0,0 +1,296 @@
+package p2p
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// maxBatchSize is the number of messages after which a batch is published without waiting for its window to end
+const maxBatchSize = 100
+
+// maxBatchBytes limits the decompressed size of a batch, which pubsub doesn't limit like it does messages
+const maxBatchBytes = 1 << 20
+
+// channelBatch collects the messages sent to a channel during its batching window
+type channelBatch struct {
+	channelID   []byte
+	window      time.Duration
+	compression pb.Compression
+	pending     []*outboundMessage
+	timer       *time.Timer
+}
+
+// take returns the pending messages of the batch and starts a new one
+func (batch *channelBatch) take() []*outboundMessage {
+	if batch.timer != nil {
+		batch.timer.Stop()
+		batch.timer = nil
+	}
+	pending := batch.pending
+	batch.pending = nil
+	return pending
+}
+
+// batchManager keeps the batches of the subscribed channels that have a batching window.
+// All of its methods are safe to call concurrently.
+type batchManager struct {
+	sync.Mutex
+	batches map[string]*channelBatch
+}
+
+func newBatchManager() *batchManager {
+	return &batchManager{batches: make(map[string]*channelBatch)}
+}
+
+// open starts batching the messages sent to a channel if its options set a batching window
+func (manager *batchManager) open(channel *pb.Channel) {
+	window := time.Duration(channel.GetOptions().GetBatchWindow()) * time.Millisecond
+	if window <= 0 {
+		return
+	}
+	manager.Lock()
+	defer manager.Unlock()
+	manager.batches[string(channel.GetId())] = &channelBatch{
+		channelID:   channel.GetId(),
+		window:      window,
+		compression: channel.GetOptions().GetCompression(),
+	}
+}
+
+// close stops batching the messages of a channel, returning the messages still waiting to be published
+func (manager *batchManager) close(channelID string) (*channelBatch, []*outboundMessage) {
+	manager.Lock()
+	defer manager.Unlock()
+	batch, ok := manager.batches[channelID]
+	if !ok {
+		return nil, nil
+	}
+	delete(manager.batches, channelID)
+	return batch, batch.take()
+}
+
+// closeAll stops batching on every channel, returning the messages still waiting to be published
+func (manager *batchManager) closeAll() []*outboundMessage {
+	manager.Lock()
+	defer manager.Unlock()
+	pending := make([]*outboundMessage, 0)
+	for _, batch := range manager.batches {
+		pending = append(pending, batch.take()...)
+	}
+	manager.batches = make(map[string]*channelBatch)
+	return pending
+}
+
+// flush takes the pending messages of a channel's batch
+func (manager *batchManager) flush(channelID string) (*channelBatch, []*outboundMessage) {
+	manager.Lock()
+	defer manager.Unlock()
+	batch, ok := manager.batches[channelID]
+	if !ok {
+		return nil, nil
+	}
+	return batch, batch.take()
+}
+
+// batch adds a message to the batch of its channel, publishing the batch once its window ends or it's full.
+// The batching window is returned, so the sender can wait for it on top of the time publishing takes.
+// Messages sent to channels without a batching window aren't batched, which is told by returning false.
+func (p2p *P2p) batch(outbound *outboundMessage) (time.Duration, bool) {
+	p2p.batches.Lock()
+	defer p2p.batches.Unlock()
+	channelID := string(outbound.message.GetChannelID())
+	batch, ok := p2p.batches.batches[channelID]
+	if !ok {
+		return 0, false
+	}
+	batch.pending = append(batch.pending, outbound)
+	if len(batch.pending) >= maxBatchSize {
+		p2p.flushBatch(batch, batch.take())
+	} else if batch.timer == nil {
+		batch.timer = time.AfterFunc(batch.window, func() {
+			p2p.flushBatch(p2p.batches.flush(channelID))
+		})
+	}
+	return batch.window, true
+}
+
+// flushBatch publishes the pending messages of a batch in a goroutine that Close waits for.
+// If the node is closing, the senders are told the messages weren't published instead.
+func (p2p *P2p) flushBatch(batch *channelBatch, pending []*outboundMessage) {
+	if len(pending) == 0 {
+		return
+	}
+	started := p2p.spawn(func() {
+		p2p.publishBatch(batch, pending)
+	})
+	if !started {
+		failBatch(pending, errors.E(errors.Op("Publish batch"), errors.Unavailable, "p2p closed before the batch was published"))
+	}
+}
+
+// failBatch reports an error to the senders of the pending messages of a batch
+func failBatch(pending []*outboundMessage, err error) {
+	for _, outbound := range pending {
+		select {
+		case outbound.result <- err:
+		default:
+		}
+	}
+}
+
+// publishBatch queues a batch envelope carrying the pending messages that haven't been abandoned by their senders,
+// and reports its result to each of them
+func (p2p *P2p) publishBatch(batch *channelBatch, pending []*outboundMessage) {
+	if len(pending) == 0 {
+		return
+	}
+	claimed := make([]*outboundMessage, 0, len(pending))
+	for _, outbound := range pending {
+		if outbound.claim() {
+			claimed = append(claimed, outbound)
+		}
+	}
+	pending = claimed
+	if len(pending) == 0 {
+		return
+	}
+	messages := make([][]byte, 0, len(pending))
+	for _, outbound := range pending {
+		buf, err := proto.Marshal(outbound.message)
+		if !errors.IsEmpty(err) {
+			outbound.result <- errors.E(errors.Op("Marshal proto"), err)
+			continue
+		}
+		messages = append(messages, buf)
+	}
+
+	envelope, err := encodeBatch(batch.channelID, batch.compression, messages)
+	if errors.IsEmpty(err) {
+		outbound := &outboundMessage{message: envelope, result: make(chan error, 1)}
+		closed := p2p.ctx.Done()
+		select {
+		case <-closed:
+			err = errors.E(errors.Op("Publish batch"), errors.Unavailable, "p2p closed before the batch was published")
+		case p2p.input <- outbound:
+			select {
+			case err = <-outbound.result:
+			case <-closed:
+				err = errors.E(errors.Op("Publish batch"), errors.Unavailable, "p2p closed before the batch was published")
+			}
+		default:
+			p2p.queueCounters.add(&p2p.queueCounters.rejected)
+			err = errors.E(errors.Op("Publish batch"), errors.Unavailable, "outbound queue is full")
+		}
+	}
+	if errors.IsEmpty(err) {
+		p2p.getStats(string(batch.channelID)).addSent(len(messages))
+		if p2p.Logger != nil {
+			p2p.Logger.Debugf("Published a batch of %d messages to channel %s", len(messages), batch.channelID)
+		}
+	}
+	failBatch(pending, err)
+}
+
+// encodeBatch packs marshalled wire messages into a batch envelope, compressing them if asked to
+func encodeBatch(channelID []byte, compression pb.Compression, messages [][]byte) (*pb.WireMessage, error) {
+	data, err := proto.Marshal(&pb.WireBatch{Messages: messages})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Encode batch"), err)
+	}
+	switch compression {
+	case pb.Compression_NO_COMPRESSION:
+	case pb.Compression_SNAPPY:
+		data = snappy.Encode(nil, data)
+	default:
+		return nil, errors.E(errors.Op("Encode batch"), errors.Unsupported, "unknown compression "+compression.String())
+	}
+	return &pb.WireMessage{
+		ChannelID:   channelID,
+		Operation:   pb.Operation_BATCH,
+		Data:        data,
+		Version:     batchWireVersion,
+		Compression: compression,
+	}, nil
+}
+
+// decodeBatch unpacks the marshalled wire messages of a batch envelope
+func decodeBatch(envelope *pb.WireMessage) ([][]byte, error) {
+	data := envelope.GetData()
+	switch envelope.GetCompression() {
+	case pb.Compression_NO_COMPRESSION:
+	case pb.Compression_SNAPPY:
+		length, err := snappy.DecodedLen(data)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Decode batch"), errors.Invalid, err)
+		}
+		if length > maxBatchBytes {
+			return nil, errors.E(errors.Op("Decode batch"), errors.Invalid, fmt.Sprintf("batch of %d bytes is too large", length))
+		}
+		data, err = snappy.Decode(nil, data)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Decode batch"), errors.Invalid, err)
+		}
+	default:
+		return nil, errors.E(errors.Op("Decode batch"), errors.Invalid, "unknown compression "+envelope.GetCompression().String())
+	}
+	batch := &pb.WireBatch{}
+	err := proto.Unmarshal(data, batch)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Decode batch"), errors.Invalid, err)
+	}
+	return batch.GetMessages(), nil
+}
+
+// checkWireMessage checks that a wire message is of a version this node understands and belongs to the channel
+func checkWireMessage(channel *pb.Channel, wireMessage *pb.WireMessage) error {
+	if !supportsVersion(wireMessage.GetVersion()) {
+		return errors.E(errors.Op("Check message"), errors.Unsupported, fmt.Sprintf("unsupported wire version %d", wireMessage.GetVersion()))
+	}
+	if string(wireMessage.GetChannelID()) != string(channel.GetId()) {
+		return errors.E(errors.Op("Check message"), errors.Invalid, "message was published to the wrong channel")
+	}
+	return nil
+}
+
+// unpackMessage returns the marshalled wire messages carried by the data of a pubsub message:
+// the wire message itself, or the messages of a batch envelope, which is told by batched. Batches can't be nested.
+func unpackMessage(channel *pb.Channel, data []byte) (messages [][]byte, batched bool, err error) {
+	wireMessage := &pb.WireMessage{}
+	err = proto.Unmarshal(data, wireMessage)
+	if !errors.IsEmpty(err) {
+		return nil, false, errors.E(errors.Op("Unmarshal wiremessage proto"), errors.Invalid, err)
+	}
+	err = checkWireMessage(channel, wireMessage)
+	if !errors.IsEmpty(err) {
+		return nil, false, err
+	}
+	if wireMessage.GetOperation() != pb.Operation_BATCH {
+		return [][]byte{data}, false, nil
+	}
+
+	messages, err = decodeBatch(wireMessage)
+	if !errors.IsEmpty(err) {
+		return nil, true, err
+	}
+	for _, message := range messages {
+		batched := &pb.WireMessage{}
+		err = proto.Unmarshal(message, batched)
+		if !errors.IsEmpty(err) {
+			return nil, true, errors.E(errors.Op("Unmarshal batched wiremessage proto"), errors.Invalid, err)
+		}
+		if batched.GetOperation() == pb.Operation_BATCH {
+			return nil, true, errors.E(errors.Op("Unpack batch"), errors.Invalid, "batches can't be nested")
+		}
+		err = checkWireMessage(channel, batched)
+		if !errors.IsEmpty(err) {
+			return nil, true, errors.E(errors.Op("Unpack batch"), errors.Invalid, err)
+		}
+	}
+	return messages, true, nil
+}
@@ -0,0 +1,209 @@
+package p2p
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const batchTestWindow = 200
+
+func createWireMessage(t *testing.T, channelID []byte, operation pb.Operation) []byte {
+	testOrderInBytes, err := proto.Marshal(testOrder)
+	assert.NoError(t, err)
+	data, err := proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: operation, Data: testOrderInBytes, Version: messageWireVersion})
+	assert.NoError(t, err)
+	return data
+}
+
+func TestBatchEncoding(t *testing.T) {
+	messages := [][]byte{
+		createWireMessage(t, testChannel.GetId(), pb.Operation_CREATE),
+		createWireMessage(t, testChannel.GetId(), pb.Operation_DELETE),
+	}
+	for _, compression := range []pb.Compression{pb.Compression_NO_COMPRESSION, pb.Compression_SNAPPY} {
+		envelope, err := encodeBatch(testChannel.GetId(), compression, messages)
+		assert.NoError(t, err)
+		assert.Equal(t, pb.Operation_BATCH, envelope.GetOperation())
+		assert.Equal(t, batchWireVersion, envelope.GetVersion())
+
+		data, err := proto.Marshal(envelope)
+		assert.NoError(t, err)
+		unpacked, _, err := unpackMessage(testChannel, data)
+		assert.NoError(t, err)
+		assert.Equal(t, messages, unpacked)
+	}
+
+	// Single messages are passed on as they are
+	unpacked, _, err := unpackMessage(testChannel, messages[0])
+	assert.NoError(t, err)
+	assert.Equal(t, messages[:1], unpacked)
+
+	// Batches can't be nested or carry messages of other channels
+	nested, err := encodeBatch(testChannel.GetId(), pb.Compression_NO_COMPRESSION, [][]byte{createWireMessage(t, testChannel.GetId(), pb.Operation_BATCH)})
+	assert.NoError(t, err)
+	data, err := proto.Marshal(nested)
+	assert.NoError(t, err)
+	_, _, err = unpackMessage(testChannel, data)
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	foreign, err := encodeBatch(testChannel.GetId(), pb.Compression_SNAPPY, [][]byte{createWireMessage(t, []byte("otherChannel"), pb.Operation_CREATE)})
+	assert.NoError(t, err)
+	data, err = proto.Marshal(foreign)
+	assert.NoError(t, err)
+	_, _, err = unpackMessage(testChannel, data)
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	corrupted := &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_BATCH, Data: []byte("garbage"), Version: batchWireVersion, Compression: pb.Compression_SNAPPY}
+	data, err = proto.Marshal(corrupted)
+	assert.NoError(t, err)
+	_, _, err = unpackMessage(testChannel, data)
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
+
+func TestBatchedSend(t *testing.T) {
+	p2pInstance := newTestP2p(t, initTestPubSub(t))
+	defer p2pInstance.Close()
+	p2pInstance.startInputLoop()
+
+	batchedChannel := &pb.Channel{Id: []byte("batchedChannel"), Options: &pb.ChannelOptions{BatchWindow: batchTestWindow, Compression: pb.Compression_SNAPPY}}
+	sub, err := p2pInstance.ps.Subscribe(channelTopic(batchedChannel.GetId()))
+	assert.NoError(t, err)
+	p2pInstance.batches.open(batchedChannel)
+
+	testOrderInBytes, err := proto.Marshal(testOrder)
+	assert.NoError(t, err)
+	var wg sync.WaitGroup
+	for _, operation := range []pb.Operation{pb.Operation_CREATE, pb.Operation_LOCK, pb.Operation_UNLOCK} {
+		wg.Add(1)
+		go func(operation pb.Operation) {
+			defer wg.Done()
+			assert.NoError(t, p2pInstance.Send(&pb.WireMessage{ChannelID: batchedChannel.GetId(), Operation: operation, Data: testOrderInBytes}))
+		}(operation)
+	}
+	wg.Wait()
+
+	// Every operation sent during the window is published in a single envelope
+	msg, err := sub.Next(p2pInstance.ctx)
+	assert.NoError(t, err)
+	messages, _, err := unpackMessage(batchedChannel, msg.GetData())
+	assert.NoError(t, err)
+	assert.Len(t, messages, 3)
+	assert.Equal(t, uint64(1), p2pInstance.GetQueueStats().Published)
+	// Every order of the envelope counts as sent, like every order unpacked from it counts as received
+	assert.Equal(t, uint64(3), p2pInstance.GetChannelStats(batchedChannel).GetOrdersSent())
+
+	// Closing the batch publishes the messages still waiting
+	sent := make(chan error, 1)
+	go func() {
+		sent <- p2pInstance.Send(&pb.WireMessage{ChannelID: batchedChannel.GetId(), Operation: pb.Operation_DELETE, Data: testOrderInBytes})
+	}()
+	for {
+		p2pInstance.batches.Lock()
+		pending := len(p2pInstance.batches.batches[string(batchedChannel.GetId())].pending)
+		p2pInstance.batches.Unlock()
+		if pending > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	p2pInstance.flushBatch(p2pInstance.batches.close(string(batchedChannel.GetId())))
+	msg, err = sub.Next(p2pInstance.ctx)
+	assert.NoError(t, err)
+	messages, _, err = unpackMessage(batchedChannel, msg.GetData())
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.NoError(t, <-sent)
+	assert.Equal(t, uint64(4), p2pInstance.GetChannelStats(batchedChannel).GetOrdersSent())
+}
+
+func TestClosePendingBatch(t *testing.T) {
+	p2pInstance := newTestP2p(t, initTestPubSub(t))
+	p2pInstance.startInputLoop()
+
+	batchedChannel := &pb.Channel{Id: []byte("batchedChannel"), Options: &pb.ChannelOptions{BatchWindow: 60000}}
+	p2pInstance.batches.open(batchedChannel)
+	result := make(chan error, 1)
+	go func() {
+		result <- p2pInstance.Send(&pb.WireMessage{ChannelID: batchedChannel.GetId(), Operation: pb.Operation_CREATE})
+	}()
+	for {
+		p2pInstance.batches.Lock()
+		pending := len(p2pInstance.batches.batches[string(batchedChannel.GetId())].pending)
+		p2pInstance.batches.Unlock()
+		if pending > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Messages still waiting for their batch when the node closes are reported as unpublished
+	p2pInstance.Close()
+	assert.True(t, errors.Is(errors.Unavailable, <-result))
+	assert.Empty(t, p2pInstance.batches.batches)
+	assert.Equal(t, uint64(0), p2pInstance.GetQueueStats().Published)
+}
+
+func TestBatchWindowDeadline(t *testing.T) {
+	p2pInstance := newTestP2p(t, initTestPubSub(t))
+	defer p2pInstance.Close()
+
+	// Senders of batched messages are told the window, so windows longer than sendTimeout don't time them out
+	batchedChannel := &pb.Channel{Id: []byte("batchedChannel"), Options: &pb.ChannelOptions{BatchWindow: 2 * uint32(sendTimeout/time.Millisecond)}}
+	p2pInstance.batches.open(batchedChannel)
+	window, batched := p2pInstance.batch(&outboundMessage{message: &pb.WireMessage{ChannelID: batchedChannel.GetId()}, result: make(chan error, 1)})
+	assert.True(t, batched)
+	assert.Equal(t, 2*sendTimeout, window)
+
+	_, batched = p2pInstance.batch(&outboundMessage{message: &pb.WireMessage{ChannelID: testChannel.GetId()}, result: make(chan error, 1)})
+	assert.False(t, batched)
+}
+
+func TestBatchedCreateAndLock(t *testing.T) {
+	p2pInstance := newTestP2p(t, initTestPubSub(t))
+	defer p2pInstance.Close()
+	orders := &service.OrderService{}
+	orders.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	p2pInstance.RegisterOrderService(orders)
+
+	order := &pb.Order{Id: []byte("batchedOrder"), Asset: "ETH", CounterAsset: "BTC", Amount: 1, ChannelID: testChannel.GetId(), Creator: misbehavingPeer.Pretty()}
+	unknown := &pb.Order{Id: []byte("unknownOrder"), Asset: "ETH", CounterAsset: "BTC", Amount: 1, ChannelID: testChannel.GetId(), Creator: misbehavingPeer.Pretty()}
+	messages := make([][]byte, 0)
+	for _, operation := range []pb.Operation{pb.Operation_CREATE, pb.Operation_LOCK, pb.Operation_DELETE} {
+		target := order
+		if operation == pb.Operation_DELETE {
+			target = unknown
+		}
+		orderInBytes, err := proto.Marshal(target)
+		assert.NoError(t, err)
+		message, err := proto.Marshal(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: operation, Data: orderInBytes, Version: messageWireVersion})
+		assert.NoError(t, err)
+		messages = append(messages, message)
+	}
+	envelope, err := encodeBatch(testChannel.GetId(), pb.Compression_NO_COMPRESSION, messages)
+	assert.NoError(t, err)
+	data, err := proto.Marshal(envelope)
+	assert.NoError(t, err)
+
+	// An order created and locked in the same window is valid, and an unknown order doesn't stop the batch
+	msg := &pubsub.Message{Message: &pubsubpb.Message{From: []byte(misbehavingPeer), Data: data, Signature: []byte("signature")}}
+	assert.NoError(t, p2pInstance.checkMessage(testChannel, msg))
+
+	// Received messages are applied in order, skipping the ones that fail
+	p2pInstance.receive(testChannel, data, misbehavingPeer.Pretty())
+	stored, err := orders.GetOrder(p2pInstance.ctx, &pb.OrderSpecificRequest{OrderID: order.GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, stored.GetState())
+	stats := p2pInstance.GetChannelStats(testChannel)
+	assert.Equal(t, uint64(3), stats.GetOrdersReceived())
+	assert.Equal(t, uint64(1), stats.GetMessagesRejected())
+}
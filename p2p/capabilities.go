@@ -0,0 +1,215 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// wireVersion is the newest version of the wire messages published by this node. Messages from
+// minWireVersion up to wireVersion are understood, and messages of other versions are skipped.
+const wireVersion uint32 = 2
+
+// messageWireVersion is the version of single wire messages. They haven't changed since the first
+// version, and keep it so nodes that don't understand batches still receive them.
+const messageWireVersion uint32 = 1
+
+// batchWireVersion is the version of batch envelopes, which older nodes skip
+const batchWireVersion uint32 = 2
+
+// minWireVersion is the oldest wire message version this node understands
+const minWireVersion uint32 = 1
+
+// topicVersion is part of every channel topic. It's bumped on breaking changes to the wire
+// protocol, moving the channels to new topics so incompatible nodes never share them.
+const topicVersion = 1
+
+// capabilitiesProtocol is the stream protocol nodes exchange their supported versions and features on
+const capabilitiesProtocol = protocol.ID("/sprawl/capabilities/1.0.0")
+
+// capabilitiesTimeout defines how long a capabilities exchange may take before the stream is reset
+const capabilitiesTimeout = 10 * time.Second
+
+// Features advertised to other nodes
+const (
+	featureBatching    = "batching"
+	featureDirectory   = "directory"
+	featureModeration  = "moderation"
+	featureNegotiation = "negotiation"
+)
+
+// features lists the features this node supports
+var features = []string{featureBatching, featureDirectory, featureModeration, featureNegotiation}
+
+// channelTopic returns the pubsub topic of a channel
+func channelTopic(channelID []byte) string {
+	return fmt.Sprintf("%sv%d/channel/%s", baseTopic, topicVersion, channelID)
+}
+
+// supportsVersion tells whether this node understands wire messages of a version
+func supportsVersion(version uint32) bool {
+	return minWireVersion <= version && version <= wireVersion
+}
+
+// localCapabilities returns the versions and features supported by this node
+func localCapabilities() *pb.Capabilities {
+	versions := make([]uint32, 0)
+	for version := minWireVersion; version <= wireVersion; version++ {
+		versions = append(versions, version)
+	}
+	return &pb.Capabilities{Versions: versions, Features: features}
+}
+
+// compatible tells whether a peer understands at least one of the wire versions this node does
+func compatible(capabilities *pb.Capabilities) bool {
+	for _, version := range capabilities.GetVersions() {
+		if supportsVersion(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFeature tells whether a feature is listed in the capabilities of a peer
+func hasFeature(capabilities *pb.Capabilities, feature string) bool {
+	for _, supported := range capabilities.GetFeatures() {
+		if supported == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// peerCapabilities holds the capabilities of the peers this node has exchanged them with
+type peerCapabilities struct {
+	sync.RWMutex
+	peers map[peer.ID]*pb.Capabilities
+}
+
+func newPeerCapabilities() *peerCapabilities {
+	return &peerCapabilities{peers: make(map[peer.ID]*pb.Capabilities)}
+}
+
+func (known *peerCapabilities) set(id peer.ID, capabilities *pb.Capabilities) {
+	known.Lock()
+	defer known.Unlock()
+	known.peers[id] = capabilities
+}
+
+func (known *peerCapabilities) get(id peer.ID) (*pb.Capabilities, bool) {
+	known.RLock()
+	defer known.RUnlock()
+	capabilities, ok := known.peers[id]
+	return capabilities, ok
+}
+
+func (known *peerCapabilities) remove(id peer.ID) {
+	known.Lock()
+	defer known.Unlock()
+	delete(known.peers, id)
+}
+
+// initCapabilities starts answering capabilities exchanges, and exchanges capabilities with every connected peer
+func (p2p *P2p) initCapabilities() {
+	p2p.host.SetStreamHandler(capabilitiesProtocol, p2p.gated(p2p.handleCapabilities))
+	p2p.host.Network().Notify(&network.NotifyBundle{
+		ConnectedF: func(n network.Network, conn network.Conn) {
+			id := conn.RemotePeer()
+			if _, ok := p2p.capabilities.get(id); ok || !p2p.gater.accepts(id) {
+				return
+			}
+			p2p.spawn(func() {
+				_, err := p2p.exchangeCapabilities(p2p.ctx, id)
+				if !errors.IsEmpty(err) && p2p.Logger != nil {
+					p2p.Logger.Debugf("Peer %s didn't exchange capabilities: %s", id, err)
+				}
+			})
+		},
+		DisconnectedF: func(n network.Network, conn network.Conn) {
+			if len(n.ConnsToPeer(conn.RemotePeer())) == 0 {
+				p2p.capabilities.remove(conn.RemotePeer())
+			}
+		},
+	})
+}
+
+// storeCapabilities remembers the capabilities of a peer, warning about peers this node can't talk to
+func (p2p *P2p) storeCapabilities(id peer.ID, capabilities *pb.Capabilities) {
+	p2p.capabilities.set(id, capabilities)
+	if !compatible(capabilities) && p2p.Logger != nil {
+		p2p.Logger.Warnf("Peer %s speaks wire versions %v, which this node doesn't understand", id, capabilities.GetVersions())
+	}
+}
+
+// handleCapabilities answers a capabilities exchange with the capabilities of this node
+func (p2p *P2p) handleCapabilities(stream network.Stream) {
+	defer stream.Close()
+	stream.SetDeadline(time.Now().Add(capabilitiesTimeout))
+
+	remote := &pb.Capabilities{}
+	err := readFramedMessage(bufio.NewReader(stream), remote)
+	if !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Warn(errors.E(errors.Op("Handle capabilities"), err))
+		}
+		stream.Reset()
+		return
+	}
+	p2p.storeCapabilities(stream.Conn().RemotePeer(), remote)
+
+	err = writeFramedMessage(stream, localCapabilities())
+	if !errors.IsEmpty(err) && p2p.Logger != nil {
+		p2p.Logger.Warn(errors.E(errors.Op("Handle capabilities"), err))
+	}
+}
+
+// exchangeCapabilities sends the capabilities of this node to a peer and stores the capabilities it answers with
+func (p2p *P2p) exchangeCapabilities(ctx context.Context, id peer.ID) (*pb.Capabilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, capabilitiesTimeout)
+	defer cancel()
+	stream, err := p2p.host.NewStream(ctx, id, capabilitiesProtocol)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Open capabilities stream"), err)
+	}
+	defer stream.Close()
+	stream.SetDeadline(time.Now().Add(capabilitiesTimeout))
+
+	err = writeFramedMessage(stream, localCapabilities())
+	if !errors.IsEmpty(err) {
+		stream.Reset()
+		return nil, err
+	}
+	remote := &pb.Capabilities{}
+	err = readFramedMessage(bufio.NewReader(stream), remote)
+	if !errors.IsEmpty(err) {
+		stream.Reset()
+		return nil, err
+	}
+	p2p.storeCapabilities(id, remote)
+	return remote, nil
+}
+
+// GetPeerCapabilities returns the wire versions and features supported by a peer,
+// exchanging capabilities with it if that hasn't been done yet
+func (p2p *P2p) GetPeerCapabilities(ctx context.Context, pretty string) (*pb.Capabilities, error) {
+	id, err := peer.IDB58Decode(pretty)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get peer capabilities"), errors.Invalid, err)
+	}
+	if capabilities, ok := p2p.capabilities.get(id); ok {
+		return capabilities, nil
+	}
+	capabilities, err := p2p.exchangeCapabilities(ctx, id)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get peer capabilities"), err)
+	}
+	return capabilities, nil
+}
@@ -0,0 +1,74 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWireVersions(t *testing.T) {
+	assert.True(t, supportsVersion(wireVersion))
+	assert.True(t, supportsVersion(minWireVersion))
+	assert.False(t, supportsVersion(wireVersion+1))
+	assert.False(t, supportsVersion(0))
+
+	// Channels live on versioned topics
+	assert.Equal(t, "/sprawl/v1/channel/testChannel", channelTopic(testChannel.GetId()))
+	assert.Equal(t, "/sprawl/v1/directory", directoryTopic)
+
+	capabilities := localCapabilities()
+	assert.True(t, compatible(capabilities))
+	assert.True(t, hasFeature(capabilities, featureNegotiation))
+	assert.False(t, hasFeature(capabilities, "teleportation"))
+	assert.False(t, compatible(&pb.Capabilities{Versions: []uint32{wireVersion + 1}}))
+}
+
+func TestCapabilitiesExchange(t *testing.T) {
+	maker := newTestP2p(t, (*P2p).initNegotiation, (*P2p).initCapabilities)
+	defer maker.host.Close()
+	taker := newTestP2p(t, (*P2p).initNegotiation, (*P2p).initCapabilities)
+	defer taker.host.Close()
+
+	// Connecting exchanges the capabilities in the background, so both peers learn them
+	assert.NoError(t, taker.host.Connect(context.Background(), peer.AddrInfo{ID: maker.host.ID(), Addrs: maker.host.Addrs()}))
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, takerKnows := taker.capabilities.get(maker.host.ID())
+		_, makerKnows := maker.capabilities.get(taker.host.ID())
+		if takerKnows && makerKnows {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("capabilities weren't exchanged on connect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	capabilities, err := taker.GetPeerCapabilities(context.Background(), maker.host.ID().Pretty())
+	assert.NoError(t, err)
+	assert.Equal(t, localCapabilities().String(), capabilities.String())
+
+	_, err = taker.GetPeerCapabilities(context.Background(), "notAPeerID")
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	// Peers that don't advertise negotiation aren't asked to lock orders
+	taker.capabilities.set(maker.host.ID(), &pb.Capabilities{Versions: []uint32{wireVersion}})
+	request := &pb.NegotiationMessage{OrderID: testOrder.GetId(), Amount: testOrder.GetAmount()}
+	_, err = taker.RequestTake(context.Background(), maker.host.ID().Pretty(), request)
+	assert.True(t, errors.Is(errors.Unsupported, err))
+
+	// Disconnecting forgets the capabilities
+	assert.NoError(t, taker.host.Network().ClosePeer(maker.host.ID()))
+	deadline = time.Now().Add(5 * time.Second)
+	_, ok := taker.capabilities.get(maker.host.ID())
+	for ok && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		_, ok = taker.capabilities.get(maker.host.ID())
+	}
+	assert.False(t, ok)
+}
@@ -0,0 +1,204 @@
+package p2p
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// Connection manager defaults, used when the watermarks in p2p.Config aren't set or are invalid
+const (
+	defaultConnectionsLowWater    = 100
+	defaultConnectionsHighWater   = 400
+	defaultConnectionsGracePeriod = 20 * time.Second
+)
+
+// channelProtectionInterval defines how often the protected channel peers are refreshed
+const channelProtectionInterval = 10 * time.Second
+
+// Tags the connection manager protects peers with
+const (
+	partnerTag     = "sprawl-partner"
+	channelPeerTag = "sprawl-channel"
+	negotiationTag = "sprawl-negotiation-"
+)
+
+// peerProtector protects peers from being pruned by the connection manager, keeping track
+// of the tags each peer is protected with
+type peerProtector struct {
+	sync.Mutex
+	manager *connmgr.BasicConnMgr
+	tags    map[peer.ID]map[string]struct{}
+}
+
+func newPeerProtector(manager *connmgr.BasicConnMgr) *peerProtector {
+	return &peerProtector{manager: manager, tags: make(map[peer.ID]map[string]struct{})}
+}
+
+func (protector *peerProtector) protect(id peer.ID, tag string) {
+	protector.Lock()
+	defer protector.Unlock()
+	if _, ok := protector.tags[id]; !ok {
+		protector.tags[id] = make(map[string]struct{})
+	}
+	protector.tags[id][tag] = struct{}{}
+	protector.manager.Protect(id, tag)
+}
+
+func (protector *peerProtector) unprotect(id peer.ID, tag string) {
+	protector.Lock()
+	defer protector.Unlock()
+	delete(protector.tags[id], tag)
+	if len(protector.tags[id]) == 0 {
+		delete(protector.tags, id)
+	}
+	protector.manager.Unprotect(id, tag)
+}
+
+// replace protects exactly the given peers with a tag, unprotecting the peers no longer in the set
+func (protector *peerProtector) replace(tag string, ids map[peer.ID]struct{}) {
+	protector.Lock()
+	previous := make([]peer.ID, 0)
+	for id, tags := range protector.tags {
+		if _, ok := tags[tag]; ok {
+			previous = append(previous, id)
+		}
+	}
+	protector.Unlock()
+
+	for _, id := range previous {
+		if _, ok := ids[id]; !ok {
+			protector.unprotect(id, tag)
+		}
+	}
+	for id := range ids {
+		protector.protect(id, tag)
+	}
+}
+
+func (protector *peerProtector) count() int {
+	protector.Lock()
+	defer protector.Unlock()
+	return len(protector.tags)
+}
+
+// initConnManager creates the connection manager with the watermarks and the grace period defined in p2p.Config
+func (p2p *P2p) initConnManager() {
+	low := int(p2p.Config.GetUint("p2p.connectionsLowWater"))
+	high := int(p2p.Config.GetUint("p2p.connectionsHighWater"))
+	gracePeriod := time.Duration(p2p.Config.GetUint("p2p.connectionsGracePeriod")) * time.Second
+	if low == 0 {
+		low = defaultConnectionsLowWater
+	}
+	if high == 0 {
+		high = defaultConnectionsHighWater
+	}
+	if low > high {
+		if p2p.Logger != nil {
+			p2p.Logger.Errorf("Invalid connection watermarks: expected connectionsLowWater <= connectionsHighWater, got %d and %d", low, high)
+		}
+		low, high = defaultConnectionsLowWater, defaultConnectionsHighWater
+	}
+	if gracePeriod == 0 {
+		gracePeriod = defaultConnectionsGracePeriod
+	}
+	p2p.connManager = connmgr.NewConnManager(low, high, gracePeriod)
+	p2p.protector = newPeerProtector(p2p.connManager)
+}
+
+// initPeerProtection protects the configured partners, and keeps the peers of the subscribed channels protected
+func (p2p *P2p) initPeerProtection() {
+	for _, id := range p2p.parsePeerIDs("p2p.protectedPeers") {
+		p2p.protector.protect(id, partnerTag)
+	}
+
+	ctx := p2p.ctx
+	p2p.spawn(func() {
+		ticker := time.NewTicker(channelProtectionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p2p.protectChannelPeers()
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// protectChannelPeers protects the peers known to be subscribed to the channels this node has joined
+func (p2p *P2p) protectChannelPeers() {
+	if p2p.ps == nil {
+		return
+	}
+	ids := make(map[peer.ID]struct{})
+	for _, subscription := range p2p.GetSubscriptions() {
+		for _, id := range p2p.ps.ListPeers(channelTopic(subscription.Channel.GetId())) {
+			ids[id] = struct{}{}
+		}
+	}
+	p2p.protector.replace(channelPeerTag, ids)
+}
+
+// protectNegotiation protects the counterparty of a negotiation over an order until the returned function is called
+func (p2p *P2p) protectNegotiation(id peer.ID, orderID []byte) func() {
+	if p2p.protector == nil {
+		return func() {}
+	}
+	tag := negotiationTag + hex.EncodeToString(orderID)
+	p2p.protector.protect(id, tag)
+	return func() {
+		p2p.protector.unprotect(id, tag)
+	}
+}
+
+// hasConnectionCapacity tells whether the node is below the high watermark of the connection manager
+func (p2p *P2p) hasConnectionCapacity() bool {
+	if p2p.connManager == nil {
+		return true
+	}
+	return len(p2p.host.Network().Conns()) < p2p.connManager.GetInfo().HighWater
+}
+
+// GetConnectionStats reports the open connections, the protected peers and the watermarks of the connection manager
+func (p2p *P2p) GetConnectionStats() *pb.ConnectionStats {
+	stats := &pb.ConnectionStats{}
+	if p2p.connManager != nil {
+		info := p2p.connManager.GetInfo()
+		stats.LowWater = uint32(info.LowWater)
+		stats.HighWater = uint32(info.HighWater)
+		stats.ProtectedPeers = uint32(p2p.protector.count())
+	}
+	if p2p.host == nil {
+		return stats
+	}
+	for _, conn := range p2p.host.Network().Conns() {
+		stats.Connections++
+		switch conn.Stat().Direction {
+		case network.DirInbound:
+			stats.Inbound++
+		case network.DirOutbound:
+			stats.Outbound++
+		}
+	}
+	stats.Peers = uint32(len(p2p.host.Network().Peers()))
+	return stats
+}
+
+// closeConnManager stops the background trimming of the connection manager
+func (p2p *P2p) closeConnManager() {
+	if p2p.connManager == nil {
+		return
+	}
+	err := p2p.connManager.Close()
+	if !errors.IsEmpty(err) && p2p.Logger != nil {
+		p2p.Logger.Error(errors.E(errors.Op("Close connection manager"), err))
+	}
+}
@@ -0,0 +1,92 @@
+package p2p
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsConnectionsLowWater string = "SPRAWL_P2P_CONNECTIONSLOWWATER"
+const optionsConnectionsHighWater string = "SPRAWL_P2P_CONNECTIONSHIGHWATER"
+
+func resetConnectionOptions() {
+	os.Unsetenv(optionsConnectionsLowWater)
+	os.Unsetenv(optionsConnectionsHighWater)
+}
+
+func TestConnManagerWatermarks(t *testing.T) {
+	readTestConfig()
+	defer resetConnectionOptions()
+
+	p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+	p2pInstance.initConnManager()
+	stats := p2pInstance.GetConnectionStats()
+	assert.Equal(t, uint32(appConfig.GetUint("p2p.connectionsLowWater")), stats.GetLowWater())
+	assert.Equal(t, uint32(appConfig.GetUint("p2p.connectionsHighWater")), stats.GetHighWater())
+	p2pInstance.closeConnManager()
+
+	os.Setenv(optionsConnectionsLowWater, "10")
+	os.Setenv(optionsConnectionsHighWater, "20")
+	p2pInstance.initConnManager()
+	stats = p2pInstance.GetConnectionStats()
+	assert.Equal(t, uint32(10), stats.GetLowWater())
+	assert.Equal(t, uint32(20), stats.GetHighWater())
+	p2pInstance.closeConnManager()
+
+	// A low watermark above the high one falls back to the defaults
+	os.Setenv(optionsConnectionsLowWater, "30")
+	p2pInstance.initConnManager()
+	stats = p2pInstance.GetConnectionStats()
+	assert.Equal(t, uint32(defaultConnectionsLowWater), stats.GetLowWater())
+	assert.Equal(t, uint32(defaultConnectionsHighWater), stats.GetHighWater())
+	p2pInstance.closeConnManager()
+}
+
+func TestPeerProtector(t *testing.T) {
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	p2pInstance.initConnManager()
+	defer p2pInstance.closeConnManager()
+	partner, channelPeer, counterparty := generatePeerID(t), generatePeerID(t), generatePeerID(t)
+
+	p2pInstance.protector.protect(partner, partnerTag)
+	p2pInstance.protector.replace(channelPeerTag, map[peer.ID]struct{}{partner: {}, channelPeer: {}})
+	assert.Equal(t, 2, p2pInstance.protector.count())
+
+	// Negotiation counterparties are protected until the negotiation is over
+	done := p2pInstance.protectNegotiation(counterparty, testOrder.GetId())
+	assert.Equal(t, 3, p2pInstance.protector.count())
+	done()
+	assert.Equal(t, 2, p2pInstance.protector.count())
+
+	// Peers leaving the channels lose their protection, unless they're protected otherwise
+	p2pInstance.protector.replace(channelPeerTag, map[peer.ID]struct{}{})
+	assert.Equal(t, 1, p2pInstance.protector.count())
+	p2pInstance.protector.unprotect(partner, partnerTag)
+	assert.Equal(t, 0, p2pInstance.protector.count())
+}
+
+func TestConnectionStats(t *testing.T) {
+	dialer := newTestP2p(t, (*P2p).initNegotiation)
+	defer dialer.host.Close()
+	listener := newTestP2p(t, (*P2p).initNegotiation)
+	defer listener.host.Close()
+
+	assert.Equal(t, uint32(0), dialer.GetConnectionStats().GetConnections())
+	assert.NoError(t, dialer.host.Connect(context.Background(), peer.AddrInfo{ID: listener.host.ID(), Addrs: listener.host.Addrs()}))
+
+	stats := dialer.GetConnectionStats()
+	assert.Equal(t, uint32(1), stats.GetConnections())
+	assert.Equal(t, uint32(1), stats.GetPeers())
+	assert.Equal(t, uint32(1), stats.GetOutbound())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for listener.GetConnectionStats().GetInbound() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, uint32(1), listener.GetConnectionStats().GetInbound())
+	assert.True(t, dialer.hasConnectionCapacity())
+}
@@ -0,0 +1,317 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// directoryTopic is the well-known topic every node announces its channels on
+var directoryTopic = fmt.Sprintf("%sv%d/directory", baseTopic, topicVersion)
+
+// directoryAnnounceInterval defines how often the joined channels are announced
+const directoryAnnounceInterval = time.Minute
+
+// directoryEntryTTL defines how long an announcement is trusted without being renewed
+const directoryEntryTTL = 3 * directoryAnnounceInterval
+
+// maxAnnouncedChannels limits the channels in a single announcement. Nodes that joined more announce only some of them.
+const maxAnnouncedChannels = 100
+
+// maxDirectoryEntries limits the channels kept in the directory. Channels announced while it's full are
+// ignored until earlier announcements expire.
+const maxDirectoryEntries = 10000
+
+// maxAnnouncedChannelIDLength limits the length of the channel IDs in an announcement
+const maxAnnouncedChannelIDLength = 256
+
+type directoryEntry struct {
+	channel *pb.Channel
+	peers   map[peer.ID]time.Time
+}
+
+// channelDirectory keeps track of the channels announced by other peers, and the channels announced by this node
+type channelDirectory struct {
+	sync.Mutex
+	entries   map[string]*directoryEntry
+	announced map[peer.ID][]string
+	pruned    time.Time
+	local     map[string]*pb.Channel
+	changed   chan struct{}
+}
+
+func newChannelDirectory() *channelDirectory {
+	return &channelDirectory{
+		entries:   make(map[string]*directoryEntry),
+		announced: make(map[peer.ID][]string),
+		local:     make(map[string]*pb.Channel),
+		changed:   make(chan struct{}, 1),
+	}
+}
+
+func (directory *channelDirectory) notifyChanged() {
+	select {
+	case directory.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (directory *channelDirectory) addLocal(channel *pb.Channel) {
+	directory.Lock()
+	directory.local[string(channel.GetId())] = channel
+	directory.Unlock()
+	directory.notifyChanged()
+}
+
+func (directory *channelDirectory) removeLocal(channel *pb.Channel) {
+	directory.Lock()
+	delete(directory.local, string(channel.GetId()))
+	directory.Unlock()
+	directory.notifyChanged()
+}
+
+func (directory *channelDirectory) announcement() *pb.ChannelAnnouncement {
+	directory.Lock()
+	defer directory.Unlock()
+	announcement := &pb.ChannelAnnouncement{}
+	for _, channel := range directory.local {
+		if len(announcement.Channels) == maxAnnouncedChannels {
+			break
+		}
+		announcement.Channels = append(announcement.Channels, channel)
+	}
+	return announcement
+}
+
+// checkAnnouncement decodes a channel announcement, refusing announcements over the limits of the directory
+func checkAnnouncement(data []byte) (*pb.ChannelAnnouncement, error) {
+	announcement := &pb.ChannelAnnouncement{}
+	err := proto.Unmarshal(data, announcement)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal channel announcement"), errors.Invalid, err)
+	}
+	if len(announcement.GetChannels()) > maxAnnouncedChannels {
+		return nil, errors.E(errors.Op("Check channel announcement"), errors.Invalid, "too many channels announced")
+	}
+	for _, channel := range announcement.GetChannels() {
+		if len(channel.GetId()) == 0 || len(channel.GetId()) > maxAnnouncedChannelIDLength {
+			return nil, errors.E(errors.Op("Check channel announcement"), errors.Invalid, "invalid channel ID")
+		}
+	}
+	return announcement, nil
+}
+
+// forget removes the channels announced earlier by a peer, dropping the entries no peer announces anymore
+func (directory *channelDirectory) forget(from peer.ID) {
+	for _, id := range directory.announced[from] {
+		entry, ok := directory.entries[id]
+		if !ok {
+			continue
+		}
+		delete(entry.peers, from)
+		if len(entry.peers) == 0 {
+			delete(directory.entries, id)
+		}
+	}
+	delete(directory.announced, from)
+}
+
+// prune drops the announcements that haven't been renewed within directoryEntryTTL
+func (directory *channelDirectory) prune(now time.Time) {
+	for id, entry := range directory.entries {
+		for peerID, seen := range entry.peers {
+			if now.Sub(seen) > directoryEntryTTL {
+				delete(entry.peers, peerID)
+			}
+		}
+		if len(entry.peers) == 0 {
+			delete(directory.entries, id)
+		}
+	}
+	for peerID, ids := range directory.announced {
+		remaining := ids[:0]
+		for _, id := range ids {
+			if entry, ok := directory.entries[id]; ok {
+				if _, ok := entry.peers[peerID]; ok {
+					remaining = append(remaining, id)
+				}
+			}
+		}
+		if len(remaining) == 0 {
+			delete(directory.announced, peerID)
+		} else {
+			directory.announced[peerID] = remaining
+		}
+	}
+	directory.pruned = now
+}
+
+// record replaces every channel announced earlier by a peer with the ones in its latest announcement.
+// Expired announcements are pruned every directoryAnnounceInterval, or right away when the directory is full.
+func (directory *channelDirectory) record(from peer.ID, announcement *pb.ChannelAnnouncement, seen time.Time) {
+	directory.Lock()
+	defer directory.Unlock()
+	directory.forget(from)
+	if seen.Sub(directory.pruned) > directoryAnnounceInterval || len(directory.entries)+len(announcement.GetChannels()) > maxDirectoryEntries {
+		directory.prune(seen)
+	}
+	announced := make([]string, 0, len(announcement.GetChannels()))
+	for _, channel := range announcement.GetChannels() {
+		id := string(channel.GetId())
+		entry, ok := directory.entries[id]
+		if !ok {
+			if len(directory.entries) >= maxDirectoryEntries {
+				continue
+			}
+			entry = &directoryEntry{peers: make(map[peer.ID]time.Time)}
+			directory.entries[id] = entry
+		}
+		if _, ok := entry.peers[from]; !ok {
+			announced = append(announced, id)
+		}
+		entry.channel = channel
+		entry.peers[from] = seen
+	}
+	directory.announced[from] = announced
+}
+
+// list prunes expired announcements and returns the remaining channels with the amount of peers announcing them
+func (directory *channelDirectory) list(now time.Time) []*pb.DirectoryEntry {
+	directory.Lock()
+	defer directory.Unlock()
+	directory.prune(now)
+	entries := make([]*pb.DirectoryEntry, 0)
+	for _, entry := range directory.entries {
+		var lastSeen time.Time
+		for _, seen := range entry.peers {
+			if seen.After(lastSeen) {
+				lastSeen = seen
+			}
+		}
+		lastSeenProto, _ := ptypes.TimestampProto(lastSeen)
+		entries = append(entries, &pb.DirectoryEntry{
+			Channel:  entry.channel,
+			Peers:    uint32(len(entry.peers)),
+			LastSeen: lastSeenProto,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].GetPeers() > entries[j].GetPeers()
+	})
+	return entries
+}
+
+func (p2p *P2p) announceChannels() {
+	buf, err := proto.Marshal(p2p.directory.announcement())
+	if !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Error(errors.E(errors.Op("Marshal channel announcement"), err))
+		}
+		return
+	}
+	err = p2p.ps.Publish(directoryTopic, buf)
+	if !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Error(errors.E(errors.Op("Publish channel announcement"), err))
+		}
+	}
+}
+
+// directoryValidator returns a pubsub topic validator for the directory topic. Announcements over the limits
+// of the directory are dropped before they're gossiped on, and count against the peer that forwarded them.
+func (p2p *P2p) directoryValidator() pubsub.Validator {
+	run, self := p2p.ctx, p2p.host.ID()
+	return func(ctx context.Context, from peer.ID, msg *pubsub.Message) bool {
+		if !p2p.enter(run) {
+			return false
+		}
+		defer p2p.routines.Done()
+		if from == self {
+			return true
+		}
+		var err error
+		if p2p.strictSignatures && len(msg.GetSignature()) == 0 {
+			err = errors.E(errors.Op("Check channel announcement"), errors.Invalid, "announcement is not signed")
+		} else {
+			_, err = checkAnnouncement(msg.GetData())
+		}
+		if errors.IsEmpty(err) {
+			return true
+		}
+		p2p.penalize(from)
+		if p2p.Logger != nil {
+			p2p.Logger.Debug(errors.E(errors.Op("Validate channel announcement"), err))
+		}
+		return false
+	}
+}
+
+// initDirectory subscribes to the directory topic and starts announcing this node's channels.
+// The node's own announcements aren't recorded, so it only counts other peers on its channels.
+// Observer and infrastructure nodes don't trade on channels, so they only listen to the announcements of others.
+func (p2p *P2p) initDirectory() error {
+	err := p2p.ps.RegisterTopicValidator(directoryTopic, p2p.directoryValidator())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Register directory validator"), err)
+	}
+	sub, err := p2p.ps.Subscribe(directoryTopic)
+	if !errors.IsEmpty(err) {
+		p2p.ps.UnregisterTopicValidator(directoryTopic)
+		return errors.E(errors.Op("Subscribe to directory"), err)
+	}
+
+	ctx, self := p2p.ctx, p2p.host.ID()
+	p2p.spawn(func() {
+		for {
+			msg, err := sub.Next(ctx)
+			if !errors.IsEmpty(err) {
+				return
+			}
+			if msg.GetFrom() == self {
+				continue
+			}
+			announcement, err := checkAnnouncement(msg.GetData())
+			if !errors.IsEmpty(err) {
+				if p2p.Logger != nil {
+					p2p.Logger.Warn(err)
+				}
+				continue
+			}
+			p2p.directory.record(msg.GetFrom(), announcement, time.Now())
+		}
+	})
+
+	if p2p.ReadOnly() || p2p.IsInfrastructure() {
+		return nil
+	}
+	p2p.spawn(func() {
+		ticker := time.NewTicker(directoryAnnounceInterval)
+		defer ticker.Stop()
+		p2p.announceChannels()
+		for {
+			select {
+			case <-ticker.C:
+				p2p.announceChannels()
+			case <-p2p.directory.changed:
+				p2p.announceChannels()
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// DiscoverChannels returns the channels announced on the network with an approximate count of peers on each
+func (p2p *P2p) DiscoverChannels() []*pb.DirectoryEntry {
+	return p2p.directory.list(time.Now())
+}
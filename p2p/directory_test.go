@@ -0,0 +1,114 @@
+package p2p
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPeer1 peer.ID = "testPeer1"
+const testPeer2 peer.ID = "testPeer2"
+
+var testChannel2 *pb.Channel = &pb.Channel{Id: []byte("testChannel2")}
+
+func TestDirectoryAnnouncement(t *testing.T) {
+	directory := newChannelDirectory()
+	assert.Empty(t, directory.announcement().GetChannels())
+
+	directory.addLocal(testChannel)
+	assert.Equal(t, []*pb.Channel{testChannel}, directory.announcement().GetChannels())
+	<-directory.changed
+
+	directory.removeLocal(testChannel)
+	assert.Empty(t, directory.announcement().GetChannels())
+}
+
+func TestDirectoryRecord(t *testing.T) {
+	directory := newChannelDirectory()
+	now := time.Now()
+
+	directory.record(testPeer1, &pb.ChannelAnnouncement{Channels: []*pb.Channel{testChannel, testChannel2}}, now)
+	directory.record(testPeer2, &pb.ChannelAnnouncement{Channels: []*pb.Channel{testChannel}}, now)
+
+	entries := directory.list(now)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, testChannel.GetId(), entries[0].GetChannel().GetId())
+	assert.Equal(t, uint32(2), entries[0].GetPeers())
+	assert.Equal(t, uint32(1), entries[1].GetPeers())
+
+	// A new announcement replaces the peer's earlier one
+	directory.record(testPeer1, &pb.ChannelAnnouncement{}, now)
+	entries = directory.list(now)
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, uint32(1), entries[0].GetPeers())
+
+	// Announcements expire when they're not renewed
+	entries = directory.list(now.Add(directoryEntryTTL + time.Second))
+	assert.Empty(t, entries)
+}
+
+func createAnnouncement(channels int, prefix string) *pb.ChannelAnnouncement {
+	announcement := &pb.ChannelAnnouncement{}
+	for i := 0; i < channels; i++ {
+		announcement.Channels = append(announcement.Channels, &pb.Channel{Id: []byte(fmt.Sprintf("%s%d", prefix, i))})
+	}
+	return announcement
+}
+
+func TestCheckAnnouncement(t *testing.T) {
+	data, err := proto.Marshal(createAnnouncement(maxAnnouncedChannels, "channel"))
+	assert.NoError(t, err)
+	_, err = checkAnnouncement(data)
+	assert.NoError(t, err)
+
+	data, err = proto.Marshal(createAnnouncement(maxAnnouncedChannels+1, "channel"))
+	assert.NoError(t, err)
+	_, err = checkAnnouncement(data)
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	data, err = proto.Marshal(&pb.ChannelAnnouncement{Channels: []*pb.Channel{{}}})
+	assert.NoError(t, err)
+	_, err = checkAnnouncement(data)
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	_, err = checkAnnouncement([]byte("garbage"))
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	// Nodes that joined too many channels announce only some of them
+	directory := newChannelDirectory()
+	for _, channel := range createAnnouncement(maxAnnouncedChannels+1, "channel").GetChannels() {
+		directory.addLocal(channel)
+	}
+	assert.Len(t, directory.announcement().GetChannels(), maxAnnouncedChannels)
+}
+
+func TestDirectoryLimits(t *testing.T) {
+	directory := newChannelDirectory()
+	now := time.Now()
+
+	// Channels announced while the directory is full are ignored
+	for i := 0; i < maxDirectoryEntries/maxAnnouncedChannels; i++ {
+		directory.record(peer.ID(fmt.Sprintf("peer%d", i)), createAnnouncement(maxAnnouncedChannels, fmt.Sprintf("peer%dchannel", i)), now)
+	}
+	directory.record(testPeer1, &pb.ChannelAnnouncement{Channels: []*pb.Channel{testChannel, {Id: []byte("peer0channel0")}}}, now)
+	entries := directory.list(now)
+	assert.Len(t, entries, maxDirectoryEntries)
+	assert.Equal(t, uint32(2), entries[0].GetPeers())
+
+	// Expired announcements make room for new ones
+	later := now.Add(directoryEntryTTL + time.Second)
+	directory.record(testPeer1, &pb.ChannelAnnouncement{Channels: []*pb.Channel{testChannel}}, later)
+	directory.Lock()
+	assert.Len(t, directory.entries, 1)
+	assert.Len(t, directory.announced, 1)
+	directory.Unlock()
+	entries = directory.list(later)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, testChannel.GetId(), entries[0].GetChannel().GetId())
+}
@@ -0,0 +1,234 @@
+package p2p
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// peerGater decides which peers this node talks to. Denied peers are always refused, and when the
+// allowlist isn't empty, only the peers on it are accepted. The gater is also the pubsub blacklist,
+// so peers blacklisted for misbehaving on pubsub are refused until they're unbanned or the node restarts.
+type peerGater struct {
+	sync.RWMutex
+	allowed     map[peer.ID]struct{}
+	denied      map[peer.ID]struct{}
+	blacklisted map[peer.ID]struct{}
+}
+
+func newPeerGater() *peerGater {
+	return &peerGater{
+		allowed:     make(map[peer.ID]struct{}),
+		denied:      make(map[peer.ID]struct{}),
+		blacklisted: make(map[peer.ID]struct{}),
+	}
+}
+
+func (gater *peerGater) allow(id peer.ID) {
+	gater.Lock()
+	defer gater.Unlock()
+	gater.allowed[id] = struct{}{}
+}
+
+func (gater *peerGater) deny(id peer.ID) {
+	gater.Lock()
+	defer gater.Unlock()
+	gater.denied[id] = struct{}{}
+}
+
+// undeny removes a peer from the denylist, reporting whether it was denied
+func (gater *peerGater) undeny(id peer.ID) bool {
+	gater.Lock()
+	defer gater.Unlock()
+	_, ok := gater.denied[id]
+	delete(gater.denied, id)
+	return ok
+}
+
+// unblacklist removes a peer from the pubsub blacklist, reporting whether it was blacklisted
+func (gater *peerGater) unblacklist(id peer.ID) bool {
+	gater.Lock()
+	defer gater.Unlock()
+	_, ok := gater.blacklisted[id]
+	delete(gater.blacklisted, id)
+	return ok
+}
+
+// accepts tells whether connections and messages from a peer are accepted
+func (gater *peerGater) accepts(id peer.ID) bool {
+	gater.RLock()
+	defer gater.RUnlock()
+	if _, ok := gater.denied[id]; ok {
+		return false
+	}
+	if _, ok := gater.blacklisted[id]; ok {
+		return false
+	}
+	if len(gater.allowed) == 0 {
+		return true
+	}
+	_, ok := gater.allowed[id]
+	return ok
+}
+
+// deniedPeers lists the denied peers ordered by ID
+func (gater *peerGater) deniedPeers() []peer.ID {
+	gater.RLock()
+	defer gater.RUnlock()
+	peers := make([]peer.ID, 0, len(gater.denied))
+	for id := range gater.denied {
+		peers = append(peers, id)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i] < peers[j]
+	})
+	return peers
+}
+
+// Add blacklists a peer, implementing pubsub.Blacklist
+func (gater *peerGater) Add(id peer.ID) {
+	gater.Lock()
+	defer gater.Unlock()
+	gater.blacklisted[id] = struct{}{}
+}
+
+// Contains tells whether pubsub should drop the messages of a peer, implementing pubsub.Blacklist
+func (gater *peerGater) Contains(id peer.ID) bool {
+	return !gater.accepts(id)
+}
+
+func getDeniedPeerStorageKey(id peer.ID) []byte {
+	return []byte(strings.Join([]string{string(interfaces.DeniedPeerPrefix), id.Pretty()}, ""))
+}
+
+// parsePeerIDs decodes the peer IDs in a config list, skipping invalid ones
+func (p2p *P2p) parsePeerIDs(variable string) []peer.ID {
+	ids := make([]peer.ID, 0)
+	for _, pretty := range p2p.Config.GetStringSlice(variable) {
+		id, err := peer.IDB58Decode(pretty)
+		if !errors.IsEmpty(err) {
+			if p2p.Logger != nil {
+				p2p.Logger.Error(errors.E(errors.Op("Parse peer ID"), fmt.Sprintf("%v, %s: %s", err, variable, pretty)))
+			}
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// gated wraps a stream handler so the streams of refused peers are reset instead of handled
+func (p2p *P2p) gated(handler network.StreamHandler) network.StreamHandler {
+	return func(stream network.Stream) {
+		if !p2p.gater.accepts(stream.Conn().RemotePeer()) {
+			stream.Reset()
+			return
+		}
+		handler(stream)
+	}
+}
+
+// initGater fills the allow and deny lists from p2p.Config and starts closing connections from refused peers.
+// This libp2p version can't refuse a peer before its connection is set up: the peer ID of an inbound
+// connection is only known after the security handshake, and the DHT may dial refused peers on its own.
+// So a refused peer is disconnected right after connecting, and until then it's kept out by the other
+// connection notifiees and the stream handlers, which all check the gater, and by the pubsub blacklist.
+func (p2p *P2p) initGater() {
+	for _, id := range p2p.parsePeerIDs("p2p.allowedPeers") {
+		p2p.gater.allow(id)
+	}
+	for _, id := range p2p.parsePeerIDs("p2p.deniedPeers") {
+		p2p.gater.deny(id)
+	}
+	p2p.host.Network().Notify(&network.NotifyBundle{
+		ConnectedF: func(n network.Network, conn network.Conn) {
+			if !p2p.gater.accepts(conn.RemotePeer()) {
+				if p2p.Logger != nil {
+					p2p.Logger.Debugf("Refusing connection from peer %s", conn.RemotePeer())
+				}
+				go conn.Close()
+			}
+		},
+	})
+}
+
+// RegisterStorage registers a storage service to persist the denied and the recently connected peers in.
+// The peers denied earlier are denied again right away.
+func (p2p *P2p) RegisterStorage(storage interfaces.Storage) {
+	p2p.storage = storage
+	maxKnownPeers := int(p2p.Config.GetUint("p2p.maxKnownPeers"))
+	if maxKnownPeers == 0 {
+		maxKnownPeers = defaultMaxKnownPeers
+	}
+	p2p.knownPeers = &knownPeerBook{storage: storage, maxPeers: maxKnownPeers}
+	denied, err := storage.GetAllWithPrefix(string(interfaces.DeniedPeerPrefix))
+	if !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Error(errors.E(errors.Op("Get denied peers"), err))
+		}
+		return
+	}
+	for _, pretty := range denied {
+		id, err := peer.IDB58Decode(pretty)
+		if errors.IsEmpty(err) {
+			p2p.gater.deny(id)
+		}
+	}
+}
+
+// BanPeer denies all connections and messages from a peer, disconnecting it if it's connected
+func (p2p *P2p) BanPeer(pretty string) error {
+	id, err := peer.IDB58Decode(pretty)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Ban peer"), errors.Invalid, err)
+	}
+	if p2p.storage != nil {
+		err = p2p.storage.Put(getDeniedPeerStorageKey(id), []byte(id.Pretty()))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Ban peer"), err)
+		}
+	}
+	p2p.gater.deny(id)
+	if p2p.host != nil {
+		p2p.host.Network().ClosePeer(id)
+	}
+	return nil
+}
+
+// UnbanPeer accepts connections and messages from a banned peer again. Peers blacklisted for forwarding
+// invalid messages are unbanned the same way, starting over with a clean score.
+// Unbanning a peer that is neither banned nor blacklisted returns a NotFound error.
+func (p2p *P2p) UnbanPeer(pretty string) error {
+	id, err := peer.IDB58Decode(pretty)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unban peer"), errors.Invalid, err)
+	}
+	denied := p2p.gater.undeny(id)
+	if p2p.gater.unblacklist(id) {
+		p2p.scores.reset(id)
+	} else if !denied {
+		return errors.E(errors.Op("Unban peer"), errors.NotFound, "peer is not banned")
+	}
+	if denied && p2p.storage != nil {
+		err = p2p.storage.Delete(getDeniedPeerStorageKey(id))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Unban peer"), err)
+		}
+	}
+	return nil
+}
+
+// GetBannedPeers lists the IDs of the banned peers
+func (p2p *P2p) GetBannedPeers() []string {
+	peers := make([]string, 0)
+	for _, id := range p2p.gater.deniedPeers() {
+		peers = append(peers, id.Pretty())
+	}
+	return peers
+}
@@ -0,0 +1,111 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func generatePeerID(t *testing.T) peer.ID {
+	_, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	id, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+	return id
+}
+
+func TestPeerGater(t *testing.T) {
+	gater := newPeerGater()
+	partner := generatePeerID(t)
+	stranger := generatePeerID(t)
+
+	assert.True(t, gater.accepts(stranger))
+
+	gater.deny(stranger)
+	assert.False(t, gater.accepts(stranger))
+	assert.True(t, gater.Contains(stranger))
+	assert.Equal(t, []peer.ID{stranger}, gater.deniedPeers())
+	assert.True(t, gater.undeny(stranger))
+	assert.False(t, gater.undeny(stranger))
+	assert.True(t, gater.accepts(stranger))
+
+	// Only allowed peers are accepted once the allowlist has entries
+	gater.allow(partner)
+	assert.True(t, gater.accepts(partner))
+	assert.False(t, gater.accepts(stranger))
+
+	// Peers blacklisted by pubsub aren't accepted either
+	gater.Add(partner)
+	assert.False(t, gater.accepts(partner))
+	assert.Empty(t, gater.deniedPeers())
+}
+
+func TestBanPeer(t *testing.T) {
+	storage := &inmemory.Storage{Db: make(map[string]string)}
+	bannedPeer := generatePeerID(t)
+
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	p2pInstance.RegisterStorage(storage)
+	assert.True(t, errors.Is(errors.Invalid, p2pInstance.BanPeer("notAPeerID")))
+	assert.NoError(t, p2pInstance.BanPeer(bannedPeer.Pretty()))
+	assert.Equal(t, []string{bannedPeer.Pretty()}, p2pInstance.GetBannedPeers())
+
+	// Bans persist over restarts
+	restarted := NewP2p(log, testConfig, privateKey, publicKey)
+	restarted.RegisterStorage(storage)
+	assert.Equal(t, []string{bannedPeer.Pretty()}, restarted.GetBannedPeers())
+	assert.False(t, restarted.gater.accepts(bannedPeer))
+
+	assert.NoError(t, restarted.UnbanPeer(bannedPeer.Pretty()))
+	assert.True(t, errors.Is(errors.NotFound, restarted.UnbanPeer(bannedPeer.Pretty())))
+	assert.Empty(t, restarted.GetBannedPeers())
+	restarted = NewP2p(log, testConfig, privateKey, publicKey)
+	restarted.RegisterStorage(storage)
+	assert.Empty(t, restarted.GetBannedPeers())
+}
+
+func TestGaterRefusesConnections(t *testing.T) {
+	p2pInstance := newTestP2p(t, (*P2p).initNegotiation)
+	defer p2pInstance.host.Close()
+	p2pInstance.initGater()
+	bannedInstance := newTestP2p(t, (*P2p).initNegotiation)
+	defer bannedInstance.host.Close()
+
+	assert.NoError(t, p2pInstance.BanPeer(bannedInstance.host.ID().Pretty()))
+	addrInfo := peer.AddrInfo{ID: p2pInstance.host.ID(), Addrs: p2pInstance.host.Addrs()}
+	bannedInstance.host.Connect(context.Background(), addrInfo)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(p2pInstance.host.Network().ConnsToPeer(bannedInstance.host.ID())) > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Empty(t, p2pInstance.host.Network().ConnsToPeer(bannedInstance.host.ID()))
+
+	// Found peers are not dialed either
+	p2pInstance.connectToFoundPeer(context.Background(), peer.AddrInfo{ID: bannedInstance.host.ID(), Addrs: bannedInstance.host.Addrs()})
+	assert.Empty(t, p2pInstance.host.Network().ConnsToPeer(bannedInstance.host.ID()))
+}
+
+func TestUnbanBlacklistedPeer(t *testing.T) {
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	blacklistedPeer := generatePeerID(t)
+	for p2pInstance.scores.get(blacklistedPeer) > blacklistScore {
+		p2pInstance.penalize(blacklistedPeer)
+	}
+	p2pInstance.gater.Add(blacklistedPeer)
+	assert.False(t, p2pInstance.gater.accepts(blacklistedPeer))
+
+	// Unbanning lifts the pubsub blacklisting and lets the peer start over
+	assert.NoError(t, p2pInstance.UnbanPeer(blacklistedPeer.Pretty()))
+	assert.True(t, p2pInstance.gater.accepts(blacklistedPeer))
+	assert.False(t, p2pInstance.gater.Contains(blacklistedPeer))
+	assert.Equal(t, 0, p2pInstance.scores.get(blacklistedPeer))
+	assert.True(t, errors.Is(errors.NotFound, p2pInstance.UnbanPeer(blacklistedPeer.Pretty())))
+}
@@ -0,0 +1,288 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	multiaddr "github.com/multiformats/go-multiaddr"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// defaultMaxKnownPeers is used when p2p.maxKnownPeers isn't set
+const defaultMaxKnownPeers = 50
+
+// maxKnownPeerFailures defines how many redials in a row may fail before a known peer is forgotten
+const maxKnownPeerFailures = 5
+
+// knownPeerDialTimeout defines how long redialing a known peer may take
+const knownPeerDialTimeout = 10 * time.Second
+
+func getKnownPeerStorageKey(id peer.ID) []byte {
+	return []byte(strings.Join([]string{string(interfaces.KnownPeerPrefix), id.Pretty()}, ""))
+}
+
+// byReliability orders known peers by their successful connections, and then by when they were last seen
+type byReliability []*pb.KnownPeer
+
+func (peers byReliability) Len() int      { return len(peers) }
+func (peers byReliability) Swap(i, j int) { peers[i], peers[j] = peers[j], peers[i] }
+func (peers byReliability) Less(i, j int) bool {
+	if peers[i].GetSuccesses() != peers[j].GetSuccesses() {
+		return peers[i].GetSuccesses() > peers[j].GetSuccesses()
+	}
+	return peers[i].GetLastSeen().GetSeconds() > peers[j].GetLastSeen().GetSeconds()
+}
+
+// knownPeerBook persists the recently connected peers, so a restarted node can redial them
+// instead of depending on the bootstrap peers. When each stored peer was last seen is kept in
+// memory, loaded from the storage on first use, so connections don't read every stored peer.
+type knownPeerBook struct {
+	sync.Mutex
+	storage  interfaces.Storage
+	maxPeers int
+	lastSeen map[peer.ID]time.Time
+}
+
+// load reads when each stored peer was last seen, unless it's already loaded
+func (book *knownPeerBook) load() error {
+	if book.lastSeen != nil {
+		return nil
+	}
+	knownPeers, err := book.list()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	book.lastSeen = make(map[peer.ID]time.Time, len(knownPeers))
+	for _, knownPeer := range knownPeers {
+		id, err := peer.IDB58Decode(knownPeer.GetId())
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		lastSeen, _ := ptypes.Timestamp(knownPeer.GetLastSeen())
+		book.lastSeen[id] = lastSeen
+	}
+	return nil
+}
+
+// forget removes a peer from the book
+func (book *knownPeerBook) forget(id peer.ID) error {
+	if book.lastSeen != nil {
+		delete(book.lastSeen, id)
+	}
+	return book.storage.Delete(getKnownPeerStorageKey(id))
+}
+
+// evictOldest forgets the peers seen longest ago until there's room for another peer
+func (book *knownPeerBook) evictOldest() error {
+	for len(book.lastSeen) >= book.maxPeers && len(book.lastSeen) > 0 {
+		var oldest peer.ID
+		var oldestSeen time.Time
+		for id, seen := range book.lastSeen {
+			if oldest == "" || seen.Before(oldestSeen) {
+				oldest, oldestSeen = id, seen
+			}
+		}
+		err := book.forget(oldest)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (book *knownPeerBook) get(id peer.ID) (*pb.KnownPeer, error) {
+	key := getKnownPeerStorageKey(id)
+	known, err := book.storage.Has(key)
+	if !errors.IsEmpty(err) || !known {
+		return nil, err
+	}
+	data, err := book.storage.Get(key)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	knownPeer := &pb.KnownPeer{}
+	err = proto.Unmarshal(data, knownPeer)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return knownPeer, nil
+}
+
+func (book *knownPeerBook) put(knownPeer *pb.KnownPeer) error {
+	id, err := peer.IDB58Decode(knownPeer.GetId())
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	data, err := proto.Marshal(knownPeer)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	return book.storage.Put(getKnownPeerStorageKey(id), data)
+}
+
+// list returns the known peers, the most reliable first
+func (book *knownPeerBook) list() ([]*pb.KnownPeer, error) {
+	stored, err := book.storage.GetAllWithPrefix(string(interfaces.KnownPeerPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	knownPeers := make([]*pb.KnownPeer, 0, len(stored))
+	for _, data := range stored {
+		knownPeer := &pb.KnownPeer{}
+		if errors.IsEmpty(proto.Unmarshal([]byte(data), knownPeer)) {
+			knownPeers = append(knownPeers, knownPeer)
+		}
+	}
+	sort.Sort(byReliability(knownPeers))
+	return knownPeers, nil
+}
+
+// seen records a successful connection to a peer and the addresses it was reached at.
+// When a new peer doesn't fit in the book, the peers seen longest ago are forgotten to make room.
+func (book *knownPeerBook) seen(id peer.ID, addrs []multiaddr.Multiaddr) error {
+	book.Lock()
+	defer book.Unlock()
+	err := book.load()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	knownPeer, err := book.get(id)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if knownPeer == nil {
+		err = book.evictOldest()
+		if !errors.IsEmpty(err) {
+			return err
+		}
+		knownPeer = &pb.KnownPeer{Id: id.Pretty()}
+	}
+	knownPeer.Addrs = make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		knownPeer.Addrs = append(knownPeer.Addrs, addr.String())
+	}
+	now := time.Now()
+	knownPeer.LastSeen, _ = ptypes.TimestampProto(now)
+	knownPeer.Successes++
+	knownPeer.Failures = 0
+	err = book.put(knownPeer)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	book.lastSeen[id] = now
+	return nil
+}
+
+// failed records a failed redial, forgetting the peer after maxKnownPeerFailures failures in a row
+func (book *knownPeerBook) failed(id peer.ID) error {
+	book.Lock()
+	defer book.Unlock()
+	knownPeer, err := book.get(id)
+	if !errors.IsEmpty(err) || knownPeer == nil {
+		return err
+	}
+	knownPeer.Failures++
+	if knownPeer.GetFailures() >= maxKnownPeerFailures {
+		return book.forget(id)
+	}
+	return book.put(knownPeer)
+}
+
+// initKnownPeers starts remembering the peers this node connects to.
+// Nothing is remembered until a storage service is registered.
+func (p2p *P2p) initKnownPeers() {
+	p2p.host.Network().Notify(&network.NotifyBundle{
+		ConnectedF: func(n network.Network, conn network.Conn) {
+			book := p2p.knownPeers
+			if book == nil || !p2p.gater.accepts(conn.RemotePeer()) {
+				return
+			}
+			id := conn.RemotePeer()
+			addrs := p2p.host.Peerstore().Addrs(id)
+			if len(addrs) == 0 {
+				addrs = []multiaddr.Multiaddr{conn.RemoteMultiaddr()}
+			}
+			p2p.spawn(func() {
+				err := book.seen(id, addrs)
+				if !errors.IsEmpty(err) && p2p.Logger != nil {
+					p2p.Logger.Error(errors.E(errors.Op("Remember peer"), err))
+				}
+			})
+		},
+	})
+}
+
+// connectToKnownPeers redials the peers remembered from earlier runs, the most reliable first
+func (p2p *P2p) connectToKnownPeers() {
+	if p2p.knownPeers == nil {
+		return
+	}
+	knownPeers, err := p2p.knownPeers.list()
+	if !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Error(errors.E(errors.Op("Get known peers"), err))
+		}
+		return
+	}
+	if p2p.Logger != nil && len(knownPeers) > 0 {
+		p2p.Logger.Infof("Connecting to %d known peers", len(knownPeers))
+	}
+
+	var wg sync.WaitGroup
+	for _, knownPeer := range knownPeers {
+		peerInfo, err := knownPeerAddrInfo(knownPeer)
+		if !errors.IsEmpty(err) || peerInfo.ID == p2p.host.ID() || !p2p.gater.accepts(peerInfo.ID) {
+			continue
+		}
+		wg.Add(1)
+		go func(peerInfo peer.AddrInfo) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(p2p.ctx, knownPeerDialTimeout)
+			defer cancel()
+			if err := p2p.host.Connect(ctx, peerInfo); !errors.IsEmpty(err) {
+				if p2p.Logger != nil {
+					p2p.Logger.Debugf("Error connecting to known peer %s: %s", peerInfo.ID, err)
+				}
+				p2p.knownPeers.failed(peerInfo.ID)
+			}
+		}(peerInfo)
+	}
+	wg.Wait()
+}
+
+// knownPeerAddrInfo decodes the ID and the addresses of a known peer, skipping invalid addresses
+func knownPeerAddrInfo(knownPeer *pb.KnownPeer) (peer.AddrInfo, error) {
+	id, err := peer.IDB58Decode(knownPeer.GetId())
+	if !errors.IsEmpty(err) {
+		return peer.AddrInfo{}, err
+	}
+	peerInfo := peer.AddrInfo{ID: id}
+	for _, addr := range knownPeer.GetAddrs() {
+		maddr, err := multiaddr.NewMultiaddr(addr)
+		if errors.IsEmpty(err) {
+			peerInfo.Addrs = append(peerInfo.Addrs, maddr)
+		}
+	}
+	return peerInfo, nil
+}
+
+// GetKnownPeers lists the peers remembered across restarts, the most reliable first
+func (p2p *P2p) GetKnownPeers() ([]*pb.KnownPeer, error) {
+	if p2p.knownPeers == nil {
+		return []*pb.KnownPeer{}, nil
+	}
+	knownPeers, err := p2p.knownPeers.list()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get known peers"), err)
+	}
+	return knownPeers, nil
+}
@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	multiaddr "github.com/multiformats/go-multiaddr"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func knownPeerIDs(knownPeers []*pb.KnownPeer) []string {
+	ids := []string{}
+	for _, knownPeer := range knownPeers {
+		ids = append(ids, knownPeer.GetId())
+	}
+	return ids
+}
+
+func TestKnownPeerBook(t *testing.T) {
+	book := &knownPeerBook{storage: &inmemory.Storage{Db: make(map[string]string)}, maxPeers: 2}
+	addr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/4001")
+	assert.NoError(t, err)
+	reliable, occasional, newcomer := generatePeerID(t), generatePeerID(t), generatePeerID(t)
+
+	assert.NoError(t, book.seen(reliable, []multiaddr.Multiaddr{addr}))
+	assert.NoError(t, book.seen(reliable, []multiaddr.Multiaddr{addr}))
+	assert.NoError(t, book.seen(occasional, []multiaddr.Multiaddr{addr}))
+
+	knownPeers, err := book.list()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(knownPeers))
+	assert.Equal(t, reliable.Pretty(), knownPeers[0].GetId())
+	assert.Equal(t, uint32(2), knownPeers[0].GetSuccesses())
+	assert.Equal(t, []string{addr.String()}, knownPeers[0].GetAddrs())
+	assert.NotNil(t, knownPeers[0].GetLastSeen())
+
+	// New peers make room by forgetting the peer seen longest ago
+	assert.NoError(t, book.seen(reliable, []multiaddr.Multiaddr{addr}))
+	assert.NoError(t, book.seen(newcomer, []multiaddr.Multiaddr{addr}))
+	knownPeers, err = book.list()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{reliable.Pretty(), newcomer.Pretty()}, knownPeerIDs(knownPeers))
+
+	// A book reopened on the same storage knows when its peers were last seen
+	reopened := &knownPeerBook{storage: book.storage, maxPeers: 2}
+	assert.NoError(t, reopened.seen(occasional, []multiaddr.Multiaddr{addr}))
+	knownPeers, err = reopened.list()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{newcomer.Pretty(), occasional.Pretty()}, knownPeerIDs(knownPeers))
+
+	// Peers failing too many redials in a row are forgotten
+	for i := 0; i < maxKnownPeerFailures; i++ {
+		assert.NoError(t, reopened.failed(newcomer))
+	}
+	knownPeers, err = reopened.list()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(knownPeers))
+	assert.Equal(t, occasional.Pretty(), knownPeers[0].GetId())
+	assert.Len(t, reopened.lastSeen, 1)
+	assert.NoError(t, reopened.failed(newcomer))
+}
+
+func TestKnownPeersRedial(t *testing.T) {
+	storage := &inmemory.Storage{Db: make(map[string]string)}
+	remote := newTestP2p(t, (*P2p).initNegotiation)
+	defer remote.host.Close()
+
+	p2pInstance := newTestP2p(t, (*P2p).initNegotiation)
+	p2pInstance.RegisterStorage(storage)
+	p2pInstance.initKnownPeers()
+	assert.NoError(t, p2pInstance.host.Connect(context.Background(), peer.AddrInfo{ID: remote.host.ID(), Addrs: remote.host.Addrs()}))
+
+	deadline := time.Now().Add(5 * time.Second)
+	knownPeers, err := p2pInstance.GetKnownPeers()
+	for len(knownPeers) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		knownPeers, err = p2pInstance.GetKnownPeers()
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(knownPeers))
+	assert.Equal(t, remote.host.ID().Pretty(), knownPeers[0].GetId())
+	p2pInstance.host.Close()
+
+	// A restarted node redials the peers it knew without being told their addresses
+	restarted := newTestP2p(t, (*P2p).initNegotiation)
+	defer restarted.host.Close()
+	restarted.RegisterStorage(storage)
+	restarted.connectToKnownPeers()
+	assert.NotEmpty(t, restarted.host.Network().ConnsToPeer(remote.host.ID()))
+}
@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	mdns "github.com/libp2p/go-libp2p/p2p/discovery"
+	"github.com/sprawl/sprawl/errors"
+)
+
+// mdnsServiceTag separates Sprawl nodes from other libp2p applications on the local network
+const mdnsServiceTag = "_sprawl-discovery._udp"
+
+// defaultMDNSInterval is used when p2p.mdnsInterval isn't configured
+const defaultMDNSInterval = 10 * time.Second
+
+// mdnsNotifee passes the peers found on the local network to the same connection path as DHT discovery
+type mdnsNotifee struct {
+	p2p *P2p
+}
+
+// HandlePeerFound connects to a peer found with mDNS
+func (notifee *mdnsNotifee) HandlePeerFound(peerInfo peer.AddrInfo) {
+	notifee.p2p.connectToFoundPeer(notifee.p2p.ctx, peerInfo)
+}
+
+// initMDNS starts looking for Sprawl nodes on the local network if it's enabled in p2p.Config
+func (p2p *P2p) initMDNS() {
+	if !p2p.Config.GetBool("p2p.enableMDNS") {
+		return
+	}
+
+	interval := time.Duration(p2p.Config.GetUint("p2p.mdnsInterval")) * time.Second
+	if interval == 0 {
+		interval = defaultMDNSInterval
+	}
+
+	service, err := mdns.NewMdnsService(p2p.ctx, p2p.host, interval, mdnsServiceTag)
+	if !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Error(errors.E(errors.Op("Start mDNS discovery"), err))
+		}
+		return
+	}
+	service.RegisterNotifee(&mdnsNotifee{p2p: p2p})
+	p2p.mdns = service
+
+	if p2p.Logger != nil {
+		p2p.Logger.Infof("Looking for peers on the local network every %s", interval)
+	}
+}
@@ -0,0 +1,52 @@
+package p2p
+
+import (
+	"os"
+	"testing"
+
+	libp2p "github.com/libp2p/go-libp2p"
+	network "github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsEnableMDNS string = "SPRAWL_P2P_ENABLEMDNS"
+const localListenAddr string = "/ip4/127.0.0.1/tcp/0"
+
+func TestInitMDNS(t *testing.T) {
+	readTestConfig()
+	defer os.Unsetenv(optionsEnableMDNS)
+
+	p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+	p2pInstance.initContext()
+	p2pInstance.host, _ = libp2p.New(p2pInstance.ctx, libp2p.ListenAddrStrings(localListenAddr))
+	defer p2pInstance.host.Close()
+
+	p2pInstance.initMDNS()
+	assert.Nil(t, p2pInstance.mdns)
+
+	os.Setenv(optionsEnableMDNS, "true")
+	p2pInstance.initMDNS()
+	assert.NotNil(t, p2pInstance.mdns)
+	assert.NoError(t, p2pInstance.mdns.Close())
+}
+
+func TestMDNSNotifee(t *testing.T) {
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	p2pInstance.initContext()
+	p2pInstance.host, _ = libp2p.New(p2pInstance.ctx, libp2p.ListenAddrStrings(localListenAddr))
+	defer p2pInstance.host.Close()
+
+	otherHost, err := libp2p.New(p2pInstance.ctx, libp2p.ListenAddrStrings(localListenAddr))
+	assert.NoError(t, err)
+	defer otherHost.Close()
+
+	notifee := &mdnsNotifee{p2p: p2pInstance}
+
+	// Finding yourself doesn't connect anywhere
+	notifee.HandlePeerFound(peer.AddrInfo{ID: p2pInstance.host.ID(), Addrs: p2pInstance.host.Addrs()})
+	assert.Empty(t, p2pInstance.host.Network().Peers())
+
+	notifee.HandlePeerFound(peer.AddrInfo{ID: otherHost.ID(), Addrs: otherHost.Addrs()})
+	assert.Equal(t, network.Connected, p2pInstance.host.Network().Connectedness(otherHost.ID()))
+}
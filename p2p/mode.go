@@ -0,0 +1,47 @@
+package p2p
+
+import (
+	"strings"
+
+	"github.com/sprawl/sprawl/errors"
+)
+
+// Run modes selected with p2p.mode. Bootstrap and relay nodes are infrastructure nodes that don't trade:
+// bootstrap nodes serve the DHT and discovery, and relay nodes also act as a circuit relay hop for NATed peers.
+// Observer nodes receive and store the orders of their channels, but never publish anything.
+const (
+	FullMode      = "full"
+	BootstrapMode = "bootstrap"
+	RelayMode     = "relay"
+	ObserverMode  = "observer"
+)
+
+// Mode returns the run mode selected in p2p.Config, defaulting to FullMode
+func (p2p *P2p) Mode() string {
+	mode := strings.ToLower(p2p.Config.GetString("p2p.mode"))
+	if mode == "" {
+		return FullMode
+	}
+	return mode
+}
+
+// IsInfrastructure tells whether the node runs in a mode that doesn't trade
+func (p2p *P2p) IsInfrastructure() bool {
+	mode := p2p.Mode()
+	return mode == BootstrapMode || mode == RelayMode
+}
+
+// ReadOnly tells whether the node is an observer that never publishes
+func (p2p *P2p) ReadOnly() bool {
+	return p2p.Mode() == ObserverMode
+}
+
+// checkMode refuses to run in an unknown mode, instead of silently running a full node
+func (p2p *P2p) checkMode() error {
+	switch p2p.Mode() {
+	case FullMode, BootstrapMode, RelayMode, ObserverMode:
+		return nil
+	default:
+		return errors.E(errors.Op("Check mode"), errors.Invalid, "unknown mode "+p2p.Mode())
+	}
+}
@@ -0,0 +1,95 @@
+package p2p
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsMode string = "SPRAWL_P2P_MODE"
+
+func TestMode(t *testing.T) {
+	readTestConfig()
+	defer os.Unsetenv(optionsMode)
+	p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+	assert.Equal(t, FullMode, p2pInstance.Mode())
+	assert.False(t, p2pInstance.IsInfrastructure())
+
+	os.Setenv(optionsMode, "Bootstrap")
+	assert.Equal(t, BootstrapMode, p2pInstance.Mode())
+	assert.True(t, p2pInstance.IsInfrastructure())
+
+	os.Setenv(optionsMode, RelayMode)
+	assert.True(t, p2pInstance.IsInfrastructure())
+
+	// Unknown modes are refused instead of running a full node
+	os.Setenv(optionsMode, "exchange")
+	err := p2pInstance.Run()
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
+
+func TestBootstrapModePeers(t *testing.T) {
+	readTestConfig()
+	defer os.Unsetenv(optionsMode)
+
+	// The first bootstrap node of a network doesn't fall back to the IPFS bootstrap peers
+	os.Setenv(optionsMode, BootstrapMode)
+	p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+	p2pInstance.addBootstrapPeers()
+	assert.Empty(t, p2pInstance.bootstrapPeers)
+
+	os.Setenv(optionsMode, RelayMode)
+	p2pInstance.addBootstrapPeers()
+	assert.NotEmpty(t, p2pInstance.bootstrapPeers)
+}
+
+func TestRelayMode(t *testing.T) {
+	readTestConfig()
+	defer os.Unsetenv(optionsMode)
+	os.Setenv(optionsMode, RelayMode)
+
+	p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+	assert.NoError(t, p2pInstance.Run())
+	p2pInstance.Close()
+}
+
+func TestObserverMode(t *testing.T) {
+	readTestConfig()
+	defer os.Unsetenv(optionsMode)
+	os.Setenv(optionsMode, ObserverMode)
+
+	p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+	assert.True(t, p2pInstance.ReadOnly())
+	assert.False(t, p2pInstance.IsInfrastructure())
+
+	// Observers never publish nor trade
+	err := p2pInstance.Send(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE})
+	assert.True(t, errors.Is(errors.Denied, err))
+	_, err = p2pInstance.RequestTake(context.Background(), p2pInstance.GetPeerID(), &pb.NegotiationMessage{Type: pb.NegotiationType_TAKE_REQUEST})
+	assert.True(t, errors.Is(errors.Denied, err))
+	assert.Equal(t, 0, p2pInstance.GetQueueStats().Length)
+}
+
+func TestModeAnnouncements(t *testing.T) {
+	defer os.Unsetenv(optionsMode)
+
+	// Only trading nodes announce their channels, the other modes listen to the directory
+	for mode, announces := range map[string]bool{FullMode: true, ObserverMode: false, BootstrapMode: false, RelayMode: false} {
+		os.Setenv(optionsMode, mode)
+		p2pInstance := newTestP2p(t, initTestPubSub(t))
+		sub, err := p2pInstance.ps.Subscribe(directoryTopic)
+		assert.NoError(t, err)
+		assert.NoError(t, p2pInstance.initDirectory())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		_, err = sub.Next(ctx)
+		cancel()
+		assert.Equal(t, announces, errors.IsEmpty(err), mode)
+		p2pInstance.Close()
+	}
+}
@@ -0,0 +1,397 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"sync"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	libp2p "github.com/libp2p/go-libp2p"
+	autonat "github.com/libp2p/go-libp2p-autonat"
+	autonatpb "github.com/libp2p/go-libp2p-autonat/pb"
+	circuit "github.com/libp2p/go-libp2p-circuit"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	libp2pConfig "github.com/libp2p/go-libp2p/config"
+	multiaddr "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr-net"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// reachabilityCheckInterval defines how often the AutoNAT status is checked for reachability changes
+const reachabilityCheckInterval = 10 * time.Second
+
+// autoNATDialTimeout limits how long the AutoNAT service tries to dial a peer back
+const autoNATDialTimeout = 15 * time.Second
+
+// autoNATThrottleInterval is the period in which a peer may ask for autoNATPeerRequests dial-backs
+const autoNATThrottleInterval = time.Minute
+
+const autoNATPeerRequests = 3
+
+// ReachabilityHandler is called with the previous and the current reachability of the node whenever it changes
+type ReachabilityHandler func(previous pb.Reachability, current pb.Reachability)
+
+// reachabilityTracker follows the reachability of the node detected by AutoNAT.
+// AutoNAT asks peers running the AutoNAT service to dial this node back.
+type reachabilityTracker struct {
+	sync.Mutex
+	autoNAT      autonat.AutoNAT
+	reachability pb.Reachability
+	lastChange   time.Time
+}
+
+func newReachabilityTracker(autoNAT autonat.AutoNAT) *reachabilityTracker {
+	return &reachabilityTracker{autoNAT: autoNAT, lastChange: time.Now()}
+}
+
+// update records the current reachability. The node is relayed when it isn't publicly dialable,
+// but announces relay addresses others can reach it through.
+func (tracker *reachabilityTracker) update(relayAddrs []multiaddr.Multiaddr, now time.Time) (previous pb.Reachability, current pb.Reachability) {
+	switch tracker.autoNAT.Status() {
+	case autonat.NATStatusPublic:
+		current = pb.Reachability_PUBLIC
+	case autonat.NATStatusPrivate:
+		if len(relayAddrs) > 0 {
+			current = pb.Reachability_RELAYED
+		} else {
+			current = pb.Reachability_UNREACHABLE
+		}
+	default:
+		current = pb.Reachability_REACHABILITY_UNKNOWN
+	}
+
+	tracker.Lock()
+	defer tracker.Unlock()
+	previous = tracker.reachability
+	if current != previous {
+		tracker.reachability = current
+		tracker.lastChange = now
+	}
+	return previous, current
+}
+
+func (tracker *reachabilityTracker) state() (pb.Reachability, time.Time) {
+	tracker.Lock()
+	defer tracker.Unlock()
+	return tracker.reachability, tracker.lastChange
+}
+
+// relayAddrs returns the circuit relay addresses the node announces
+func (p2p *P2p) relayAddrs() []multiaddr.Multiaddr {
+	relayAddrs := []multiaddr.Multiaddr{}
+	if p2p.host == nil {
+		return relayAddrs
+	}
+	for _, addr := range p2p.host.Addrs() {
+		if _, err := addr.ValueForProtocol(circuit.P_CIRCUIT); errors.IsEmpty(err) {
+			relayAddrs = append(relayAddrs, addr)
+		}
+	}
+	return relayAddrs
+}
+
+// initAutoNAT starts detecting whether the node is reachable from the internet if it's enabled in p2p.Config
+func (p2p *P2p) initAutoNAT() {
+	if !p2p.Config.GetBool("p2p.enableAutoNAT") {
+		return
+	}
+	p2p.setReachability(newReachabilityTracker(autonat.NewAutoNAT(p2p.ctx, p2p.host, nil)))
+
+	ctx := p2p.ctx
+	p2p.spawn(func() {
+		ticker := time.NewTicker(reachabilityCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p2p.checkReachability()
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// setReachability replaces the reachability tracker of the node, which is nil while AutoNAT isn't running
+func (p2p *P2p) setReachability(tracker *reachabilityTracker) {
+	p2p.natLock.Lock()
+	defer p2p.natLock.Unlock()
+	p2p.reachability = tracker
+}
+
+// getReachability returns the reachability tracker of the node. Close clears it while status calls
+// may still be reading it, so it's only read through here.
+func (p2p *P2p) getReachability() *reachabilityTracker {
+	p2p.natLock.Lock()
+	defer p2p.natLock.Unlock()
+	return p2p.reachability
+}
+
+// OnReachabilityChange registers a handler that's called whenever AutoNAT detects a change in the reachability
+// of the node. Handlers stay registered when the node is closed and run again.
+func (p2p *P2p) OnReachabilityChange(handler ReachabilityHandler) {
+	p2p.natLock.Lock()
+	defer p2p.natLock.Unlock()
+	p2p.natHandlers = append(p2p.natHandlers, handler)
+}
+
+// checkReachability logs and reports an event to the registered handlers whenever the reachability
+// of the node changes. Nodes found to be public start serving dial-backs to the peers behind NATs.
+func (p2p *P2p) checkReachability() {
+	tracker := p2p.getReachability()
+	if tracker == nil {
+		return
+	}
+	previous, current := tracker.update(p2p.relayAddrs(), time.Now())
+	if previous == current {
+		return
+	}
+	if p2p.Logger != nil {
+		switch current {
+		case pb.Reachability_UNREACHABLE:
+			p2p.Logger.Warnf("Reachability changed from %s to %s: peers can't dial this node. Consider enabling NAT port mapping, setting an external IP or enabling auto relay", previous, current)
+		default:
+			p2p.Logger.Infof("Reachability changed from %s to %s", previous, current)
+		}
+	}
+	if current == pb.Reachability_PUBLIC {
+		p2p.startAutoNATService()
+	}
+
+	p2p.natLock.Lock()
+	handlers := append([]ReachabilityHandler{}, p2p.natHandlers...)
+	p2p.natLock.Unlock()
+	for _, handler := range handlers {
+		handler(previous, current)
+	}
+}
+
+// autoNATService answers the dial-back requests of AutoNAT clients, telling them whether they're publicly reachable.
+// Peers are dialed back from a separate host without listen addresses, so the connection the request came in on
+// isn't reused, and only at addresses with the IP the request came from, so the service can't be used to make
+// this node dial arbitrary hosts. Private addresses are only dialed when allowPrivate is set.
+type autoNATService struct {
+	sync.Mutex
+	dialer       host.Host
+	requests     map[peer.ID]int
+	since        time.Time
+	allowPrivate bool
+}
+
+func newAutoNATService(ctx context.Context, options ...libp2pConfig.Option) (*autoNATService, error) {
+	dialer, err := libp2p.New(ctx, append([]libp2pConfig.Option{libp2p.NoListenAddrs}, options...)...)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Add AutoNAT dialer"), err)
+	}
+	return &autoNATService{dialer: dialer, requests: make(map[peer.ID]int), since: time.Now()}, nil
+}
+
+// allow tells whether a peer may be dialed back, allowing autoNATPeerRequests dial-backs per autoNATThrottleInterval
+func (service *autoNATService) allow(id peer.ID, now time.Time) bool {
+	service.Lock()
+	defer service.Unlock()
+	if now.Sub(service.since) > autoNATThrottleInterval {
+		service.requests = make(map[peer.ID]int)
+		service.since = now
+	}
+	if service.requests[id] >= autoNATPeerRequests {
+		return false
+	}
+	service.requests[id]++
+	return true
+}
+
+// addrIP returns the IP address a multiaddress starts with, or an empty string if it doesn't start with one
+func addrIP(addr multiaddr.Multiaddr) string {
+	if ip, err := addr.ValueForProtocol(multiaddr.P_IP4); errors.IsEmpty(err) {
+		return ip
+	}
+	if ip, err := addr.ValueForProtocol(multiaddr.P_IP6); errors.IsEmpty(err) {
+		return ip
+	}
+	return ""
+}
+
+// dialAddrs returns the addresses a peer asked to be dialed back at that share the IP of the observed address
+// its request came from. The observed address is dialed too, since NATs may keep the port mapping open.
+func (service *autoNATService) dialAddrs(observed multiaddr.Multiaddr, requested [][]byte) []multiaddr.Multiaddr {
+	if _, err := observed.ValueForProtocol(circuit.P_CIRCUIT); errors.IsEmpty(err) {
+		return nil
+	}
+	ip := addrIP(observed)
+	if ip == "" {
+		return nil
+	}
+	addrs := []multiaddr.Multiaddr{}
+	for _, data := range append([][]byte{observed.Bytes()}, requested...) {
+		addr, err := multiaddr.NewMultiaddrBytes(data)
+		if !errors.IsEmpty(err) || addrIP(addr) != ip {
+			continue
+		}
+		if _, err := addr.ValueForProtocol(circuit.P_CIRCUIT); errors.IsEmpty(err) {
+			continue
+		}
+		if !service.allowPrivate && !manet.IsPublicAddr(addr) {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// dialBack connects to a peer from the dialer host and returns the address the connection was made to
+func (service *autoNATService) dialBack(ctx context.Context, id peer.ID, addrs []multiaddr.Multiaddr) (multiaddr.Multiaddr, error) {
+	ctx, cancel := context.WithTimeout(ctx, autoNATDialTimeout)
+	defer cancel()
+	service.dialer.Peerstore().ClearAddrs(id)
+	err := service.dialer.Connect(ctx, peer.AddrInfo{ID: id, Addrs: addrs})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Dial back"), err)
+	}
+	defer service.dialer.Network().ClosePeer(id)
+	conns := service.dialer.Network().ConnsToPeer(id)
+	if len(conns) == 0 {
+		return nil, errors.E(errors.Op("Dial back"), errors.Unavailable, "connection closed before it was checked")
+	}
+	return conns[0].RemoteMultiaddr(), nil
+}
+
+func newDialResponse(status autonatpb.Message_ResponseStatus, text string, addr multiaddr.Multiaddr) *autonatpb.Message {
+	response := &autonatpb.Message{
+		Type:         autonatpb.Message_DIAL_RESPONSE.Enum(),
+		DialResponse: &autonatpb.Message_DialResponse{Status: status.Enum(), StatusText: &text},
+	}
+	if addr != nil {
+		response.DialResponse.Addr = addr.Bytes()
+	}
+	return response
+}
+
+// handleAutoNAT returns a stream handler answering a dial-back request with the result of dialing the requesting peer
+func (p2p *P2p) handleAutoNAT(service *autoNATService) network.StreamHandler {
+	run := p2p.ctx
+	return func(stream network.Stream) {
+		if !p2p.enter(run) {
+			stream.Reset()
+			return
+		}
+		defer p2p.routines.Done()
+		defer stream.Close()
+		stream.SetDeadline(time.Now().Add(autonat.AutoNATRequestTimeout))
+		from := stream.Conn().RemotePeer()
+
+		request := &autonatpb.Message{}
+		err := readFramedMessage(bufio.NewReader(stream), request)
+		if !errors.IsEmpty(err) {
+			if p2p.Logger != nil {
+				p2p.Logger.Debug(errors.E(errors.Op("Handle AutoNAT"), err))
+			}
+			stream.Reset()
+			return
+		}
+
+		var response *autonatpb.Message
+		switch {
+		case request.GetType() != autonatpb.Message_DIAL:
+			response = newDialResponse(autonatpb.Message_E_BAD_REQUEST, "expected a dial request", nil)
+		case peer.ID(request.GetDial().GetPeer().GetId()) != from:
+			response = newDialResponse(autonatpb.Message_E_BAD_REQUEST, "peer ID doesn't match the connection", nil)
+		case !service.allow(from, time.Now()):
+			response = newDialResponse(autonatpb.Message_E_DIAL_REFUSED, "too many dial-back requests", nil)
+		default:
+			addrs := service.dialAddrs(stream.Conn().RemoteMultiaddr(), request.GetDial().GetPeer().GetAddrs())
+			if len(addrs) == 0 {
+				response = newDialResponse(autonatpb.Message_E_DIAL_REFUSED, "no dialable addresses", nil)
+				break
+			}
+			addr, err := service.dialBack(run, from, addrs)
+			if !errors.IsEmpty(err) {
+				response = newDialResponse(autonatpb.Message_E_DIAL_ERROR, err.Error(), nil)
+			} else {
+				response = newDialResponse(autonatpb.Message_OK, "", addr)
+			}
+		}
+		err = writeFramedMessage(stream, response)
+		if !errors.IsEmpty(err) && p2p.Logger != nil {
+			p2p.Logger.Debug(errors.E(errors.Op("Handle AutoNAT"), err))
+		}
+	}
+}
+
+// startAutoNATService starts answering the dial-back requests of AutoNAT clients, unless the node already does
+func (p2p *P2p) startAutoNATService() {
+	p2p.natLock.Lock()
+	defer p2p.natLock.Unlock()
+	if p2p.autoNATService != nil {
+		return
+	}
+	options := []libp2pConfig.Option{}
+	if p2p.isPrivateNetwork() {
+		protector, err := newProtector(p2p.Config.GetString("p2p.privateNetworkKey"))
+		if !errors.IsEmpty(err) {
+			if p2p.Logger != nil {
+				p2p.Logger.Error(errors.E(errors.Op("Start AutoNAT service"), err))
+			}
+			return
+		}
+		options = append(options, libp2p.PrivateNetwork(protector))
+	}
+	service, err := newAutoNATService(p2p.ctx, options...)
+	if !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Error(errors.E(errors.Op("Start AutoNAT service"), err))
+		}
+		return
+	}
+	p2p.autoNATService = service
+	p2p.host.SetStreamHandler(autonat.AutoNATProto, p2p.gated(p2p.handleAutoNAT(service)))
+	if p2p.Logger != nil {
+		p2p.Logger.Info("Answering AutoNAT dial-back requests")
+	}
+}
+
+// closeAutoNATService stops the dialer of the AutoNAT service. The stream handler is removed along with the host.
+func (p2p *P2p) closeAutoNATService() {
+	p2p.natLock.Lock()
+	defer p2p.natLock.Unlock()
+	if p2p.autoNATService == nil {
+		return
+	}
+	err := p2p.autoNATService.dialer.Close()
+	if !errors.IsEmpty(err) && p2p.Logger != nil {
+		p2p.Logger.Error(errors.E(errors.Op("Close AutoNAT dialer"), err))
+	}
+	p2p.autoNATService = nil
+}
+
+// GetNATStatus reports whether the node is publicly reachable, relayed or unreachable,
+// along with the relay addresses in use, the addresses other peers have observed
+// and whether the node answers the dial-back requests of other peers
+func (p2p *P2p) GetNATStatus() *pb.NATStatus {
+	status := &pb.NATStatus{
+		RelayAddrs:    addrsToStrings(p2p.relayAddrs()),
+		ObservedAddrs: []string{},
+	}
+	if p2p.host != nil {
+		status.ObservedAddrs = addrsToStrings(p2p.observedAddrs())
+	}
+	p2p.natLock.Lock()
+	status.AutoNATService = p2p.autoNATService != nil
+	p2p.natLock.Unlock()
+	tracker := p2p.getReachability()
+	if tracker == nil {
+		return status
+	}
+	status.AutoNAT = true
+	reachability, lastChange := tracker.state()
+	status.Reachability = reachability
+	status.LastChange, _ = ptypes.TimestampProto(lastChange)
+	if publicAddr, err := tracker.autoNAT.PublicAddr(); errors.IsEmpty(err) {
+		status.PublicAddr = publicAddr.String()
+	}
+	return status
+}
@@ -0,0 +1,158 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	libp2p "github.com/libp2p/go-libp2p"
+	autonat "github.com/libp2p/go-libp2p-autonat"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	multiaddr "github.com/multiformats/go-multiaddr"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAutoNAT reports a fixed NAT status instead of asking peers to dial back
+type fakeAutoNAT struct {
+	status autonat.NATStatus
+	addr   multiaddr.Multiaddr
+}
+
+func (fake *fakeAutoNAT) Status() autonat.NATStatus {
+	return fake.status
+}
+
+func (fake *fakeAutoNAT) PublicAddr() (multiaddr.Multiaddr, error) {
+	if fake.status != autonat.NATStatusPublic {
+		return nil, errors.E(errors.Op("Public addr"), "not public")
+	}
+	return fake.addr, nil
+}
+
+func TestReachabilityTracker(t *testing.T) {
+	fake := &fakeAutoNAT{status: autonat.NATStatusUnknown}
+	tracker := newReachabilityTracker(fake)
+	relayAddr, err := multiaddr.NewMultiaddr("/ip4/1.2.3.4/tcp/4001/ipfs/QmaCpDMGvV2BGHeYERUEnRQAwe3N8SzbUtfsmvsqQLuvuJ/p2p-circuit")
+	assert.NoError(t, err)
+	start := time.Now()
+
+	previous, current := tracker.update(nil, start)
+	assert.Equal(t, pb.Reachability_REACHABILITY_UNKNOWN, previous)
+	assert.Equal(t, pb.Reachability_REACHABILITY_UNKNOWN, current)
+
+	fake.status = autonat.NATStatusPrivate
+	_, current = tracker.update(nil, start.Add(time.Second))
+	assert.Equal(t, pb.Reachability_UNREACHABLE, current)
+
+	// Private nodes announcing relay addresses are reachable through the relays
+	previous, current = tracker.update([]multiaddr.Multiaddr{relayAddr}, start.Add(2*time.Second))
+	assert.Equal(t, pb.Reachability_UNREACHABLE, previous)
+	assert.Equal(t, pb.Reachability_RELAYED, current)
+
+	fake.status = autonat.NATStatusPublic
+	_, current = tracker.update([]multiaddr.Multiaddr{relayAddr}, start.Add(3*time.Second))
+	assert.Equal(t, pb.Reachability_PUBLIC, current)
+
+	// The time of the last change isn't touched while the reachability stays the same
+	tracker.update(nil, start.Add(4*time.Second))
+	reachability, lastChange := tracker.state()
+	assert.Equal(t, pb.Reachability_PUBLIC, reachability)
+	assert.Equal(t, start.Add(3*time.Second), lastChange)
+}
+
+func TestNATStatus(t *testing.T) {
+	p2pInstance := newTestP2p(t, (*P2p).initNegotiation)
+	defer p2pInstance.Close()
+
+	status := p2pInstance.GetNATStatus()
+	assert.False(t, status.GetAutoNAT())
+	assert.False(t, status.GetAutoNATService())
+	assert.Empty(t, status.GetRelayAddrs())
+
+	changes := [][]pb.Reachability{}
+	p2pInstance.OnReachabilityChange(func(previous pb.Reachability, current pb.Reachability) {
+		changes = append(changes, []pb.Reachability{previous, current})
+	})
+	publicAddr, err := multiaddr.NewMultiaddr("/ip4/1.2.3.4/tcp/4001")
+	assert.NoError(t, err)
+	fake := &fakeAutoNAT{status: autonat.NATStatusPublic, addr: publicAddr}
+	p2pInstance.setReachability(newReachabilityTracker(fake))
+	p2pInstance.checkReachability()
+	p2pInstance.checkReachability()
+
+	// Handlers only hear about changes, and public nodes start answering dial-back requests
+	assert.Equal(t, [][]pb.Reachability{{pb.Reachability_REACHABILITY_UNKNOWN, pb.Reachability_PUBLIC}}, changes)
+	status = p2pInstance.GetNATStatus()
+	assert.True(t, status.GetAutoNAT())
+	assert.True(t, status.GetAutoNATService())
+	assert.Equal(t, pb.Reachability_PUBLIC, status.GetReachability())
+	assert.Equal(t, publicAddr.String(), status.GetPublicAddr())
+	assert.NotNil(t, status.GetLastChange())
+
+	fake.status = autonat.NATStatusPrivate
+	p2pInstance.checkReachability()
+	assert.Equal(t, []pb.Reachability{pb.Reachability_PUBLIC, pb.Reachability_UNREACHABLE}, changes[1])
+}
+
+func TestNATStatusWhileClosing(t *testing.T) {
+	p2pInstance := newTestP2p(t, (*P2p).initNegotiation)
+	p2pInstance.setReachability(newReachabilityTracker(&fakeAutoNAT{status: autonat.NATStatusPrivate}))
+
+	// Status calls keep working while Close drops the tracker
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			p2pInstance.GetNATStatus()
+		}
+	}()
+	p2pInstance.Close()
+	<-done
+	assert.False(t, p2pInstance.GetNATStatus().GetAutoNAT())
+}
+
+func TestAutoNATService(t *testing.T) {
+	p2pInstance := newTestP2p(t, (*P2p).initNegotiation)
+	defer p2pInstance.Close()
+	p2pInstance.startAutoNATService()
+	assert.NotNil(t, p2pInstance.autoNATService)
+
+	ctx := context.Background()
+	client, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	assert.NoError(t, err)
+	defer client.Close()
+	assert.NoError(t, client.Connect(ctx, peer.AddrInfo{ID: p2pInstance.host.ID(), Addrs: p2pInstance.host.Addrs()}))
+	dialBack := autonat.NewAutoNATClient(client, nil).DialBack
+
+	// Loopback addresses aren't dialed back on the internet
+	_, err = dialBack(ctx, p2pInstance.host.ID())
+	assert.True(t, autonat.IsDialRefused(err))
+
+	p2pInstance.autoNATService.allowPrivate = true
+	addr, err := dialBack(ctx, p2pInstance.host.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", addrIP(addr))
+
+	// Peers can only ask for a few dial-backs at a time
+	_, err = dialBack(ctx, p2pInstance.host.ID())
+	assert.NoError(t, err)
+	_, err = dialBack(ctx, p2pInstance.host.ID())
+	assert.True(t, autonat.IsDialRefused(err))
+}
+
+func TestAutoNATDialAddrs(t *testing.T) {
+	service := &autoNATService{}
+	observed := multiaddr.StringCast("/ip4/1.2.3.4/tcp/50000")
+	requested := [][]byte{
+		multiaddr.StringCast("/ip4/1.2.3.4/tcp/4001").Bytes(),
+		multiaddr.StringCast("/ip4/5.6.7.8/tcp/4001").Bytes(),
+		multiaddr.StringCast("/ip4/192.168.0.1/tcp/4001").Bytes(),
+		[]byte("garbage"),
+	}
+
+	// Only the addresses with the IP the request came from are dialed
+	assert.Equal(t, []multiaddr.Multiaddr{observed, multiaddr.StringCast("/ip4/1.2.3.4/tcp/4001")}, service.dialAddrs(observed, requested))
+	assert.Empty(t, service.dialAddrs(multiaddr.StringCast("/ip4/1.2.3.4/tcp/4001/ipfs/QmaCpDMGvV2BGHeYERUEnRQAwe3N8SzbUtfsmvsqQLuvuJ/p2p-circuit"), requested))
+}
@@ -0,0 +1,176 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// negotiationProtocol is the stream protocol used for two-party trade negotiation
+const negotiationProtocol = protocol.ID("/sprawl/negotiate/1.0.0")
+
+// negotiationTimeout defines how long a single negotiation may take before the stream is reset
+const negotiationTimeout = 30 * time.Second
+
+// maxNegotiationMessageSize limits the size of a single framed message on the negotiation and capabilities streams
+const maxNegotiationMessageSize = 64 * 1024
+
+// writeFramedMessage writes a message prefixed with its varint encoded length
+func writeFramedMessage(w io.Writer, message proto.Message) error {
+	data, err := proto.Marshal(message)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal framed message"), err)
+	}
+	length := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(length, uint64(len(data)))
+	_, err = w.Write(append(length[:n], data...))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Write framed message"), err)
+	}
+	return nil
+}
+
+// readFramedMessage reads a single message written by writeFramedMessage
+func readFramedMessage(r *bufio.Reader, message proto.Message) error {
+	length, err := binary.ReadUvarint(r)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Read framed message length"), err)
+	}
+	if length > maxNegotiationMessageSize {
+		return errors.E(errors.Op("Read framed message"), errors.Invalid, "framed message is too large")
+	}
+	data := make([]byte, length)
+	_, err = io.ReadFull(r, data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Read framed message"), err)
+	}
+	err = proto.Unmarshal(data, message)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal framed message"), errors.Invalid, err)
+	}
+	return nil
+}
+
+// writeNegotiationMessage writes a length prefixed negotiation message
+func writeNegotiationMessage(w io.Writer, message *pb.NegotiationMessage) error {
+	return writeFramedMessage(w, message)
+}
+
+// readNegotiationMessage reads a single message written by writeNegotiationMessage
+func readNegotiationMessage(r *bufio.Reader) (*pb.NegotiationMessage, error) {
+	message := &pb.NegotiationMessage{}
+	err := readFramedMessage(r, message)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return message, nil
+}
+
+// initNegotiation starts answering the take requests of other peers
+func (p2p *P2p) initNegotiation() {
+	p2p.host.SetStreamHandler(negotiationProtocol, p2p.gated(p2p.handleNegotiation))
+}
+
+// handleNegotiation answers a take request, passing it to the OrderService to accept and lock the order
+func (p2p *P2p) handleNegotiation(stream network.Stream) {
+	defer stream.Close()
+	stream.SetDeadline(time.Now().Add(negotiationTimeout))
+	from := stream.Conn().RemotePeer()
+
+	request, err := readNegotiationMessage(bufio.NewReader(stream))
+	if !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Warn(errors.E(errors.Op("Handle negotiation"), err))
+		}
+		stream.Reset()
+		return
+	}
+
+	defer p2p.protectNegotiation(from, request.GetOrderID())()
+
+	respond := func(response *pb.NegotiationMessage) error {
+		return writeNegotiationMessage(stream, response)
+	}
+
+	if request.GetType() != pb.NegotiationType_TAKE_REQUEST {
+		respond(&pb.NegotiationMessage{Type: pb.NegotiationType_REJECT, OrderID: request.GetOrderID(), Reason: "expected a take request"})
+		return
+	}
+	if p2p.Orders == nil {
+		respond(&pb.NegotiationMessage{Type: pb.NegotiationType_REJECT, OrderID: request.GetOrderID(), Reason: "orders are not served by this node"})
+		return
+	}
+
+	if p2p.Logger != nil {
+		p2p.Logger.Infof("Received a take request for order %x from peer %s", request.GetOrderID(), from)
+	}
+	err = p2p.Orders.Negotiate(request, from.Pretty(), respond)
+	if !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Warn(errors.E(errors.Op("Handle negotiation"), err))
+		}
+	}
+}
+
+// RequestTake asks the creator of an order to lock it for this node. The answer is either
+// a rejection or an acceptance followed by a lock confirmation, and the last one is returned.
+func (p2p *P2p) RequestTake(ctx context.Context, creator string, request *pb.NegotiationMessage) (*pb.NegotiationMessage, error) {
+	if p2p.ReadOnly() {
+		return nil, errors.E(errors.Op("Request take"), errors.Denied, "observer nodes don't trade")
+	}
+	peerID, err := peer.IDB58Decode(creator)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Decode creator peer ID"), errors.Invalid, err)
+	}
+
+	if capabilities, ok := p2p.capabilities.get(peerID); ok && !hasFeature(capabilities, featureNegotiation) {
+		return nil, errors.E(errors.Op("Request take"), errors.Unsupported, "peer doesn't support negotiation")
+	}
+
+	defer p2p.protectNegotiation(peerID, request.GetOrderID())()
+
+	ctx, cancel := context.WithTimeout(ctx, negotiationTimeout)
+	defer cancel()
+	stream, err := p2p.host.NewStream(ctx, peerID, negotiationProtocol)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Open negotiation stream"), err)
+	}
+	defer stream.Close()
+	stream.SetDeadline(time.Now().Add(negotiationTimeout))
+
+	request.Type = pb.NegotiationType_TAKE_REQUEST
+	err = writeNegotiationMessage(stream, request)
+	if !errors.IsEmpty(err) {
+		stream.Reset()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(stream)
+	for {
+		response, err := readNegotiationMessage(reader)
+		if !errors.IsEmpty(err) {
+			stream.Reset()
+			return nil, err
+		}
+		switch response.GetType() {
+		case pb.NegotiationType_ACCEPT:
+			if p2p.Logger != nil {
+				p2p.Logger.Debugf("Peer %s accepted the take request for order %x", creator, request.GetOrderID())
+			}
+		case pb.NegotiationType_REJECT, pb.NegotiationType_LOCK_CONFIRMATION:
+			return response, nil
+		default:
+			stream.Reset()
+			return nil, errors.E(errors.Op("Request take"), errors.Invalid, "unexpected negotiation message "+response.GetType().String())
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package p2p
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiationFraming(t *testing.T) {
+	buf := &bytes.Buffer{}
+	request := &pb.NegotiationMessage{Type: pb.NegotiationType_TAKE_REQUEST, OrderID: testOrder.GetId(), Amount: testOrder.GetAmount()}
+	accept := &pb.NegotiationMessage{Type: pb.NegotiationType_ACCEPT, OrderID: testOrder.GetId()}
+	assert.NoError(t, writeNegotiationMessage(buf, request))
+	assert.NoError(t, writeNegotiationMessage(buf, accept))
+
+	reader := bufio.NewReader(buf)
+	message, err := readNegotiationMessage(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, request.String(), message.String())
+	message, err = readNegotiationMessage(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, accept.String(), message.String())
+	_, err = readNegotiationMessage(reader)
+	assert.Error(t, err)
+
+	length := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(length, maxNegotiationMessageSize+1)
+	_, err = readNegotiationMessage(bufio.NewReader(bytes.NewReader(length[:n])))
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
+
+func TestRequestTake(t *testing.T) {
+	maker := newTestP2p(t, (*P2p).initNegotiation)
+	defer maker.host.Close()
+	taker := newTestP2p(t, (*P2p).initNegotiation)
+	defer taker.host.Close()
+	taker.host.Peerstore().AddAddrs(maker.host.ID(), maker.host.Addrs(), peerstore.PermanentAddrTTL)
+
+	request := &pb.NegotiationMessage{OrderID: testOrder.GetId(), Amount: testOrder.GetAmount()}
+
+	// Makers without an OrderService reject every request
+	response, err := taker.RequestTake(context.Background(), maker.host.ID().Pretty(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.NegotiationType_REJECT, response.GetType())
+
+	// The OrderService can't lock orders it doesn't store
+	maker.RegisterOrderService(&service.OrderService{})
+	response, err = taker.RequestTake(context.Background(), maker.host.ID().Pretty(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.NegotiationType_REJECT, response.GetType())
+	assert.Equal(t, testOrder.GetId(), response.GetOrderID())
+	assert.NotEmpty(t, response.GetReason())
+
+	_, err = taker.RequestTake(context.Background(), "notAPeerID", request)
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
@@ -0,0 +1,158 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
+	multiaddr "github.com/multiformats/go-multiaddr"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// identifier is implemented by hosts running the libp2p identify service
+type identifier interface {
+	IDService() *identify.IDService
+}
+
+func addrsToStrings(addrs []multiaddr.Multiaddr) []string {
+	strings := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		strings = append(strings, addr.String())
+	}
+	return strings
+}
+
+// observedAddrs returns the addresses other peers have seen this node connecting from. The routed host
+// created with the DHT doesn't expose the identify service, so the host it wraps is asked instead.
+func (p2p *P2p) observedAddrs() []multiaddr.Multiaddr {
+	if h, ok := p2p.host.(identifier); ok {
+		return h.IDService().OwnObservedAddrs()
+	}
+	if h, ok := p2p.innerHost.(identifier); ok {
+		return h.IDService().OwnObservedAddrs()
+	}
+	return []multiaddr.Multiaddr{}
+}
+
+// GetNodeInfo describes this node: its identity, its addresses and the protocol versions it supports
+func (p2p *P2p) GetNodeInfo() *pb.NodeInfo {
+	info := &pb.NodeInfo{
+		PeerID:        p2p.GetPeerID(),
+		ListenAddrs:   []string{},
+		ObservedAddrs: []string{},
+		WireVersion:   wireVersion,
+		Capabilities:  localCapabilities(),
+	}
+	info.PublicKey, _ = p2p.GetPublicKey()
+	if p2p.host != nil {
+		info.ListenAddrs = addrsToStrings(p2p.host.Addrs())
+		info.ObservedAddrs = addrsToStrings(p2p.observedAddrs())
+	}
+	return info
+}
+
+// GetPeers describes the connected peers ordered by ID, including their latency, protocols and connection direction
+func (p2p *P2p) GetPeers() []*pb.PeerInfo {
+	peers := make([]*pb.PeerInfo, 0)
+	if p2p.host == nil {
+		return peers
+	}
+	peerstore := p2p.host.Peerstore()
+	for _, id := range p2p.host.Network().Peers() {
+		info := &pb.PeerInfo{
+			PeerID:    id.Pretty(),
+			Addrs:     addrsToStrings(peerstore.Addrs(id)),
+			Latency:   ptypes.DurationProto(peerstore.LatencyEWMA(id)),
+			Protocols: []string{},
+		}
+		if protocols, err := peerstore.GetProtocols(id); errors.IsEmpty(err) {
+			sort.Strings(protocols)
+			info.Protocols = protocols
+		}
+		if agentVersion, err := peerstore.Get(id, "AgentVersion"); errors.IsEmpty(err) {
+			info.AgentVersion = fmt.Sprint(agentVersion)
+		}
+		if conns := p2p.host.Network().ConnsToPeer(id); len(conns) > 0 {
+			switch conns[0].Stat().Direction {
+			case network.DirInbound:
+				info.Direction = pb.Direction_INBOUND
+			case network.DirOutbound:
+				info.Direction = pb.Direction_OUTBOUND
+			}
+		}
+		if capabilities, ok := p2p.capabilities.get(id); ok {
+			info.Capabilities = capabilities
+		}
+		peers = append(peers, info)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].GetPeerID() < peers[j].GetPeerID()
+	})
+	return peers
+}
+
+// ConnectPeer connects to a peer. The address is either a multiaddress ending with the peer ID,
+// or a bare peer ID, in which case the addresses of the peer are looked up.
+func (p2p *P2p) ConnectPeer(ctx context.Context, address string) error {
+	var peerInfo peer.AddrInfo
+	if id, err := peer.IDB58Decode(address); errors.IsEmpty(err) {
+		peerInfo.ID = id
+	} else {
+		peerAddr, err := multiaddr.NewMultiaddr(address)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Connect peer"), errors.Invalid, err)
+		}
+		info, err := peer.AddrInfoFromP2pAddr(peerAddr)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Connect peer"), errors.Invalid, err)
+		}
+		peerInfo = *info
+	}
+
+	if peerInfo.ID == p2p.host.ID() {
+		return errors.E(errors.Op("Connect peer"), errors.Invalid, "can't connect to self")
+	}
+	if !p2p.gater.accepts(peerInfo.ID) {
+		return errors.E(errors.Op("Connect peer"), errors.Invalid, "peer is banned or not allowed")
+	}
+	err := p2p.host.Connect(ctx, peerInfo)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Connect peer"), errors.Unavailable, err)
+	}
+	return nil
+}
+
+// DisconnectPeer closes the connections to a peer. Disconnecting a peer that isn't connected returns a NotFound error.
+func (p2p *P2p) DisconnectPeer(pretty string) error {
+	id, err := peer.IDB58Decode(pretty)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Disconnect peer"), errors.Invalid, err)
+	}
+	if p2p.host.Network().Connectedness(id) != network.Connected {
+		return errors.E(errors.Op("Disconnect peer"), errors.NotFound, "peer is not connected")
+	}
+	err = p2p.host.Network().ClosePeer(id)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Disconnect peer"), err)
+	}
+	return nil
+}
+
+// GetSubscriptionInfo describes the subscribed channels, including when they were joined and their statistics
+func (p2p *P2p) GetSubscriptionInfo() []*pb.Subscription {
+	subscriptions := make([]*pb.Subscription, 0)
+	for _, state := range p2p.GetSubscriptions() {
+		since, _ := ptypes.TimestampProto(state.Since)
+		subscriptions = append(subscriptions, &pb.Subscription{
+			Channel: state.Channel,
+			Since:   since,
+			Stats:   p2p.GetChannelStats(state.Channel),
+		})
+	}
+	return subscriptions
+}
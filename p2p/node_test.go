@@ -0,0 +1,66 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeInfo(t *testing.T) {
+	p2pInstance := newTestP2p(t, (*P2p).initNegotiation)
+	defer p2pInstance.host.Close()
+
+	info := p2pInstance.GetNodeInfo()
+	assert.Equal(t, p2pInstance.GetPeerID(), info.GetPeerID())
+	publicKey, err := p2pInstance.GetPublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, publicKey, info.GetPublicKey())
+	assert.NotEmpty(t, info.GetListenAddrs())
+	assert.Equal(t, wireVersion, info.GetWireVersion())
+	assert.Equal(t, localCapabilities().String(), info.GetCapabilities().String())
+}
+
+func TestConnectAndDisconnectPeer(t *testing.T) {
+	dialer := newTestP2p(t, (*P2p).initNegotiation)
+	defer dialer.host.Close()
+	listener := newTestP2p(t, (*P2p).initNegotiation)
+	defer listener.host.Close()
+
+	err := dialer.ConnectPeer(context.Background(), "notAnAddress")
+	assert.True(t, errors.Is(errors.Invalid, err))
+	err = dialer.ConnectPeer(context.Background(), dialer.host.ID().Pretty())
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	address := fmt.Sprintf("%s/ipfs/%s", listener.host.Addrs()[0], listener.host.ID().Pretty())
+	assert.NoError(t, dialer.ConnectPeer(context.Background(), address))
+
+	peers := dialer.GetPeers()
+	assert.Equal(t, 1, len(peers))
+	assert.Equal(t, listener.host.ID().Pretty(), peers[0].GetPeerID())
+	assert.Equal(t, pb.Direction_OUTBOUND, peers[0].GetDirection())
+	assert.NotEmpty(t, peers[0].GetAddrs())
+	assert.NotNil(t, peers[0].GetLatency())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(listener.GetPeers()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, pb.Direction_INBOUND, listener.GetPeers()[0].GetDirection())
+
+	assert.NoError(t, dialer.DisconnectPeer(listener.host.ID().Pretty()))
+	assert.Empty(t, dialer.GetPeers())
+	err = dialer.DisconnectPeer(listener.host.ID().Pretty())
+	assert.True(t, errors.Is(errors.NotFound, err))
+	err = dialer.DisconnectPeer("notAPeerID")
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	// Banned peers can't be connected to
+	assert.NoError(t, dialer.BanPeer(listener.host.ID().Pretty()))
+	err = dialer.ConnectPeer(context.Background(), address)
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
@@ -1,10 +1,15 @@
 package p2p
 
 import (
+	"encoding/hex"
 	"fmt"
+	"net"
 	"github.com/sprawl/sprawl/errors"
 
 	libp2p "github.com/libp2p/go-libp2p"
+	circuit "github.com/libp2p/go-libp2p-circuit"
+	ipnet "github.com/libp2p/go-libp2p-core/pnet"
+	pnet "github.com/libp2p/go-libp2p-pnet"
 	libp2pConfig "github.com/libp2p/go-libp2p/config"
 	ma "github.com/multiformats/go-multiaddr"
 )
@@ -18,44 +23,163 @@ func defaultListenAddrs(p2pPort string) []ma.Multiaddr {
 	return multiaddrs
 }
  
-func createMultiAddr(externalIP string, p2pPort string) (ma.Multiaddr, error) {
-	return ma.NewMultiaddr(fmt.Sprintf(addrTemplate, externalIP, p2pPort))
+// checkTransport makes sure a listen address uses a transport this node has: TCP over IPv4 or IPv6, optionally with WebSocket.
+// QUIC is refused: the only QUIC transport compatible with libp2p v0.2.1 is go-libp2p-quic-transport v0.1.1,
+// whose quic-go v0.11 panics on startup with Go 1.13 and later, so QUIC waits for a libp2p upgrade.
+func checkTransport(addr ma.Multiaddr) error {
+	protocols := addr.Protocols()
+	for _, protocol := range protocols {
+		if protocol.Name == "quic" {
+			return errors.E(errors.Op("Check transport"), errors.Unsupported, "the QUIC transport needs a newer libp2p than this build uses")
+		}
+	}
+	if len(protocols) < 2 || len(protocols) > 3 {
+		return errors.E(errors.Op("Check transport"), errors.Unsupported, "expected an IP address, a TCP port and optionally /ws")
+	}
+	if protocols[0].Code != ma.P_IP4 && protocols[0].Code != ma.P_IP6 {
+		return errors.E(errors.Op("Check transport"), errors.Unsupported, "listen addresses must start with /ip4 or /ip6")
+	}
+	if protocols[1].Code != ma.P_TCP {
+		return errors.E(errors.Op("Check transport"), errors.Unsupported, "listen addresses must use TCP")
+	}
+	if len(protocols) == 3 && protocols[2].Name != "ws" {
+		return errors.E(errors.Op("Check transport"), errors.Unsupported, "unsupported transport "+protocols[2].Name)
+	}
+	return nil
+}
+
+// listenAddrs returns the addresses defined in p2p.listenAddrs, skipping invalid ones.
+// Without any, the node listens on TCP on all IPv4 interfaces on p2p.port.
+func (p2p *P2p) listenAddrs() []ma.Multiaddr {
+	multiaddrs := []ma.Multiaddr{}
+	for _, addr := range p2p.Config.GetStringSlice("p2p.listenAddrs") {
+		listenAddr, err := ma.NewMultiaddr(addr)
+		if errors.IsEmpty(err) {
+			err = checkTransport(listenAddr)
+		}
+		if !errors.IsEmpty(err) {
+			if p2p.Logger != nil {
+				p2p.Logger.Error(errors.E(errors.Op("Parse listen address"), fmt.Sprintf("%v, address: %s", err, addr)))
+			}
+			continue
+		}
+		multiaddrs = append(multiaddrs, listenAddr)
+	}
+	if len(multiaddrs) == 0 {
+		return defaultListenAddrs(p2p.Config.GetString("p2p.port"))
+	}
+	return multiaddrs
+}
+
+// externalAddr returns the address a listen address is reachable at from outside, keeping its transport
+// and port but replacing its IP with the external one. Listen addresses of the other IP version have none.
+func externalAddr(listenAddr ma.Multiaddr, externalIP string) (ma.Multiaddr, bool) {
+	ip := net.ParseIP(externalIP)
+	if ip == nil {
+		return nil, false
+	}
+	ipProtocol, transport := ma.SplitFirst(listenAddr)
+	if ipProtocol == nil || transport == nil {
+		return nil, false
+	}
+	var external ma.Multiaddr
+	var err error
+	if ip.To4() != nil && ipProtocol.Protocol().Code == ma.P_IP4 {
+		external, err = ma.NewMultiaddr("/ip4/" + ip.String())
+	} else if ip.To4() == nil && ipProtocol.Protocol().Code == ma.P_IP6 {
+		external, err = ma.NewMultiaddr("/ip6/" + ip.String())
+	} else {
+		return nil, false
+	}
+	if !errors.IsEmpty(err) {
+		return nil, false
+	}
+	return external.Encapsulate(transport), true
+}
+
+// newProtector creates a private network protector from a hex encoded 32 byte pre-shared key
+func newProtector(privateNetworkKey string) (ipnet.Protector, error) {
+	key, err := hex.DecodeString(privateNetworkKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Decode private network key"), err)
+	}
+	if len(key) != 32 {
+		return nil, errors.E(errors.Op("Decode private network key"), "private network key must be 32 bytes long")
+	}
+	var psk [32]byte
+	copy(psk[:], key)
+	return pnet.NewV1ProtectorFromBytes(&psk)
+}
+
+// isPrivateNetwork tells whether the node only connects to peers sharing its private network key
+func (p2p *P2p) isPrivateNetwork() bool {
+	return p2p.Config.GetString("p2p.privateNetworkKey") != ""
 }
 
 // CreateOptions queries p2p.Config for any user-submitted options and assigns defaults
 func (p2p *P2p) CreateOptions() []libp2pConfig.Option {
 	options := []libp2pConfig.Option{}
 	externalIP := p2p.Config.GetString("p2p.externalIP")
-	p2pPort := p2p.Config.GetString("p2p.port")
 
 	// Non-configurable options, since we always need an identity and the DHT discovery
 	options = append(options, p2p.initDHT())
 	options = append(options, libp2p.Identity(p2p.privateKey))
 
-	// libp2p relay options
-	if p2p.Config.GetBool("p2p.enableRelay") {
-		options = append(options, libp2p.EnableRelay())
+	// Connection manager keeping the amount of connections between the watermarks
+	if p2p.connManager != nil {
+		options = append(options, libp2p.ConnectionManager(p2p.connManager))
 	}
-	if p2p.Config.GetBool("p2p.enableAutoRelay") {
+
+	// Private network option. An invalid key fails the host creation instead of silently joining the public network.
+	if p2p.isPrivateNetwork() {
+		protector, err := newProtector(p2p.Config.GetString("p2p.privateNetworkKey"))
+		if !errors.IsEmpty(err) {
+			if p2p.Logger != nil {
+				p2p.Logger.Error(errors.E(errors.Op("Creating private network protector"), err))
+			}
+			options = append(options, func(cfg *libp2pConfig.Config) error {
+				return err
+			})
+		} else {
+			options = append(options, libp2p.PrivateNetwork(protector))
+		}
+	}
+
+	// libp2p relay options. Relay nodes act as a hop for other peers, and advertise themselves
+	// on the DHT so NATed peers with auto relay enabled find them.
+	if p2p.Mode() == RelayMode {
+		options = append(options, libp2p.EnableRelay(circuit.OptHop))
 		options = append(options, libp2p.EnableAutoRelay())
+	} else {
+		if p2p.Config.GetBool("p2p.enableRelay") {
+			options = append(options, libp2p.EnableRelay())
+		}
+		if p2p.Config.GetBool("p2p.enableAutoRelay") {
+			options = append(options, libp2p.EnableAutoRelay())
+		}
 	}
 
 	// If NAT port map is not enabled, define listened addresses and port manually
 	if p2p.Config.GetBool("p2p.enableNATPortMap") {
 		options = append(options, libp2p.NATPortMap())
 	} else {
-		multiaddrs := defaultListenAddrs(p2pPort)
+		listenAddrs := p2p.listenAddrs()
+		multiaddrs := append([]ma.Multiaddr{}, listenAddrs...)
 		if externalIP != "" {
-			extMultiAddr, err := createMultiAddr(externalIP, p2pPort)
-			if !errors.IsEmpty(err) {
-				p2p.Logger.Error(errors.E(errors.Op("Creating multiaddr"), err))
+			// Announce the external IP on every transport the node listens on
+			for _, listenAddr := range listenAddrs {
+				if extMultiAddr, ok := externalAddr(listenAddr, externalIP); ok {
+					multiaddrs = append(multiaddrs, extMultiAddr)
+				}
+			}
+			if len(multiaddrs) == len(listenAddrs) && p2p.Logger != nil {
+				p2p.Logger.Error(errors.E(errors.Op("Creating multiaddr"), "no listen address matches the external IP "+externalIP))
 			}
-			multiaddrs = append(multiaddrs, extMultiAddr)
 		}
 		addrFactory := func(addrs []ma.Multiaddr) []ma.Multiaddr {
 			return multiaddrs
 		}
-		options = append(options, libp2p.ListenAddrs(multiaddrs...))
+		options = append(options, libp2p.ListenAddrs(listenAddrs...))
 		options = append(options, libp2p.AddrsFactory(addrFactory))
 	}
 
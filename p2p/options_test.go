@@ -1,14 +1,18 @@
 package p2p
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"os"
 	"testing"
 
 	config "github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/identity"
 	libp2p "github.com/libp2p/go-libp2p"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
 	libp2pConfig "github.com/libp2p/go-libp2p/config"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +23,11 @@ const optionsEnableRelay string = "SPRAWL_P2P_ENABLERELAY"
 const optionsEnableAutoRelay string = "SPRAWL_P2P_ENABLEAUTORELAY"
 const optionsEnableNATPortMap string = "SPRAWL_P2P_ENABLENATPORTMAP"
 const optionsExternalIP string = "SPRAWL_P2P_EXTERNALIP"
+const optionsPrivateNetworkKey string = "SPRAWL_P2P_PRIVATENETWORKKEY"
+const optionsBootstrapPeers string = "SPRAWL_P2P_BOOTSTRAPPEERS"
+const optionsListenAddrs string = "SPRAWL_P2P_LISTENADDRS"
+const testPrivateNetworkKey string = "6a5c0bcb4a7ba7d42ef3b9c0e7c0be8bd3b2e5e4c4d6b64a0e4c1e77c6a1f0aa"
+const testBootstrapPeer string = "/ip4/10.0.0.1/tcp/4001/ipfs/QmaCpDMGvV2BGHeYERUEnRQAwe3N8SzbUtfsmvsqQLuvuJ"
 
 var appConfig *config.Config
 
@@ -33,6 +42,9 @@ func resetOptions() {
 	os.Unsetenv(optionsEnableAutoRelay)
 	os.Unsetenv(optionsEnableNATPortMap)
 	os.Unsetenv(optionsExternalIP)
+	os.Unsetenv(optionsPrivateNetworkKey)
+	os.Unsetenv(optionsBootstrapPeers)
+	os.Unsetenv(optionsListenAddrs)
 }
 
 func TestCreateOptions(t *testing.T) {
@@ -63,8 +75,9 @@ func TestCreateOptions(t *testing.T) {
 	externalIP := "192.168.0.1"
 	os.Setenv(optionsExternalIP, externalIP)
 	multiaddrs = defaultListenAddrs(appConfig.GetString("p2p.port"))
-	externalMultiaddr, err := createMultiAddr(externalIP, appConfig.GetString("p2p.port"))
-	assert.Nil(t, err)
+	externalMultiaddr, ok := externalAddr(multiaddrs[0], externalIP)
+	assert.True(t, ok)
+	assert.Equal(t, fmt.Sprintf("/ip4/%s/tcp/%s", externalIP, appConfig.GetString("p2p.port")), externalMultiaddr.String())
 	multiaddrs = append(multiaddrs, externalMultiaddr)
 	addrFactory = func(addrs []ma.Multiaddr) []ma.Multiaddr {
 		return multiaddrs
@@ -82,3 +95,95 @@ func TestCreateOptions(t *testing.T) {
 
 	resetOptions()
 }
+
+func TestPrivateNetworkOptions(t *testing.T) {
+	readTestConfig()
+	resetOptions()
+	defer resetOptions()
+
+	p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+	p2pInstance.initContext()
+	assert.False(t, p2pInstance.isPrivateNetwork())
+	publicOptions := p2pInstance.CreateOptions()
+
+	os.Setenv(optionsPrivateNetworkKey, testPrivateNetworkKey)
+	assert.True(t, p2pInstance.isPrivateNetwork())
+	assert.Equal(t, len(publicOptions)+1, len(p2pInstance.CreateOptions()))
+
+	protector, err := newProtector(testPrivateNetworkKey)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, protector.Fingerprint())
+
+	_, err = newProtector("abcd")
+	assert.Error(t, err)
+	_, err = newProtector("not hex")
+	assert.Error(t, err)
+
+	// An invalid key must prevent the node from starting instead of falling back to the public network
+	os.Setenv(optionsPrivateNetworkKey, "abcd")
+	_, err = libp2p.New(p2pInstance.ctx, p2pInstance.CreateOptions()...)
+	assert.Error(t, err)
+}
+
+func TestBootstrapPeerOptions(t *testing.T) {
+	readTestConfig()
+	resetOptions()
+	defer resetOptions()
+
+	p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+
+	// Public networks default to the IPFS bootstrap peers
+	p2pInstance.addBootstrapPeers()
+	var defaultBootstrapPeers addrList = dht.DefaultBootstrapPeers
+	assert.Equal(t, defaultBootstrapPeers, p2pInstance.bootstrapPeers)
+
+	// Private networks never use them
+	os.Setenv(optionsPrivateNetworkKey, testPrivateNetworkKey)
+	p2pInstance.addBootstrapPeers()
+	assert.Empty(t, p2pInstance.bootstrapPeers)
+
+	// Invalid addresses and addresses without a peer ID are skipped
+	os.Setenv(optionsBootstrapPeers, testBootstrapPeer+" /ip4/10.0.0.1/tcp/4001 notamultiaddr")
+	p2pInstance.addBootstrapPeers()
+	assert.Equal(t, 1, len(p2pInstance.bootstrapPeers))
+	assert.Equal(t, testBootstrapPeer, p2pInstance.bootstrapPeers[0].String())
+}
+
+func TestListenAddrOptions(t *testing.T) {
+	readTestConfig()
+	resetOptions()
+	defer resetOptions()
+
+	p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+	defaults := p2pInstance.listenAddrs()
+	assert.Equal(t, defaultListenAddrs(appConfig.GetString("p2p.port")), defaults)
+
+	// Invalid addresses and transports this node doesn't have are skipped
+	os.Setenv(optionsListenAddrs, "/ip4/127.0.0.1/tcp/0 /ip6/::1/tcp/4001 /ip4/127.0.0.1/tcp/0/ws /ip4/127.0.0.1/udp/4001/quic /ip4/127.0.0.1/udp/4001 notamultiaddr")
+	listenAddrs := p2pInstance.listenAddrs()
+	assert.Equal(t, 3, len(listenAddrs))
+	assert.True(t, errors.Is(errors.Unsupported, checkTransport(ma.StringCast("/ip4/127.0.0.1/udp/4001/quic"))))
+
+	// External addresses keep the transport and the port of the listen address they're announced for
+	external, ok := externalAddr(ma.StringCast("/ip4/0.0.0.0/tcp/4002/ws"), "192.168.0.1")
+	assert.True(t, ok)
+	assert.Equal(t, "/ip4/192.168.0.1/tcp/4002/ws", external.String())
+	external, ok = externalAddr(ma.StringCast("/ip6/::/tcp/4001"), "2001:db8::1")
+	assert.True(t, ok)
+	assert.Equal(t, "/ip6/2001:db8::1/tcp/4001", external.String())
+	_, ok = externalAddr(ma.StringCast("/ip6/::/tcp/4001"), "192.168.0.1")
+	assert.False(t, ok)
+	_, ok = externalAddr(ma.StringCast("/ip4/0.0.0.0/tcp/4001"), "not an IP")
+	assert.False(t, ok)
+
+	// Nodes listening on WebSocket are reachable over it
+	os.Setenv(optionsListenAddrs, "/ip4/127.0.0.1/tcp/0/ws")
+	listener, err := libp2p.New(context.Background(), libp2p.ListenAddrs(p2pInstance.listenAddrs()...))
+	assert.NoError(t, err)
+	defer listener.Close()
+	dialer, err := libp2p.New(context.Background(), libp2p.NoListenAddrs)
+	assert.NoError(t, err)
+	defer dialer.Close()
+	assert.NoError(t, dialer.Connect(context.Background(), peer.AddrInfo{ID: listener.ID(), Addrs: listener.Addrs()}))
+	assert.Contains(t, dialer.Network().ConnsToPeer(listener.ID())[0].RemoteMultiaddr().String(), "/ws")
+}
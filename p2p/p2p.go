@@ -6,10 +6,10 @@ import (
 	"sync"
 	"time"
 
-	"github.com/golang/protobuf/proto"
 	"github.com/sprawl/sprawl/interfaces"
 
 	libp2p "github.com/libp2p/go-libp2p"
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
 	crypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/host"
 	peer "github.com/libp2p/go-libp2p-core/peer"
@@ -18,6 +18,7 @@ import (
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	libp2pConfig "github.com/libp2p/go-libp2p/config"
+	mdns "github.com/libp2p/go-libp2p/p2p/discovery"
 	multiaddr "github.com/multiformats/go-multiaddr"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/pb"
@@ -35,76 +36,157 @@ type P2p struct {
 	privateKey       crypto.PrivKey
 	publicKey        crypto.PubKey
 	ps               *pubsub.PubSub
+	closePubSub      context.CancelFunc
 	ctx              context.Context
+	cancel           context.CancelFunc
+	lifecycle        sync.Mutex
+	routines         sync.WaitGroup
 	host             host.Host
+	innerHost        host.Host
 	kademliaDHT      *dht.IpfsDHT
 	routingDiscovery *discovery.RoutingDiscovery
 	peerChan         <-chan peer.AddrInfo
 	bootstrapPeers   addrList
-	input            chan pb.WireMessage
-	subscriptions    map[string]chan bool
+	input            chan *outboundMessage
+	queueCounters    queueCounters
+	batches          *batchManager
+	subscriptions    *subscriptionManager
+	channelStats     map[string]*channelStats
+	statsLock        sync.Mutex
+	directory        *channelDirectory
+	scores           *peerScores
+	strictSignatures bool
+	gater            *peerGater
+	capabilities     *peerCapabilities
+	knownPeers       *knownPeerBook
+	connManager      *connmgr.BasicConnMgr
+	protector        *peerProtector
+	storage          interfaces.Storage
+	mdns             mdns.Service
+	reachability     *reachabilityTracker
+	autoNATService   *autoNATService
+	natHandlers      []ReachabilityHandler
+	natLock          sync.Mutex
 	Orders           interfaces.OrderService
 	Channels         interfaces.ChannelService
 }
 
-// NewP2p returns a P2p struct with an input channel
+// NewP2p returns a P2p struct with an outbound message queue
 func NewP2p(log interfaces.Logger, config interfaces.Config, privateKey crypto.PrivKey, publicKey crypto.PubKey) (p2p *P2p) {
 	p2p = &P2p{
 		Logger:        log,
 		Config:        config,
 		privateKey:    privateKey,
 		publicKey:     publicKey,
-		input:         make(chan pb.WireMessage),
-		subscriptions: make(map[string]chan bool),
+		input:         newOutboundQueue(config),
+		batches:       newBatchManager(),
+		subscriptions: newSubscriptionManager(),
+		channelStats:  make(map[string]*channelStats),
+		directory:     newChannelDirectory(),
+		scores:        newPeerScores(),
+		gater:         newPeerGater(),
+		capabilities:  newPeerCapabilities(),
 	}
 	return
 }
 
-func (p2p *P2p) inputCheckLoop() (err error) {
+// inputCheckLoop publishes the queued messages until ctx is cancelled
+func (p2p *P2p) inputCheckLoop(ctx context.Context) {
 	for {
 		select {
 		case message := <-p2p.input:
-			p2p.handleInput(&message)
+			p2p.handleInput(message)
+		case <-ctx.Done():
+			return
 		}
 	}
 }
 
+// enter registers a routine of the run of ctx that Close waits for, which calls p2p.routines.Done when it returns.
+// Nothing is registered once that run is closing, which is told by returning false. Close cancels the
+// run under p2p.lifecycle, so a routine can't be registered while Close is waiting for the others.
+func (p2p *P2p) enter(ctx context.Context) bool {
+	p2p.lifecycle.Lock()
+	defer p2p.lifecycle.Unlock()
+	if ctx == nil || ctx != p2p.ctx || ctx.Err() != nil {
+		return false
+	}
+	p2p.routines.Add(1)
+	return true
+}
+
+// spawn runs f in a goroutine that Close waits for. Nothing is started once the node is closing,
+// which is told by returning false.
+func (p2p *P2p) spawn(f func()) bool {
+	p2p.lifecycle.Lock()
+	ctx := p2p.ctx
+	p2p.lifecycle.Unlock()
+	if !p2p.enter(ctx) {
+		return false
+	}
+	go func() {
+		defer p2p.routines.Done()
+		f()
+	}()
+	return true
+}
+
 func (p2p *P2p) checkForPeers() {
 	if p2p.Logger != nil {
 		p2p.Logger.Infof("This node's ID: %s\n", p2p.host.ID())
 		p2p.Logger.Infof("Listening to the following addresses: %s\n", p2p.host.Addrs())
 	}
 
-	var wg sync.WaitGroup
-	go func(ctx context.Context) {
-		for peer := range p2p.peerChan {
-			if peer.ID == p2p.host.ID() {
-				if p2p.Logger != nil {
-					p2p.Logger.Debug("Found a new peer!")
-					p2p.Logger.Debug("But the peer was you!")
+	ctx, peerChan := p2p.ctx, p2p.peerChan
+	p2p.spawn(func() {
+		for {
+			select {
+			case peer, ok := <-peerChan:
+				if !ok {
+					return
 				}
-				continue
-			}
-			if p2p.Logger != nil {
-				p2p.Logger.Infof("Found a new peer: %s\n", peer.ID)
+				p2p.connectToFoundPeer(ctx, peer)
+			case <-ctx.Done():
+				return
 			}
+		}
+	})
+}
 
-			wg.Add(1)
-			go func(ctx context.Context) {
-				defer wg.Done()
-				if err := p2p.host.Connect(ctx, peer); !errors.IsEmpty(err) {
-					if p2p.Logger != nil {
-						p2p.Logger.Error(errors.E(errors.Op("Connect"), err))
-					}
-				} else {
-					if p2p.Logger != nil {
-						p2p.Logger.Infof("Connected to: %s\n", peer)
-					}
-				}
-			}(p2p.ctx)
-			wg.Wait()
+// connectToFoundPeer connects to a peer found by any of the discovery mechanisms
+func (p2p *P2p) connectToFoundPeer(ctx context.Context, peer peer.AddrInfo) {
+	if peer.ID == p2p.host.ID() {
+		if p2p.Logger != nil {
+			p2p.Logger.Debug("Found a new peer!")
+			p2p.Logger.Debug("But the peer was you!")
 		}
-	}(p2p.ctx)
+		return
+	}
+	if !p2p.gater.accepts(peer.ID) {
+		if p2p.Logger != nil {
+			p2p.Logger.Debugf("Found a refused peer: %s\n", peer.ID)
+		}
+		return
+	}
+	if !p2p.hasConnectionCapacity() {
+		if p2p.Logger != nil {
+			p2p.Logger.Debugf("Found a new peer %s, but the connection limit is reached\n", peer.ID)
+		}
+		return
+	}
+	if p2p.Logger != nil {
+		p2p.Logger.Infof("Found a new peer: %s\n", peer.ID)
+	}
+
+	if err := p2p.host.Connect(ctx, peer); !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Error(errors.E(errors.Op("Connect"), err))
+		}
+	} else {
+		if p2p.Logger != nil {
+			p2p.Logger.Infof("Connected to: %s\n", peer)
+		}
+	}
 }
 
 // RegisterOrderService registers an order service to persist order data locally
@@ -117,109 +199,159 @@ func (p2p *P2p) RegisterChannelService(channels interfaces.ChannelService) {
 	p2p.Channels = channels
 }
 
-func (p2p *P2p) handleInput(message *pb.WireMessage) {
-	buf, err := proto.Marshal(message)
-	if !errors.IsEmpty(err) {
-		if p2p.Logger != nil {
-			p2p.Logger.Error(errors.E(errors.Op("Marshal proto"), err))
-		}
-	}
-	err = p2p.ps.Publish(string(message.GetChannelID()), buf)
-	if !errors.IsEmpty(err) {
-		if p2p.Logger != nil {
-			p2p.Logger.Error(errors.E(errors.Op("Marshal proto"), fmt.Sprintf("%v, message data: %s", err.Error(), message.Data)))
-		}
-	}
+// Sign signs data with the node's private key
+func (p2p *P2p) Sign(data []byte) ([]byte, error) {
+	return p2p.privateKey.Sign(data)
 }
 
-// Send queues a message for sending to other peers
-func (p2p *P2p) Send(message *pb.WireMessage) {
-	if p2p.Logger != nil {
-		p2p.Logger.Debugf("Sending order %s to channel %s", message.GetData(), message.GetChannelID())
-	}
-	go func(ctx context.Context) {
-		p2p.input <- *message
-	}(p2p.ctx)
+// GetPublicKey returns the node's marshaled public key
+func (p2p *P2p) GetPublicKey() ([]byte, error) {
+	return crypto.MarshalPublicKey(p2p.publicKey)
 }
 
-func (p2p *P2p) initPubSub() {
-	var err error
-	p2p.ps, err = pubsub.NewGossipSub(p2p.ctx, p2p.host)
+// GetPeerID returns the pretty-printed peer ID of the node
+func (p2p *P2p) GetPeerID() string {
+	id, err := peer.IDFromPublicKey(p2p.publicKey)
 	if !errors.IsEmpty(err) {
-		if p2p.Logger != nil {
-			p2p.Logger.Error(err)
-		}
+		return ""
 	}
+	return id.Pretty()
 }
 
-// Subscribe subscribes to a libp2p pubsub channel defined with "channel"
-func (p2p *P2p) Subscribe(channel *pb.Channel) {
+// Subscribe subscribes to a libp2p pubsub channel defined with "channel".
+// Subscribing to an already subscribed channel returns an AlreadyExists error.
+func (p2p *P2p) Subscribe(channel *pb.Channel) error {
 	if p2p.Logger != nil {
 		p2p.Logger.Infof("Subscribing to channel %s with options: %s", channel.GetId(), channel.GetOptions())
 	}
-	sub, err := p2p.ps.Subscribe(string(channel.GetId()))
-	if !errors.IsEmpty(err) {
-		if p2p.Logger != nil {
-			p2p.Logger.Error(errors.E(errors.Op("Subscribe"), err))
+	if p2p.ps == nil {
+		return errors.E(errors.Op("Subscribe"), "pubsub not initialized")
+	}
+	// The listener is started first, so a subscription is only registered once it'll be listened to
+	subscribed := make(chan *subscription, 1)
+	started := p2p.spawn(func() {
+		if s, ok := <-subscribed; ok {
+			p2p.listen(s)
 		}
+	})
+	if !started {
+		return errors.E(errors.Op("Subscribe"), errors.Unavailable, "p2p is not running")
 	}
+	topic := channelTopic(channel.GetId())
+	s, err := p2p.subscriptions.add(p2p.ctx, channel, func() (*pubsub.Subscription, error) {
+		err := p2p.ps.RegisterTopicValidator(topic, p2p.validator(channel))
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+		sub, err := p2p.ps.Subscribe(topic)
+		if !errors.IsEmpty(err) {
+			p2p.ps.UnregisterTopicValidator(topic)
+		}
+		return sub, err
+	}, func() {
+		p2p.flushBatch(p2p.batches.close(string(channel.GetId())))
+		p2p.ps.UnregisterTopicValidator(topic)
+	})
+	if !errors.IsEmpty(err) {
+		close(subscribed)
+		return errors.E(errors.Op("Subscribe"), err)
+	}
+	p2p.batches.open(channel)
+	p2p.directory.addLocal(channel)
+	subscribed <- s
+	return nil
+}
 
-	quitSignal := make(chan bool)
-	p2p.subscriptions[string(channel.GetId())] = quitSignal
-
-	go func(ctx context.Context) {
-		for {
-			msg, err := sub.Next(ctx)
-			if !errors.IsEmpty(err) {
-				if p2p.Logger != nil {
-					p2p.Logger.Error(errors.E(errors.Op("Next Message"), err))
-				}
+// listen passes the messages of a subscription to the OrderService until the subscription is cancelled
+func (p2p *P2p) listen(s *subscription) {
+	defer p2p.subscriptions.release(s)
+	for {
+		msg, err := s.sub.Next(s.ctx)
+		if !errors.IsEmpty(err) {
+			if s.ctx.Err() == nil && p2p.Logger != nil {
+				p2p.Logger.Error(errors.E(errors.Op("Next Message"), err))
 			}
+			return
+		}
 
-			data := msg.GetData()
-			peer := msg.GetFrom()
+		data := msg.GetData()
+		peer := msg.GetFrom()
 
-			if peer != p2p.host.ID() {
-				if p2p.Logger != nil {
-					p2p.Logger.Infof("Received order from peer %s: %s", peer, data)
-				}
+		if peer != p2p.host.ID() {
+			if p2p.Logger != nil {
+				p2p.Logger.Infof("Received order from peer %s: %s", peer, data)
+			}
 
-				if p2p.Orders != nil {
-					err = p2p.Orders.Receive(data)
-					if !errors.IsEmpty(err) {
-						if p2p.Logger != nil {
-							p2p.Logger.Error(errors.E(errors.Op("Receive order"), err))
-						}
-					}
-				} else {
-					if p2p.Logger != nil {
-						p2p.Logger.Warn("P2p: OrderService not registered with p2p, not persisting incoming orders to DB!")
-					}
+			if p2p.Orders != nil {
+				p2p.receive(s.channel, data, peer.Pretty())
+			} else {
+				if p2p.Logger != nil {
+					p2p.Logger.Warn("P2p: OrderService not registered with p2p, not persisting incoming orders to DB!")
 				}
 			}
+		}
+	}
+}
 
-			select {
-			case quit := <-quitSignal: //Delete subscription
-				if quit {
-					delete(p2p.subscriptions, string(channel.GetId()))
-					return
-				}
-			default:
+// receive passes the wire messages of a pubsub message to the OrderService, unpacking batch envelopes.
+// The messages of a batch are validated and applied one at a time, so a message that fails is skipped
+// without dropping the rest of the batch. Messages of unsupported wire versions, which the validator relays
+// for newer nodes, are skipped without counting them as rejected.
+func (p2p *P2p) receive(channel *pb.Channel, data []byte, from string) {
+	messages, batched, err := unpackMessage(channel, data)
+	if errors.Is(errors.Unsupported, err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Debug(errors.E(errors.Op("Unpack message"), err))
+		}
+		return
+	}
+	if !errors.IsEmpty(err) {
+		if p2p.Logger != nil {
+			p2p.Logger.Error(errors.E(errors.Op("Unpack message"), err))
+		}
+		p2p.getStats(string(channel.GetId())).addReceived(true)
+		return
+	}
+	for _, message := range messages {
+		if batched {
+			err = p2p.Orders.Validate(message, from)
+		}
+		if errors.IsEmpty(err) {
+			err = p2p.Orders.Receive(message, from)
+		}
+		if !errors.IsEmpty(err) {
+			if p2p.Logger != nil {
+				p2p.Logger.Error(errors.E(errors.Op("Receive order"), err))
 			}
 		}
-	}(p2p.ctx)
+		p2p.getStats(string(channel.GetId())).addReceived(!errors.IsEmpty(err))
+	}
+}
+
+// Unsubscribe cancels the subscription of a channel.
+// Unsubscribing from a channel that isn't subscribed returns a NotFound error.
+func (p2p *P2p) Unsubscribe(channel *pb.Channel) error {
+	err := p2p.subscriptions.remove(string(channel.GetId()))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unsubscribe"), err)
+	}
+	p2p.directory.removeLocal(channel)
+	return nil
 }
 
-// Unsubscribe sends a quit signal to a channel goroutine
-func (p2p *P2p) Unsubscribe(channel *pb.Channel) {
-	p2p.subscriptions[string(channel.GetId())] <- true
+// GetSubscriptions reports the currently subscribed channels
+func (p2p *P2p) GetSubscriptions() []SubscriptionState {
+	return p2p.subscriptions.state()
 }
 
+// initContext creates the context of a run, which Close cancels
 func (p2p *P2p) initContext() {
-	p2p.ctx = context.Background()
+	p2p.lifecycle.Lock()
+	defer p2p.lifecycle.Unlock()
+	p2p.ctx, p2p.cancel = context.WithCancel(context.Background())
 }
 
-func (p2p *P2p) bootstrapDHT() {
+func (p2p *P2p) bootstrapDHT() error {
 	// Bootstrap the DHT. In the default configuration, this spawns a Background
 	// thread that will refresh the peer table every five minutes.
 	var err error
@@ -231,12 +363,10 @@ func (p2p *P2p) bootstrapDHT() {
 	}
 
 	err = p2p.kademliaDHT.BootstrapWithConfig(p2p.ctx, bootstrapConfig)
-
 	if !errors.IsEmpty(err) {
-		if p2p.Logger != nil {
-			p2p.Logger.Error(errors.E(errors.Op("Bootstrap with config"), err))
-		}
+		return errors.E(errors.Op("Bootstrap with config"), err)
 	}
+	return nil
 }
 
 func (p2p *P2p) initBootstrapPeers(bootstrapPeers addrList) {
@@ -247,6 +377,32 @@ func (p2p *P2p) addDefaultBootstrapPeers() {
 	p2p.initBootstrapPeers(dht.DefaultBootstrapPeers)
 }
 
+// addBootstrapPeers uses the bootstrap peers defined in p2p.Config. Public networks fall back
+// to the IPFS bootstrap peers, while private networks and bootstrap nodes never contact them.
+// A bootstrap node without bootstrap peers is the first node of its network.
+func (p2p *P2p) addBootstrapPeers() {
+	bootstrapPeers := addrList{}
+	for _, addr := range p2p.Config.GetStringSlice("p2p.bootstrapPeers") {
+		peerAddr, err := multiaddr.NewMultiaddr(addr)
+		if errors.IsEmpty(err) {
+			_, err = peer.AddrInfoFromP2pAddr(peerAddr)
+		}
+		if !errors.IsEmpty(err) {
+			if p2p.Logger != nil {
+				p2p.Logger.Error(errors.E(errors.Op("Parse bootstrap peer"), fmt.Sprintf("%v, address: %s", err, addr)))
+			}
+			continue
+		}
+		bootstrapPeers = append(bootstrapPeers, peerAddr)
+	}
+
+	if len(bootstrapPeers) == 0 && !p2p.isPrivateNetwork() && p2p.Mode() != BootstrapMode {
+		p2p.addDefaultBootstrapPeers()
+		return
+	}
+	p2p.initBootstrapPeers(bootstrapPeers)
+}
+
 func (p2p *P2p) connectToPeers() {
 	var wg sync.WaitGroup
 	if p2p.Logger != nil {
@@ -281,62 +437,178 @@ func (p2p *P2p) advertise() {
 	discovery.Advertise(p2p.ctx, p2p.routingDiscovery, baseTopic)
 }
 
-func (p2p *P2p) findPeers() {
+func (p2p *P2p) findPeers() error {
 	var err error
 	p2p.peerChan, err = p2p.routingDiscovery.FindPeers(p2p.ctx, baseTopic)
 	if !errors.IsEmpty(err) {
-		if p2p.Logger != nil {
-			p2p.Logger.Error(errors.E(errors.Op("Find peers"), err))
-		}
+		return errors.E(errors.Op("Find peers"), err)
 	}
+	return nil
 }
 
 func (p2p *P2p) initDHT() libp2pConfig.Option {
 	NewDHT := func(h host.Host) (routing.PeerRouting, error) {
 		var err error
+		p2p.innerHost = h
 		p2p.kademliaDHT, err = dht.New(p2p.ctx, h)
 		if !errors.IsEmpty(err) {
-			if p2p.Logger != nil {
-				p2p.Logger.Error(errors.E(errors.Op("Add dht"), err))
-			}
+			return nil, errors.E(errors.Op("Add dht"), err)
 		}
-		return p2p.kademliaDHT, err
+		return p2p.kademliaDHT, nil
 	}
 	return libp2p.Routing(NewDHT)
 
 }
 
-func (p2p *P2p) initHost(options ...libp2pConfig.Option) {
+func (p2p *P2p) initHost(options ...libp2pConfig.Option) error {
 	var err error
 	p2p.host, err = libp2p.New(
 		p2p.ctx,
 		options...)
 	if !errors.IsEmpty(err) {
-		if p2p.Logger != nil {
-			p2p.Logger.Error(errors.E(errors.Op("Add host"), err))
-		}
+		return errors.E(errors.Op("Add host"), err)
+	}
+	return nil
+}
+
+// Run runs the p2p network until Close is called. If the node fails to start, whatever
+// was already started is closed and the error is returned.
+func (p2p *P2p) Run() error {
+	if p2p.cancel != nil {
+		return errors.E(errors.Op("Run p2p"), errors.AlreadyExists, "p2p is already running")
+	}
+	err := p2p.run()
+	if !errors.IsEmpty(err) {
+		p2p.Close()
+		return errors.E(errors.Op("Run p2p"), err)
 	}
+	return nil
 }
 
-// Run runs the p2p network
-func (p2p *P2p) Run() {
+func (p2p *P2p) run() error {
+	err := p2p.checkMode()
+	if !errors.IsEmpty(err) {
+		return err
+	}
 	p2p.initContext()
-	p2p.initHost(p2p.CreateOptions()...)
-	p2p.addDefaultBootstrapPeers()
+	p2p.initConnManager()
+	err = p2p.initHost(p2p.CreateOptions()...)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	p2p.initGater()
+	p2p.initNegotiation()
+	p2p.initCapabilities()
+	p2p.initKnownPeers()
+	p2p.connectToKnownPeers()
+	p2p.addBootstrapPeers()
 	p2p.connectToPeers()
 	p2p.createRoutingDiscovery()
 	p2p.advertise()
-	p2p.findPeers()
-	p2p.initPubSub()
-	p2p.bootstrapDHT()
-	go func() {
-		p2p.inputCheckLoop()
-	}()
+	err = p2p.findPeers()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	err = p2p.initPubSub()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	p2p.initPeerProtection()
+	err = p2p.initDirectory()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	err = p2p.bootstrapDHT()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	p2p.initMDNS()
+	p2p.initAutoNAT()
+	if p2p.IsInfrastructure() {
+		p2p.startAutoNATService()
+	}
+	p2p.startInputLoop()
+	if p2p.Logger != nil {
+		p2p.Logger.Infof("Running in %s mode", p2p.Mode())
+	}
 	p2p.checkForPeers()
+	return nil
+}
+
+// RunLocal runs the p2p network on an existing host, without bootstrapping or discovering peers.
+// The caller connects the host to its peers, which makes local and in-process networks deterministic.
+func (p2p *P2p) RunLocal(h host.Host) error {
+	if p2p.cancel != nil {
+		return errors.E(errors.Op("Run local p2p"), errors.AlreadyExists, "p2p is already running")
+	}
+	err := p2p.runLocal(h)
+	if !errors.IsEmpty(err) {
+		p2p.Close()
+		return errors.E(errors.Op("Run local p2p"), err)
+	}
+	return nil
+}
+
+func (p2p *P2p) runLocal(h host.Host) error {
+	p2p.initContext()
+	p2p.initConnManager()
+	p2p.host = h
+	p2p.host.Network().Notify(p2p.connManager.Notifee())
+	p2p.initGater()
+	p2p.initNegotiation()
+	p2p.initCapabilities()
+	p2p.initKnownPeers()
+	err := p2p.initPubSub()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	p2p.initPeerProtection()
+	err = p2p.initDirectory()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	p2p.startInputLoop()
+	return nil
 }
 
-// Close closes the underlying libp2p host
+func (p2p *P2p) startInputLoop() {
+	ctx := p2p.ctx
+	p2p.spawn(func() {
+		p2p.inputCheckLoop(ctx)
+	})
+}
+
+// Close stops the p2p network. It cancels the context of the run, closes the host
+// and waits for the goroutines of the node and the running pubsub validators to return
+// before pubsub is stopped. The node can be run again afterwards.
 func (p2p *P2p) Close() {
-	p2p.Logger.Debug("P2P shutting down")
-	p2p.host.Close()
+	if p2p.Logger != nil {
+		p2p.Logger.Debug("P2P shutting down")
+	}
+	p2p.lifecycle.Lock()
+	if p2p.cancel != nil {
+		p2p.cancel()
+	}
+	p2p.lifecycle.Unlock()
+	failBatch(p2p.batches.closeAll(), errors.E(errors.Op("Close"), errors.Unavailable, "p2p closed before the batch was published"))
+	if p2p.mdns != nil {
+		p2p.mdns.Close()
+		p2p.mdns = nil
+	}
+	p2p.closeConnManager()
+	if p2p.host != nil {
+		err := p2p.host.Close()
+		if !errors.IsEmpty(err) && p2p.Logger != nil {
+			p2p.Logger.Error(errors.E(errors.Op("Close host"), err))
+		}
+	}
+	p2p.routines.Wait()
+	p2p.closeAutoNATService()
+	p2p.setReachability(nil)
+	if p2p.closePubSub != nil {
+		p2p.closePubSub()
+		p2p.closePubSub = nil
+		p2p.ps = nil
+	}
+	p2p.cancel = nil
 }
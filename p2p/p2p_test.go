@@ -3,13 +3,17 @@ package p2p
 import (
 	"context"
 	"crypto/rand"
+	"os"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
 	libp2p "github.com/libp2p/go-libp2p"
 	crypto "github.com/libp2p/go-libp2p-core/crypto"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/identity"
 	"github.com/sprawl/sprawl/pb"
 	"github.com/sprawl/sprawl/service"
@@ -31,9 +35,31 @@ func init() {
 	logger = zap.NewNop()
 	log = logger.Sugar()
 	testConfig = &config.Config{Logger: log}
+	testConfig.ReadConfig(testConfigPath)
 	privateKey, publicKey, _ = identity.GenerateKeyPair(rand.Reader)
 }
 
+// newTestP2p creates a node with a host listening on a loopback port, without running it.
+// The init steps start only the parts of the node a test needs, in order.
+func newTestP2p(t *testing.T, steps ...func(p2pInstance *P2p)) *P2p {
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	p2pInstance.initContext()
+	var err error
+	p2pInstance.host, err = libp2p.New(p2pInstance.ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	assert.NoError(t, err)
+	for _, step := range steps {
+		step(p2pInstance)
+	}
+	return p2pInstance
+}
+
+// initTestPubSub is the init step starting the pubsub router of a test node
+func initTestPubSub(t *testing.T) func(p2pInstance *P2p) {
+	return func(p2pInstance *P2p) {
+		assert.NoError(t, p2pInstance.initPubSub())
+	}
+}
+
 func TestServiceRegistration(t *testing.T) {
 	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
 	orderService := &service.OrderService{}
@@ -47,7 +73,9 @@ func TestServiceRegistration(t *testing.T) {
 func TestInitContext(t *testing.T) {
 	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
 	p2pInstance.initContext()
-	assert.Equal(t, p2pInstance.ctx, context.Background())
+	assert.NoError(t, p2pInstance.ctx.Err())
+	p2pInstance.cancel()
+	assert.Equal(t, context.Canceled, p2pInstance.ctx.Err())
 }
 
 func TestBootstrapping(t *testing.T) {
@@ -76,11 +104,16 @@ func TestSend(t *testing.T) {
 	testOrderInBytes, err := proto.Marshal(testOrder)
 	assert.NoError(t, err)
 	testWireMessage = &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}
-	p2pInstance.Send(testWireMessage)
+	result := make(chan error)
+	go func() {
+		result <- p2pInstance.Send(testWireMessage)
+	}()
 
-	message := <-p2pInstance.input
-	assert.Equal(t, message.ChannelID, testChannel.GetId())
-	assert.Equal(t, message.GetData(), testOrderInBytes)
+	outbound := <-p2pInstance.input
+	assert.Equal(t, outbound.message.ChannelID, testChannel.GetId())
+	assert.Equal(t, outbound.message.GetData(), testOrderInBytes)
+	outbound.result <- nil
+	assert.NoError(t, <-result)
 }
 
 func TestSubscription(t *testing.T) {
@@ -88,29 +121,30 @@ func TestSubscription(t *testing.T) {
 
 	p2pInstance.initContext()
 	p2pInstance.host, _ = libp2p.New(p2pInstance.ctx)
+	defer p2pInstance.host.Close()
 
-	assert.Panics(t, func() { p2pInstance.Subscribe(testChannel) })
+	assert.Error(t, p2pInstance.Subscribe(testChannel))
 
 	p2pInstance.initPubSub()
-	p2pInstance.Subscribe(testChannel)
+	assert.NoError(t, p2pInstance.Subscribe(testChannel))
+	assert.True(t, p2pInstance.subscriptions.has(string(testChannel.GetId())))
 
-	_, ok := p2pInstance.subscriptions[string(testChannel.GetId())]
-	assert.True(t, ok)
+	err := p2pInstance.Subscribe(testChannel)
+	assert.True(t, errors.Is(errors.AlreadyExists, err))
 
-	testOrderInBytes, err := proto.Marshal(testOrder)
-	assert.NoError(t, err)
-	testWireMessage = &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}
+	subscriptions := p2pInstance.GetSubscriptions()
+	assert.Equal(t, 1, len(subscriptions))
+	assert.Equal(t, testChannel, subscriptions[0].Channel)
 
-	go p2pInstance.Unsubscribe(testChannel)
+	assert.NoError(t, p2pInstance.Unsubscribe(testChannel))
+	assert.False(t, p2pInstance.subscriptions.has(string(testChannel.GetId())))
+	err = p2pInstance.Unsubscribe(testChannel)
+	assert.True(t, errors.Is(errors.NotFound, err))
+	assert.Empty(t, p2pInstance.GetSubscriptions())
 
-	go func() {
-		p2pInstance.Send(testWireMessage)
-	}()
-
-	go func() {
-		p2pInstance.inputCheckLoop()
-	}()
-	<-p2pInstance.subscriptions[string(testChannel.GetId())]
+	// Resubscribing after leaving works
+	assert.NoError(t, p2pInstance.Subscribe(testChannel))
+	assert.NoError(t, p2pInstance.Unsubscribe(testChannel))
 }
 
 func TestPublish(t *testing.T) {
@@ -120,25 +154,86 @@ func TestPublish(t *testing.T) {
 	p2pInstance.host, _ = libp2p.New(p2pInstance.ctx)
 	p2pInstance.initPubSub()
 
-	sub, _ := p2pInstance.ps.Subscribe(string(testChannel.GetId()))
+	sub, _ := p2pInstance.ps.Subscribe(channelTopic(testChannel.GetId()))
 	testOrderInBytes, err := proto.Marshal(testOrder)
 	assert.NoError(t, err)
 	testWireMessage = &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}
-	p2pInstance.Send(testWireMessage)
-	wireMessageAsBytes, err := proto.Marshal(testWireMessage)
+	go p2pInstance.inputCheckLoop(p2pInstance.ctx)
+	assert.NoError(t, p2pInstance.Send(testWireMessage))
+	// Send stamps the message with the wire version of this node
+	publishedMessage := proto.Clone(testWireMessage).(*pb.WireMessage)
+	publishedMessage.Version = messageWireVersion
+	wireMessageAsBytes, err := proto.Marshal(publishedMessage)
 	assert.NoError(t, err)
-	select {
-	case message := <-p2pInstance.input:
-		p2pInstance.handleInput(&message)
-		msg, _ := sub.Next(p2pInstance.ctx)
-		assert.Equal(t, msg.GetData(), wireMessageAsBytes)
-	}
+	msg, _ := sub.Next(p2pInstance.ctx)
+	assert.Equal(t, msg.GetData(), wireMessageAsBytes)
+	assert.Equal(t, uint64(1), p2pInstance.GetQueueStats().Published)
 }
 
 func TestRun(t *testing.T) {
 	testConfig.ReadConfig(testConfigPath)
 	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
-	// TODO: Acculi test this
-	assert.NotPanics(t, p2pInstance.Run, "p2p run should not panic")
-	assert.NotPanics(t, p2pInstance.Close, "p2p close should not panic")
+	assert.NoError(t, p2pInstance.Run())
+	err := p2pInstance.Run()
+	assert.True(t, errors.Is(errors.AlreadyExists, err))
+	p2pInstance.Close()
+
+	// Nothing is published after closing, and the node can be run again
+	err = p2pInstance.Send(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE})
+	assert.True(t, errors.Is(errors.Unavailable, err))
+	assert.NoError(t, p2pInstance.Run())
+	p2pInstance.Close()
+}
+
+func TestSpawnWhileClosing(t *testing.T) {
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	assert.False(t, p2pInstance.spawn(func() {}))
+
+	p2pInstance.initContext()
+	p2pInstance.host, _ = libp2p.New(p2pInstance.ctx)
+	defer p2pInstance.host.Close()
+	p2pInstance.initPubSub()
+	defer p2pInstance.closePubSub()
+	validate := p2pInstance.validator(testChannel)
+	assert.True(t, p2pInstance.spawn(func() {}))
+	p2pInstance.routines.Wait()
+
+	// Once the run is closing nothing is started, and no subscription is left without a listener
+	p2pInstance.cancel()
+	assert.False(t, p2pInstance.spawn(func() {}))
+	err := p2pInstance.Subscribe(testChannel)
+	assert.True(t, errors.Is(errors.Unavailable, err))
+	assert.False(t, p2pInstance.subscriptions.has(string(testChannel.GetId())))
+
+	// Validators of a closed run refuse messages without touching the node
+	msg := &pubsub.Message{Message: &pubsubpb.Message{Data: []byte("message")}}
+	assert.False(t, validate(context.Background(), p2pInstance.host.ID(), msg))
+}
+
+func TestRunFailure(t *testing.T) {
+	testConfig.ReadConfig(testConfigPath)
+	defer os.Unsetenv(optionsPrivateNetworkKey)
+	os.Setenv(optionsPrivateNetworkKey, "notAKey")
+
+	// A node that can't start reports the error instead of panicking later
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	assert.Error(t, p2pInstance.Run())
+	assert.Nil(t, p2pInstance.ps)
+	assert.NotPanics(t, p2pInstance.Close)
+}
+
+func TestChannelStats(t *testing.T) {
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+
+	p2pInstance.getStats(string(testChannel.GetId())).addReceived(false)
+	p2pInstance.getStats(string(testChannel.GetId())).addReceived(true)
+	p2pInstance.getStats(string(testChannel.GetId())).addSent(1)
+
+	stats := p2pInstance.GetChannelStats(testChannel)
+	assert.Equal(t, uint64(2), stats.GetOrdersReceived())
+	assert.Equal(t, uint64(1), stats.GetOrdersSent())
+	assert.Equal(t, uint64(1), stats.GetMessagesRejected())
+	assert.NotNil(t, stats.GetLastMessage())
+	assert.Equal(t, uint32(0), stats.GetTopicPeers())
+	assert.Empty(t, p2pInstance.GetChannelPeers(testChannel))
 }
@@ -0,0 +1,126 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sprawl/sprawl/errors"
+)
+
+// Supported values of p2p.pubsubRouter
+const (
+	floodsubRouter  = "floodsub"
+	gossipsubRouter = "gossipsub"
+	randomsubRouter = "randomsub"
+)
+
+// pubsubOptions returns the message signing options defined in p2p.Config.
+// The peer gater acts as the blacklist, so the messages of banned peers are dropped.
+func (p2p *P2p) pubsubOptions() []pubsub.Option {
+	return []pubsub.Option{
+		pubsub.WithMessageSigning(p2p.Config.GetBool("p2p.messageSigning")),
+		pubsub.WithStrictSignatureVerification(p2p.Config.GetBool("p2p.strictSignatureVerification")),
+		pubsub.WithBlacklist(p2p.gater),
+	}
+}
+
+// gossipSubParameters are the gossipsub mesh degrees and heartbeat interval
+type gossipSubParameters struct {
+	d                 int
+	dlo               int
+	dhi               int
+	heartbeatInterval time.Duration
+}
+
+// defaultGossipSubParameters are the defaults of the pubsub package, captured before they're changed
+var defaultGossipSubParameters = gossipSubParameters{
+	d:                 pubsub.GossipSubD,
+	dlo:               pubsub.GossipSubDlo,
+	dhi:               pubsub.GossipSubDhi,
+	heartbeatInterval: pubsub.GossipSubHeartbeatInterval,
+}
+
+// gossipSubOnce applies the gossipsub parameters of the first node in the process, before any router starts
+var gossipSubOnce sync.Once
+
+// appliedGossipSubParameters are the parameters gossipSubOnce applied
+var appliedGossipSubParameters gossipSubParameters
+
+// gossipSubParameters returns the gossipsub parameters defined in p2p.Config. Unset parameters keep their defaults.
+func (p2p *P2p) gossipSubParameters() (gossipSubParameters, error) {
+	params := defaultGossipSubParameters
+	if d := int(p2p.Config.GetUint("p2p.gossipsubD")); d != 0 {
+		params.d = d
+	}
+	if dlo := int(p2p.Config.GetUint("p2p.gossipsubDlo")); dlo != 0 {
+		params.dlo = dlo
+	}
+	if dhi := int(p2p.Config.GetUint("p2p.gossipsubDhi")); dhi != 0 {
+		params.dhi = dhi
+	}
+	if heartbeatInterval := time.Duration(p2p.Config.GetUint("p2p.gossipsubHeartbeatInterval")) * time.Millisecond; heartbeatInterval != 0 {
+		params.heartbeatInterval = heartbeatInterval
+	}
+	if params.dlo > params.d || params.d > params.dhi {
+		return params, errors.E(errors.Op("Gossipsub parameters"), errors.Invalid, fmt.Sprintf("expected gossipsubDlo <= gossipsubD <= gossipsubDhi, got %d, %d and %d", params.dlo, params.d, params.dhi))
+	}
+	return params, nil
+}
+
+// configureGossipSub applies the gossipsub parameters defined in p2p.Config. The parameters are global
+// to the pubsub package and read by running routers without locking, so they're process-wide: the first
+// node creating a gossipsub router sets them, and later nodes asking for other values are warned.
+// Invalid parameters are refused, so a misconfigured node doesn't start with degrees it wasn't given.
+func (p2p *P2p) configureGossipSub() error {
+	params, err := p2p.gossipSubParameters()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Configure gossipsub"), err)
+	}
+	gossipSubOnce.Do(func() {
+		pubsub.GossipSubD = params.d
+		pubsub.GossipSubDlo = params.dlo
+		pubsub.GossipSubDhi = params.dhi
+		pubsub.GossipSubHeartbeatInterval = params.heartbeatInterval
+		appliedGossipSubParameters = params
+	})
+	if params != appliedGossipSubParameters && p2p.Logger != nil {
+		p2p.Logger.Warnf("Gossipsub parameters are shared by every node in the process, keeping %+v instead of %+v", appliedGossipSubParameters, params)
+	}
+	return nil
+}
+
+// initPubSub creates the pubsub router selected in p2p.Config, defaulting to gossipsub.
+// Gossipsub parameters that don't fit together return an Invalid error.
+// The router outlives the context of the run, so goroutines publishing while the node
+// is closing don't block forever. Close stops it once those goroutines have returned.
+func (p2p *P2p) initPubSub() error {
+	var err error
+	ctx, cancel := context.WithCancel(context.Background())
+	p2p.closePubSub = cancel
+	options := p2p.pubsubOptions()
+	p2p.strictSignatures = p2p.Config.GetBool("p2p.strictSignatureVerification")
+
+	router := p2p.Config.GetString("p2p.pubsubRouter")
+	switch router {
+	case floodsubRouter:
+		p2p.ps, err = pubsub.NewFloodSub(ctx, p2p.host, options...)
+	case randomsubRouter:
+		p2p.ps, err = pubsub.NewRandomSub(ctx, p2p.host, options...)
+	default:
+		if router != gossipsubRouter && router != "" && p2p.Logger != nil {
+			p2p.Logger.Warnf("Unknown pubsub router %s, using %s", router, gossipsubRouter)
+		}
+		err = p2p.configureGossipSub()
+		if !errors.IsEmpty(err) {
+			break
+		}
+		p2p.ps, err = pubsub.NewGossipSub(ctx, p2p.host, options...)
+	}
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Init pubsub"), err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package p2p
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	libp2p "github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsPubsubRouter string = "SPRAWL_P2P_PUBSUBROUTER"
+const optionsGossipsubD string = "SPRAWL_P2P_GOSSIPSUBD"
+const optionsGossipsubDlo string = "SPRAWL_P2P_GOSSIPSUBDLO"
+const optionsGossipsubHeartbeatInterval string = "SPRAWL_P2P_GOSSIPSUBHEARTBEATINTERVAL"
+
+func resetPubSubOptions() {
+	os.Unsetenv(optionsPubsubRouter)
+	os.Unsetenv(optionsGossipsubD)
+	os.Unsetenv(optionsGossipsubDlo)
+	os.Unsetenv(optionsGossipsubHeartbeatInterval)
+}
+
+func hasProtocol(p2pInstance *P2p, id protocol.ID) bool {
+	for _, p := range p2pInstance.host.Mux().Protocols() {
+		if p == string(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPubSubRouters(t *testing.T) {
+	readTestConfig()
+	defer resetPubSubOptions()
+
+	routers := map[string]protocol.ID{
+		"floodsub":  pubsub.FloodSubID,
+		"gossipsub": pubsub.GossipSubID,
+		"randomsub": pubsub.RandomSubID,
+		"unknown":   pubsub.GossipSubID,
+	}
+	for router, id := range routers {
+		os.Setenv(optionsPubsubRouter, router)
+		p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+		p2pInstance.initContext()
+		var err error
+		p2pInstance.host, err = libp2p.New(p2pInstance.ctx)
+		assert.NoError(t, err)
+		p2pInstance.initPubSub()
+		assert.NotNil(t, p2pInstance.ps)
+		assert.True(t, hasProtocol(p2pInstance, id), router)
+		if router == "floodsub" {
+			assert.False(t, hasProtocol(p2pInstance, pubsub.GossipSubID))
+		}
+		assert.True(t, p2pInstance.strictSignatures)
+		p2pInstance.host.Close()
+	}
+}
+
+func TestConfigureGossipSub(t *testing.T) {
+	readTestConfig()
+	defer resetPubSubOptions()
+
+	p2pInstance := NewP2p(log, appConfig, privateKey, publicKey)
+
+	os.Setenv(optionsGossipsubD, "8")
+	os.Setenv(optionsGossipsubDlo, "5")
+	os.Setenv(optionsGossipsubHeartbeatInterval, "500")
+	params, err := p2pInstance.gossipSubParameters()
+	assert.NoError(t, err)
+	assert.Equal(t, 8, params.d)
+	assert.Equal(t, 5, params.dlo)
+	assert.Equal(t, 500*time.Millisecond, params.heartbeatInterval)
+
+	// A mesh degree outside the low and high watermarks is refused
+	os.Setenv(optionsGossipsubD, "2")
+	_, err = p2pInstance.gossipSubParameters()
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	// and the node doesn't start its router with them
+	assert.True(t, errors.Is(errors.Invalid, p2pInstance.configureGossipSub()))
+	assert.True(t, errors.Is(errors.Invalid, p2pInstance.initPubSub()))
+	assert.Nil(t, p2pInstance.ps)
+
+	// The parameters are process-wide, the first node configuring them keeps them
+	os.Setenv(optionsGossipsubD, "8")
+	assert.NoError(t, p2pInstance.configureGossipSub())
+	applied := appliedGossipSubParameters
+	os.Setenv(optionsGossipsubD, "9")
+	assert.NoError(t, p2pInstance.configureGossipSub())
+	assert.Equal(t, applied, appliedGossipSubParameters)
+	assert.Equal(t, applied.d, pubsub.GossipSubD)
+}
@@ -0,0 +1,211 @@
+package p2p
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// defaultOutboundQueueSize is used when p2p.outboundQueueSize isn't set
+const defaultOutboundQueueSize = 256
+
+// publishRetryDelay is multiplied by the attempt number to get the delay before retrying a failed publish
+const publishRetryDelay = 100 * time.Millisecond
+
+// sendTimeout defines how long Send waits for a queued message to be published. Batched messages
+// are waited for sendTimeout after the batching window of their channel ends.
+const sendTimeout = 10 * time.Second
+
+// States of an outbound message. A queued message is either claimed for publishing or abandoned by its sender, never both.
+const (
+	outboundQueued int32 = iota
+	outboundClaimed
+	outboundAbandoned
+)
+
+// outboundMessage is a message waiting to be published, and the channel its result is reported on
+type outboundMessage struct {
+	message *pb.WireMessage
+	result  chan error
+	state   int32
+}
+
+// claim marks a queued message as being published, failing if its sender has abandoned it
+func (outbound *outboundMessage) claim() bool {
+	return atomic.CompareAndSwapInt32(&outbound.state, outboundQueued, outboundClaimed)
+}
+
+// abandon withdraws a queued message, failing if it's already being published
+func (outbound *outboundMessage) abandon() bool {
+	return atomic.CompareAndSwapInt32(&outbound.state, outboundQueued, outboundAbandoned)
+}
+
+// QueueStats describes the state of the outbound message queue
+type QueueStats struct {
+	Length    int
+	Capacity  int
+	Published uint64
+	Retried   uint64
+	Failed    uint64
+	Rejected  uint64
+}
+
+// queueCounters holds the counters of the outbound message queue
+type queueCounters struct {
+	sync.Mutex
+	published uint64
+	retried   uint64
+	failed    uint64
+	rejected  uint64
+}
+
+func (counters *queueCounters) add(counter *uint64) {
+	counters.Lock()
+	defer counters.Unlock()
+	*counter++
+}
+
+func newOutboundQueue(config interfaces.Config) chan *outboundMessage {
+	size := config.GetUint("p2p.outboundQueueSize")
+	if size == 0 {
+		size = defaultOutboundQueueSize
+	}
+	return make(chan *outboundMessage, size)
+}
+
+// Send queues a message for publishing and waits until it's published. Instead of waiting for room
+// in a full queue, Send fails right away with an Unavailable error, so callers can back off.
+// A message that times out or is cut short by Close is withdrawn from the queue unless it's already
+// being published, in which case Send waits for the result, so an error always means it wasn't published.
+func (p2p *P2p) Send(message *pb.WireMessage) error {
+	if p2p.ReadOnly() {
+		return errors.E(errors.Op("Send"), errors.Denied, "observer nodes don't publish")
+	}
+	if p2p.Logger != nil {
+		p2p.Logger.Debugf("Sending order %s to channel %s", message.GetData(), message.GetChannelID())
+	}
+	outbound := &outboundMessage{
+		message: proto.Clone(message).(*pb.WireMessage),
+		result:  make(chan error, 1),
+	}
+	outbound.message.Version = messageWireVersion
+
+	var closed <-chan struct{}
+	if p2p.ctx != nil {
+		closed = p2p.ctx.Done()
+	}
+	select {
+	case <-closed:
+		return errors.E(errors.Op("Send"), errors.Unavailable, "p2p is closed")
+	default:
+	}
+
+	// Messages sent to channels with a batching window wait for the batch instead of being queued one by one
+	window, batched := p2p.batch(outbound)
+	if !batched {
+		select {
+		case p2p.input <- outbound:
+		default:
+			p2p.queueCounters.add(&p2p.queueCounters.rejected)
+			return errors.E(errors.Op("Send"), errors.Unavailable, "outbound queue is full")
+		}
+	}
+
+	timeout := time.NewTimer(window + sendTimeout)
+	defer timeout.Stop()
+	var err error
+	select {
+	case err = <-outbound.result:
+		return err
+	case <-closed:
+		err = errors.E(errors.Op("Send"), errors.Unavailable, "p2p closed before the message was published")
+	case <-timeout.C:
+		err = errors.E(errors.Op("Send"), errors.Unavailable, "timed out waiting for the message to be published")
+	}
+	if outbound.abandon() {
+		return err
+	}
+	return <-outbound.result
+}
+
+// handleInput publishes a queued message, retrying failed attempts p2p.publishRetries times,
+// and reports the result back to the sender. Messages abandoned by their senders are dropped.
+func (p2p *P2p) handleInput(outbound *outboundMessage) {
+	if !outbound.claim() {
+		return
+	}
+	err := p2p.publish(outbound.message)
+	if !errors.IsEmpty(err) {
+		p2p.queueCounters.add(&p2p.queueCounters.failed)
+		if p2p.Logger != nil {
+			p2p.Logger.Error(err)
+		}
+	} else {
+		p2p.queueCounters.add(&p2p.queueCounters.published)
+		// The orders of a batch envelope are counted by publishBatch
+		if outbound.message.GetOperation() != pb.Operation_BATCH {
+			p2p.getStats(string(outbound.message.GetChannelID())).addSent(1)
+		}
+	}
+	outbound.result <- err
+}
+
+func (p2p *P2p) publish(message *pb.WireMessage) error {
+	if p2p.ps == nil {
+		return errors.E(errors.Op("Publish"), "pubsub not initialized")
+	}
+	buf, err := proto.Marshal(message)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal proto"), err)
+	}
+
+	retries := p2p.Config.GetUint("p2p.publishRetries")
+	for attempt := uint(0); ; attempt++ {
+		err = p2p.ps.Publish(channelTopic(message.GetChannelID()), buf)
+		if errors.IsEmpty(err) {
+			return nil
+		}
+		if attempt >= retries {
+			return errors.E(errors.Op("Publish"), err)
+		}
+		if !p2p.waitRetry(attempt) {
+			return errors.E(errors.Op("Publish"), errors.Unavailable, "p2p closed before the message was published")
+		}
+		p2p.queueCounters.add(&p2p.queueCounters.retried)
+	}
+}
+
+// waitRetry waits before retrying a failed publish, returning false instead if the node closes meanwhile
+func (p2p *P2p) waitRetry(attempt uint) bool {
+	var closed <-chan struct{}
+	if p2p.ctx != nil {
+		closed = p2p.ctx.Done()
+	}
+	delay := time.NewTimer(publishRetryDelay * time.Duration(attempt+1))
+	defer delay.Stop()
+	select {
+	case <-delay.C:
+		return true
+	case <-closed:
+		return false
+	}
+}
+
+// GetQueueStats reports the length and the counters of the outbound message queue
+func (p2p *P2p) GetQueueStats() QueueStats {
+	p2p.queueCounters.Lock()
+	defer p2p.queueCounters.Unlock()
+	return QueueStats{
+		Length:    len(p2p.input),
+		Capacity:  cap(p2p.input),
+		Published: p2p.queueCounters.published,
+		Retried:   p2p.queueCounters.retried,
+		Failed:    p2p.queueCounters.failed,
+		Rejected:  p2p.queueCounters.rejected,
+	}
+}
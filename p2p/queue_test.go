@@ -0,0 +1,84 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboundQueueSize(t *testing.T) {
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	assert.Equal(t, int(testConfig.GetUint("p2p.outboundQueueSize")), cap(p2pInstance.input))
+}
+
+func TestFullQueue(t *testing.T) {
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	p2pInstance.input = make(chan *outboundMessage, 1)
+	message := &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE}
+
+	p2pInstance.input <- &outboundMessage{message: message, result: make(chan error, 1)}
+
+	// Nobody reads the queue, so the next message is rejected right away
+	err := p2pInstance.Send(message)
+	assert.True(t, errors.Is(errors.Unavailable, err))
+
+	stats := p2pInstance.GetQueueStats()
+	assert.Equal(t, 1, stats.Length)
+	assert.Equal(t, 1, stats.Capacity)
+	assert.Equal(t, uint64(1), stats.Rejected)
+}
+
+func TestPublishFailure(t *testing.T) {
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	go p2pInstance.inputCheckLoop(context.Background())
+
+	// Publishing without pubsub fails, and the failure is reported back to the sender
+	err := p2pInstance.Send(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE})
+	assert.Error(t, err)
+
+	stats := p2pInstance.GetQueueStats()
+	assert.Equal(t, uint64(1), stats.Failed)
+	assert.Equal(t, uint64(0), stats.Published)
+	assert.Equal(t, uint64(0), p2pInstance.GetChannelStats(testChannel).GetOrdersSent())
+}
+
+func TestAbandonedMessage(t *testing.T) {
+	p2pInstance := newTestP2p(t, initTestPubSub(t))
+	defer p2pInstance.Close()
+	p2pInstance.input = make(chan *outboundMessage, 1)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- p2pInstance.Send(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE})
+	}()
+	outbound := <-p2pInstance.input
+	p2pInstance.input <- outbound
+
+	// A message given up on by its sender is withdrawn, so the error means it's never published
+	p2pInstance.cancel()
+	assert.True(t, errors.Is(errors.Unavailable, <-result))
+	p2pInstance.handleInput(<-p2pInstance.input)
+	assert.Equal(t, uint64(0), p2pInstance.GetQueueStats().Published)
+	assert.False(t, outbound.claim())
+
+	// A message already being published can't be withdrawn
+	claimed := &outboundMessage{message: &pb.WireMessage{}, result: make(chan error, 1)}
+	assert.True(t, claimed.claim())
+	assert.False(t, claimed.abandon())
+}
+
+func TestWaitRetry(t *testing.T) {
+	p2pInstance := NewP2p(log, testConfig, privateKey, publicKey)
+	assert.True(t, p2pInstance.waitRetry(0))
+
+	// Retries don't hold up closing the node
+	p2pInstance.initContext()
+	p2pInstance.cancel()
+	start := time.Now()
+	assert.False(t, p2pInstance.waitRetry(100))
+	assert.True(t, time.Since(start) < publishRetryDelay)
+}
@@ -0,0 +1,82 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// channelStats holds the message counters of a single channel
+type channelStats struct {
+	sync.Mutex
+	received    uint64
+	sent        uint64
+	rejected    uint64
+	lastMessage time.Time
+}
+
+func (stats *channelStats) addReceived(rejected bool) {
+	stats.Lock()
+	defer stats.Unlock()
+	stats.received++
+	if rejected {
+		stats.rejected++
+	}
+	stats.lastMessage = time.Now()
+}
+
+// addSent counts published orders, which are published one by one or in a batch envelope
+func (stats *channelStats) addSent(orders int) {
+	stats.Lock()
+	defer stats.Unlock()
+	stats.sent += uint64(orders)
+	stats.lastMessage = time.Now()
+}
+
+func (stats *channelStats) toProto() *pb.ChannelStats {
+	stats.Lock()
+	defer stats.Unlock()
+	channelStats := &pb.ChannelStats{
+		OrdersReceived:   stats.received,
+		OrdersSent:       stats.sent,
+		MessagesRejected: stats.rejected,
+	}
+	if !stats.lastMessage.IsZero() {
+		channelStats.LastMessage, _ = ptypes.TimestampProto(stats.lastMessage)
+	}
+	return channelStats
+}
+
+// getStats returns the counters of a channel, creating them if they don't exist yet
+func (p2p *P2p) getStats(channelID string) *channelStats {
+	p2p.statsLock.Lock()
+	defer p2p.statsLock.Unlock()
+	stats, ok := p2p.channelStats[channelID]
+	if !ok {
+		stats = &channelStats{}
+		p2p.channelStats[channelID] = stats
+	}
+	return stats
+}
+
+// GetChannelPeers returns the IDs of the peers known to be subscribed to a channel
+func (p2p *P2p) GetChannelPeers(channel *pb.Channel) []string {
+	peers := make([]string, 0)
+	if p2p.ps == nil {
+		return peers
+	}
+	for _, peerID := range p2p.ps.ListPeers(channelTopic(channel.GetId())) {
+		peers = append(peers, peerID.Pretty())
+	}
+	return peers
+}
+
+// GetChannelStats returns the message counters of a channel and the amount of peers subscribed to its topic.
+// Gossipsub forwards to a mesh of only some of those peers, which pubsub doesn't expose.
+func (p2p *P2p) GetChannelStats(channel *pb.Channel) *pb.ChannelStats {
+	stats := p2p.getStats(string(channel.GetId())).toProto()
+	stats.TopicPeers = uint32(len(p2p.GetChannelPeers(channel)))
+	return stats
+}
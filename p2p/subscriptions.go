@@ -0,0 +1,146 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// subscription is a single joined channel and the means to stop listening to it
+type subscription struct {
+	channel   *pb.Channel
+	sub       *pubsub.Subscription
+	teardown  func()
+	parentCtx context.Context
+	ctx       context.Context
+	cancel    context.CancelFunc
+	since     time.Time
+	closeOnce sync.Once
+}
+
+// SubscriptionState describes a single active subscription
+type SubscriptionState struct {
+	Channel *pb.Channel
+	Since   time.Time
+}
+
+// subscriptionManager keeps track of the pubsub subscriptions of every joined channel.
+// All of its methods are safe to call concurrently.
+type subscriptionManager struct {
+	sync.RWMutex
+	subscriptions map[string]*subscription
+}
+
+func newSubscriptionManager() *subscriptionManager {
+	return &subscriptionManager{
+		subscriptions: make(map[string]*subscription),
+	}
+}
+
+// add creates a subscription with subscribe, refusing to subscribe to a channel twice.
+// The subscription's context is derived from ctx and cancelled when the subscription is removed,
+// after which teardown is called to release anything set up by subscribe.
+func (manager *subscriptionManager) add(ctx context.Context, channel *pb.Channel, subscribe func() (*pubsub.Subscription, error), teardown func()) (*subscription, error) {
+	manager.Lock()
+	defer manager.Unlock()
+
+	id := string(channel.GetId())
+	if _, ok := manager.subscriptions[id]; ok {
+		return nil, errors.E(errors.Op("Add subscription"), errors.AlreadyExists, "already subscribed to channel "+id)
+	}
+
+	sub, err := subscribe()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Add subscription"), err)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	s := &subscription{
+		channel:   channel,
+		sub:       sub,
+		teardown:  teardown,
+		parentCtx: ctx,
+		ctx:       subCtx,
+		cancel:    cancel,
+		since:     time.Now(),
+	}
+	manager.subscriptions[id] = s
+	return s, nil
+}
+
+// remove cancels and forgets the subscription of a channel. The subscription is closed before
+// the lock is released, so a new subscription to the same channel can't overlap with the teardown.
+func (manager *subscriptionManager) remove(channelID string) error {
+	manager.Lock()
+	defer manager.Unlock()
+	s, ok := manager.subscriptions[channelID]
+	delete(manager.subscriptions, channelID)
+
+	if !ok {
+		return errors.E(errors.Op("Remove subscription"), errors.NotFound, "not subscribed to channel "+channelID)
+	}
+	s.close()
+	return nil
+}
+
+// release forgets a subscription that has stopped on its own, unless it has already been replaced
+func (manager *subscriptionManager) release(s *subscription) {
+	manager.Lock()
+	defer manager.Unlock()
+	id := string(s.channel.GetId())
+	if manager.subscriptions[id] == s {
+		delete(manager.subscriptions, id)
+	}
+	s.close()
+}
+
+// removeAll cancels every subscription
+func (manager *subscriptionManager) removeAll() {
+	manager.Lock()
+	defer manager.Unlock()
+	for _, s := range manager.subscriptions {
+		s.close()
+	}
+	manager.subscriptions = make(map[string]*subscription)
+}
+
+func (manager *subscriptionManager) has(channelID string) bool {
+	manager.RLock()
+	defer manager.RUnlock()
+	_, ok := manager.subscriptions[channelID]
+	return ok
+}
+
+// state lists the active subscriptions ordered by channel ID
+func (manager *subscriptionManager) state() []SubscriptionState {
+	manager.RLock()
+	defer manager.RUnlock()
+	states := make([]SubscriptionState, 0, len(manager.subscriptions))
+	for _, s := range manager.subscriptions {
+		states = append(states, SubscriptionState{Channel: s.channel, Since: s.since})
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return string(states[i].Channel.GetId()) < string(states[j].Channel.GetId())
+	})
+	return states
+}
+
+// close stops the subscription exactly once. Pubsub is only told to cancel the subscription
+// while it's still running, since it stops reading cancellations when the parent context is done.
+func (s *subscription) close() {
+	s.closeOnce.Do(func() {
+		pubsubRunning := s.parentCtx.Err() == nil
+		s.cancel()
+		if pubsubRunning {
+			s.sub.Cancel()
+			if s.teardown != nil {
+				s.teardown()
+			}
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package p2p
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const stormChannels = 4
+const stormWorkers = 16
+const stormRounds = 50
+
+func stormChannel(i int) *pb.Channel {
+	return &pb.Channel{Id: []byte(fmt.Sprintf("stormChannel%d", i%stormChannels))}
+}
+
+func TestConcurrentDoubleSubscribe(t *testing.T) {
+	p2pInstance := newTestP2p(t, initTestPubSub(t))
+	defer p2pInstance.host.Close()
+
+	var wg sync.WaitGroup
+	results := make(chan error, stormWorkers)
+	for i := 0; i < stormWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- p2pInstance.Subscribe(testChannel)
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for err := range results {
+		if errors.IsEmpty(err) {
+			succeeded++
+		} else {
+			assert.True(t, errors.Is(errors.AlreadyExists, err))
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, 1, len(p2pInstance.GetSubscriptions()))
+	assert.NoError(t, p2pInstance.Unsubscribe(testChannel))
+}
+
+func TestJoinLeaveStorm(t *testing.T) {
+	p2pInstance := newTestP2p(t, initTestPubSub(t))
+	defer p2pInstance.host.Close()
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < stormWorkers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for round := 0; round < stormRounds; round++ {
+				channel := stormChannel(worker + round)
+				if round%2 == 0 {
+					p2pInstance.Subscribe(channel)
+				} else {
+					p2pInstance.Unsubscribe(channel)
+				}
+				p2pInstance.GetSubscriptions()
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	// Whatever survived the storm must be consistent and removable exactly once
+	for _, state := range p2pInstance.GetSubscriptions() {
+		assert.NoError(t, p2pInstance.Unsubscribe(state.Channel))
+		assert.Error(t, p2pInstance.Unsubscribe(state.Channel))
+	}
+	assert.Empty(t, p2pInstance.GetSubscriptions())
+
+	for i := 0; i < stormChannels; i++ {
+		assert.NoError(t, p2pInstance.Subscribe(stormChannel(i)))
+	}
+	assert.Equal(t, stormChannels, len(p2pInstance.GetSubscriptions()))
+	p2pInstance.subscriptions.removeAll()
+	assert.Empty(t, p2pInstance.GetSubscriptions())
+}
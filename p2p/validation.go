@@ -0,0 +1,136 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// invalidMessagePenalty is subtracted from a peer's score for every invalid message it forwards
+const invalidMessagePenalty = 10
+
+// validMessageReward is added to a peer's score for every valid message it forwards, up to maxPeerScore
+const validMessageReward = 1
+
+const maxPeerScore = 0
+
+// blacklistScore is the score at which a peer is blacklisted and its messages are no longer accepted
+const blacklistScore = -100
+
+// peerScores keeps track of how many invalid messages each peer has forwarded to this node
+type peerScores struct {
+	sync.Mutex
+	scores map[peer.ID]int
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{scores: make(map[peer.ID]int)}
+}
+
+// add changes the score of a peer by amount and returns the new score
+func (scores *peerScores) add(id peer.ID, amount int) int {
+	scores.Lock()
+	defer scores.Unlock()
+	score := scores.scores[id] + amount
+	if score > maxPeerScore {
+		score = maxPeerScore
+	}
+	scores.scores[id] = score
+	return score
+}
+
+// reset forgets the score of a peer
+func (scores *peerScores) reset(id peer.ID) {
+	scores.Lock()
+	defer scores.Unlock()
+	delete(scores.scores, id)
+}
+
+func (scores *peerScores) get(id peer.ID) int {
+	scores.Lock()
+	defer scores.Unlock()
+	return scores.scores[id]
+}
+
+// penalize lowers the score of a peer that forwarded an invalid message, blacklisting it once the score gets too low
+func (p2p *P2p) penalize(id peer.ID) {
+	score := p2p.scores.add(id, -invalidMessagePenalty)
+	if score > blacklistScore {
+		return
+	}
+	if p2p.Logger != nil {
+		p2p.Logger.Warnf("Blacklisting peer %s for forwarding invalid messages", id)
+	}
+	if p2p.ps != nil {
+		p2p.ps.BlacklistPeer(id)
+	}
+}
+
+// checkMessage decodes a pubsub message and lets the OrderService check its contents. The messages of a batch
+// are checked in order, each as if the ones before it had been applied. A batch is relayed even if some of its
+// messages refer to orders unknown to this node, since those are only skipped when the batch is received.
+// Pubsub has already verified the signature of signed messages, but the sender of an unsigned
+// message can't be trusted, so unsigned messages are only accepted when signatures aren't required.
+func (p2p *P2p) checkMessage(channel *pb.Channel, msg *pubsub.Message) error {
+	if p2p.strictSignatures && len(msg.GetSignature()) == 0 {
+		return errors.E(errors.Op("Check message"), errors.Invalid, "message is not signed")
+	}
+	messages, batched, err := unpackMessage(channel, msg.GetData())
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if p2p.Orders == nil {
+		return nil
+	}
+	if !batched {
+		return p2p.Orders.Validate(messages[0], msg.GetFrom().Pretty())
+	}
+	for _, err := range p2p.Orders.ValidateBatch(messages, msg.GetFrom().Pretty()) {
+		if !errors.IsEmpty(err) && !errors.Is(errors.NotFound, err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// validator returns a pubsub topic validator for a channel. Messages that fail validation are
+// dropped before they're gossiped on, and invalid ones count against the peer that forwarded them.
+// Messages of unsupported wire versions are relayed without penalty, since newer nodes may publish them,
+// and only skipped when they're received. Messages about orders unknown to this node are relayed without
+// penalty or reward too, so late joiners and partially connected peers keep getting order updates.
+// Validators run in the goroutines of pubsub, so they register with the run they were created in
+// and refuse messages once it's closing, letting Close wait for them before the node is torn down.
+func (p2p *P2p) validator(channel *pb.Channel) pubsub.Validator {
+	run, self := p2p.ctx, p2p.host.ID()
+	return func(ctx context.Context, from peer.ID, msg *pubsub.Message) bool {
+		if !p2p.enter(run) {
+			return false
+		}
+		defer p2p.routines.Done()
+		// Messages published by this node have been checked by the services creating them
+		if from == self {
+			return true
+		}
+		err := p2p.checkMessage(channel, msg)
+		if errors.IsEmpty(err) {
+			p2p.scores.add(from, validMessageReward)
+			return true
+		}
+		// Messages about orders this node hasn't seen can't be checked, but peers that have seen them need them
+		if errors.Is(errors.Unsupported, err) || errors.Is(errors.NotFound, err) {
+			return true
+		}
+		if errors.Is(errors.Invalid, err) {
+			p2p.getStats(string(channel.GetId())).addReceived(true)
+			p2p.penalize(from)
+		}
+		if p2p.Logger != nil {
+			p2p.Logger.Debug(errors.E(errors.Op("Validate message"), err))
+		}
+		return false
+	}
+}
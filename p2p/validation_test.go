@@ -0,0 +1,107 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const misbehavingPeer peer.ID = "misbehavingPeer"
+
+func createPubsubMessage(t *testing.T, channelID []byte, signature []byte) *pubsub.Message {
+	return createVersionedPubsubMessage(t, channelID, signature, wireVersion)
+}
+
+func createVersionedPubsubMessage(t *testing.T, channelID []byte, signature []byte, version uint32) *pubsub.Message {
+	testOrderInBytes, err := proto.Marshal(testOrder)
+	assert.NoError(t, err)
+	data, err := proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_CREATE, Data: testOrderInBytes, Version: version})
+	assert.NoError(t, err)
+	return &pubsub.Message{Message: &pubsubpb.Message{From: []byte(misbehavingPeer), Data: data, Signature: signature}}
+}
+
+func TestPeerScores(t *testing.T) {
+	scores := newPeerScores()
+	assert.Equal(t, maxPeerScore, scores.add(misbehavingPeer, validMessageReward))
+	assert.Equal(t, -invalidMessagePenalty, scores.add(misbehavingPeer, -invalidMessagePenalty))
+	assert.Equal(t, -invalidMessagePenalty+validMessageReward, scores.add(misbehavingPeer, validMessageReward))
+	assert.Equal(t, 0, scores.get("unknownPeer"))
+}
+
+func TestValidator(t *testing.T) {
+	p2pInstance := newTestP2p(t, initTestPubSub(t))
+	defer p2pInstance.host.Close()
+	p2pInstance.RegisterOrderService(&service.OrderService{})
+
+	validate := p2pInstance.validator(testChannel)
+	ctx := context.Background()
+	signature := []byte("signature")
+
+	// Messages published by this node are always accepted
+	assert.True(t, validate(ctx, p2pInstance.host.ID(), createPubsubMessage(t, testChannel.GetId(), nil)))
+
+	assert.True(t, validate(ctx, misbehavingPeer, createPubsubMessage(t, testChannel.GetId(), signature)))
+	assert.False(t, validate(ctx, misbehavingPeer, createPubsubMessage(t, testChannel.GetId(), nil)))
+	assert.False(t, validate(ctx, misbehavingPeer, createPubsubMessage(t, []byte("otherChannel"), signature)))
+	assert.False(t, validate(ctx, misbehavingPeer, &pubsub.Message{Message: &pubsubpb.Message{Data: []byte("garbage"), Signature: signature}}))
+
+	assert.Equal(t, -3*invalidMessagePenalty, p2pInstance.scores.get(misbehavingPeer))
+	assert.Equal(t, uint64(3), p2pInstance.GetChannelStats(testChannel).GetMessagesRejected())
+
+	// Messages of unknown wire versions are relayed without penalizing the peer, and skipped when received
+	newer := createVersionedPubsubMessage(t, testChannel.GetId(), signature, wireVersion+1)
+	assert.True(t, validate(ctx, misbehavingPeer, newer))
+	assert.True(t, validate(ctx, misbehavingPeer, createVersionedPubsubMessage(t, testChannel.GetId(), signature, 0)))
+	assert.Equal(t, -3*invalidMessagePenalty, p2pInstance.scores.get(misbehavingPeer))
+	received := p2pInstance.GetChannelStats(testChannel).GetOrdersReceived()
+	p2pInstance.receive(testChannel, newer.GetData(), misbehavingPeer.Pretty())
+	assert.Equal(t, received, p2pInstance.GetChannelStats(testChannel).GetOrdersReceived())
+	assert.Equal(t, uint64(3), p2pInstance.GetChannelStats(testChannel).GetMessagesRejected())
+
+	// Enough invalid messages get the peer blacklisted
+	for p2pInstance.scores.get(misbehavingPeer) > blacklistScore {
+		validate(ctx, misbehavingPeer, createPubsubMessage(t, testChannel.GetId(), nil))
+	}
+	assert.True(t, p2pInstance.scores.get(misbehavingPeer) <= blacklistScore)
+}
+
+func TestValidatorRelaysUnknownOrders(t *testing.T) {
+	p2pInstance := newTestP2p(t, initTestPubSub(t))
+	defer p2pInstance.host.Close()
+	orders := &service.OrderService{}
+	orders.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	p2pInstance.RegisterOrderService(orders)
+
+	// A node without a copy of the order can't check a DELETE for it, but passes it on to the peers that can
+	orderInBytes, err := proto.Marshal(&pb.Order{Id: []byte("unseenOrder"), Asset: "ETH", CounterAsset: "BTC", Amount: 1, ChannelID: testChannel.GetId(), Creator: misbehavingPeer.Pretty()})
+	assert.NoError(t, err)
+	data, err := proto.Marshal(&pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_DELETE, Data: orderInBytes, Version: messageWireVersion})
+	assert.NoError(t, err)
+	msg := &pubsub.Message{Message: &pubsubpb.Message{From: []byte(misbehavingPeer), Data: data, Signature: []byte("signature")}}
+	assert.True(t, errors.Is(errors.NotFound, p2pInstance.checkMessage(testChannel, msg)))
+	p2pInstance.scores.add(misbehavingPeer, -invalidMessagePenalty)
+	assert.True(t, p2pInstance.validator(testChannel)(context.Background(), misbehavingPeer, msg))
+	assert.Equal(t, -invalidMessagePenalty, p2pInstance.scores.get(misbehavingPeer))
+	assert.Equal(t, uint64(0), p2pInstance.GetChannelStats(testChannel).GetMessagesRejected())
+}
+
+func TestSubscriptionValidator(t *testing.T) {
+	p2pInstance := newTestP2p(t, initTestPubSub(t))
+	defer p2pInstance.host.Close()
+
+	assert.NoError(t, p2pInstance.Subscribe(testChannel))
+	// The validator is already registered while subscribed
+	assert.Error(t, p2pInstance.ps.RegisterTopicValidator(channelTopic(testChannel.GetId()), p2pInstance.validator(testChannel)))
+	assert.NoError(t, p2pInstance.Unsubscribe(testChannel))
+	// and removed on unsubscribe
+	assert.Error(t, p2pInstance.ps.UnregisterTopicValidator(channelTopic(testChannel.GetId())))
+}
@@ -11,6 +11,7 @@ It is generated from these files:
 It has these top-level commands:
 	OrderHandlerClientCommand
 	ChannelHandlerClientCommand
+	NodeHandlerClientCommand
 */
 
 package pb
@@ -499,6 +500,92 @@ func init() {
 	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerGetAllOrdersClientCommand.Flags())
 }
 
+var _OrderHandlerQueryOrdersClientCommand = &cobra.Command{
+	Use:  "queryorders",
+	Long: "QueryOrders client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	queryorders -p > req.json
+
+Submit request using file:
+	queryorders -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | queryorders --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v OrderQuery
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.QueryOrders(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerQueryOrdersClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerQueryOrdersClientCommand.Flags())
+}
+
+var _OrderHandlerTakeClientCommand = &cobra.Command{
+	Use:  "take",
+	Long: "Take client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	take -p > req.json
+
+Submit request using file:
+	take -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | take --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v TakeRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Take(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerTakeClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerTakeClientCommand.Flags())
+}
+
 var _DefaultChannelHandlerClientCommandConfig = _NewChannelHandlerClientCommandConfig()
 
 type _ChannelHandlerClientCommandConfig struct {
@@ -832,3 +919,767 @@ func init() {
 	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetAllChannelsClientCommand)
 	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetAllChannelsClientCommand.Flags())
 }
+
+var _ChannelHandlerGetChannelPeersClientCommand = &cobra.Command{
+	Use:  "getchannelpeers",
+	Long: "GetChannelPeers client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getchannelpeers -p > req.json
+
+Submit request using file:
+	getchannelpeers -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getchannelpeers --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelSpecificRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetChannelPeers(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetChannelPeersClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetChannelPeersClientCommand.Flags())
+}
+
+var _ChannelHandlerGetChannelStatsClientCommand = &cobra.Command{
+	Use:  "getchannelstats",
+	Long: "GetChannelStats client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getchannelstats -p > req.json
+
+Submit request using file:
+	getchannelstats -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getchannelstats --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelSpecificRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetChannelStats(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetChannelStatsClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetChannelStatsClientCommand.Flags())
+}
+
+var _ChannelHandlerDiscoverChannelsClientCommand = &cobra.Command{
+	Use:  "discoverchannels",
+	Long: "DiscoverChannels client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	discoverchannels -p > req.json
+
+Submit request using file:
+	discoverchannels -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | discoverchannels --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.DiscoverChannels(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerDiscoverChannelsClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerDiscoverChannelsClientCommand.Flags())
+}
+
+var _ChannelHandlerModerateClientCommand = &cobra.Command{
+	Use:  "moderate",
+	Long: "Moderate client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	moderate -p > req.json
+
+Submit request using file:
+	moderate -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | moderate --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ModerationRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Moderate(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerModerateClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerModerateClientCommand.Flags())
+}
+
+var _DefaultNodeHandlerClientCommandConfig = _NewNodeHandlerClientCommandConfig()
+
+type _NodeHandlerClientCommandConfig struct {
+	ServerAddr         string        `envconfig:"SERVER_ADDR" default:"localhost:8080"`
+	RequestFile        string        `envconfig:"REQUEST_FILE"`
+	PrintSampleRequest bool          `envconfig:"PRINT_SAMPLE_REQUEST"`
+	ResponseFormat     string        `envconfig:"RESPONSE_FORMAT" default:"json"`
+	Timeout            time.Duration `envconfig:"TIMEOUT" default:"10s"`
+	TLS                bool          `envconfig:"TLS"`
+	ServerName         string        `envconfig:"TLS_SERVER_NAME"`
+	InsecureSkipVerify bool          `envconfig:"TLS_INSECURE_SKIP_VERIFY"`
+	CACertFile         string        `envconfig:"TLS_CA_CERT_FILE"`
+	CertFile           string        `envconfig:"TLS_CERT_FILE"`
+	KeyFile            string        `envconfig:"TLS_KEY_FILE"`
+	AuthToken          string        `envconfig:"AUTH_TOKEN"`
+	AuthTokenType      string        `envconfig:"AUTH_TOKEN_TYPE" default:"Bearer"`
+	JWTKey             string        `envconfig:"JWT_KEY"`
+	JWTKeyFile         string        `envconfig:"JWT_KEY_FILE"`
+}
+
+func _NewNodeHandlerClientCommandConfig() *_NodeHandlerClientCommandConfig {
+	c := &_NodeHandlerClientCommandConfig{}
+	envconfig.Process("", c)
+	return c
+}
+
+func (o *_NodeHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.ServerAddr, "server-addr", "s", o.ServerAddr, "server address in form of host:port")
+	fs.StringVarP(&o.RequestFile, "request-file", "f", o.RequestFile, "client request file (must be json, yaml, or xml); use \"-\" for stdin + json")
+	fs.BoolVarP(&o.PrintSampleRequest, "print-sample-request", "p", o.PrintSampleRequest, "print sample request file and exit")
+	fs.StringVarP(&o.ResponseFormat, "response-format", "o", o.ResponseFormat, "response format (json, prettyjson, yaml, or xml)")
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "client connection timeout")
+	fs.BoolVar(&o.TLS, "tls", o.TLS, "enable tls")
+	fs.StringVar(&o.ServerName, "tls-server-name", o.ServerName, "tls server name override")
+	fs.BoolVar(&o.InsecureSkipVerify, "tls-insecure-skip-verify", o.InsecureSkipVerify, "INSECURE: skip tls checks")
+	fs.StringVar(&o.CACertFile, "tls-ca-cert-file", o.CACertFile, "ca certificate file")
+	fs.StringVar(&o.CertFile, "tls-cert-file", o.CertFile, "client certificate file")
+	fs.StringVar(&o.KeyFile, "tls-key-file", o.KeyFile, "client key file")
+	fs.StringVar(&o.AuthToken, "auth-token", o.AuthToken, "authorization token")
+	fs.StringVar(&o.AuthTokenType, "auth-token-type", o.AuthTokenType, "authorization token type")
+	fs.StringVar(&o.JWTKey, "jwt-key", o.JWTKey, "jwt key")
+	fs.StringVar(&o.JWTKeyFile, "jwt-key-file", o.JWTKeyFile, "jwt key file")
+}
+
+var NodeHandlerClientCommand = &cobra.Command{
+	Use: "nodehandler",
+}
+
+func _DialNodeHandler() (*grpc.ClientConn, NodeHandlerClient, error) {
+	cfg := _DefaultNodeHandlerClientCommandConfig
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithTimeout(cfg.Timeout),
+	}
+	if cfg.TLS {
+		tlsConfig := &tls.Config{}
+		if cfg.InsecureSkipVerify {
+			tlsConfig.InsecureSkipVerify = true
+		}
+		if cfg.CACertFile != "" {
+			cacert, err := ioutil.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("ca cert: %v", err)
+			}
+			certpool := x509.NewCertPool()
+			certpool.AppendCertsFromPEM(cacert)
+			tlsConfig.RootCAs = certpool
+		}
+		if cfg.CertFile != "" {
+			if cfg.KeyFile == "" {
+				return nil, nil, fmt.Errorf("missing key file")
+			}
+			pair, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cert/key: %v", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{pair}
+		}
+		if cfg.ServerName != "" {
+			tlsConfig.ServerName = cfg.ServerName
+		} else {
+			addr, _, _ := net.SplitHostPort(cfg.ServerAddr)
+			tlsConfig.ServerName = addr
+		}
+		//tlsConfig.BuildNameToCertificate()
+		cred := credentials.NewTLS(tlsConfig)
+		opts = append(opts, grpc.WithTransportCredentials(cred))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if cfg.AuthToken != "" {
+		cred := oauth.NewOauthAccess(&oauth2.Token{
+			AccessToken: cfg.AuthToken,
+			TokenType:   cfg.AuthTokenType,
+		})
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKey != "" {
+		cred, err := oauth.NewJWTAccessFromKey([]byte(cfg.JWTKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKeyFile != "" {
+		cred, err := oauth.NewJWTAccessFromFile(cfg.JWTKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key file: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	conn, err := grpc.Dial(cfg.ServerAddr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, NewNodeHandlerClient(conn), nil
+}
+
+type _NodeHandlerRoundTripFunc func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
+
+func _NodeHandlerRoundTrip(sample interface{}, fn _NodeHandlerRoundTripFunc) error {
+	cfg := _DefaultNodeHandlerClientCommandConfig
+	var em iocodec.EncoderMaker
+	var ok bool
+	if cfg.ResponseFormat == "" {
+		em = iocodec.DefaultEncoders["json"]
+	} else {
+		em, ok = iocodec.DefaultEncoders[cfg.ResponseFormat]
+		if !ok {
+			return fmt.Errorf("invalid response format: %q", cfg.ResponseFormat)
+		}
+	}
+	if cfg.PrintSampleRequest {
+		return em.NewEncoder(os.Stdout).Encode(sample)
+	}
+	var d iocodec.Decoder
+	if cfg.RequestFile == "" || cfg.RequestFile == "-" {
+		d = iocodec.DefaultDecoders["json"].NewDecoder(os.Stdin)
+	} else {
+		f, err := os.Open(cfg.RequestFile)
+		if err != nil {
+			return fmt.Errorf("request file: %v", err)
+		}
+		defer f.Close()
+		ext := filepath.Ext(cfg.RequestFile)
+		if len(ext) > 0 && ext[0] == '.' {
+			ext = ext[1:]
+		}
+		dm, ok := iocodec.DefaultDecoders[ext]
+		if !ok {
+			return fmt.Errorf("invalid request file format: %q", ext)
+		}
+		d = dm.NewDecoder(f)
+	}
+	conn, client, err := _DialNodeHandler()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return fn(client, d, em.NewEncoder(os.Stdout))
+}
+
+var _NodeHandlerBanPeerClientCommand = &cobra.Command{
+	Use:  "banpeer",
+	Long: "BanPeer client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	banpeer -p > req.json
+
+Submit request using file:
+	banpeer -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | banpeer --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v PeerSpecificRequest
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.BanPeer(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerBanPeerClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerBanPeerClientCommand.Flags())
+}
+
+var _NodeHandlerUnbanPeerClientCommand = &cobra.Command{
+	Use:  "unbanpeer",
+	Long: "UnbanPeer client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	unbanpeer -p > req.json
+
+Submit request using file:
+	unbanpeer -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | unbanpeer --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v PeerSpecificRequest
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.UnbanPeer(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerUnbanPeerClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerUnbanPeerClientCommand.Flags())
+}
+
+var _NodeHandlerListBannedPeersClientCommand = &cobra.Command{
+	Use:  "listbannedpeers",
+	Long: "ListBannedPeers client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	listbannedpeers -p > req.json
+
+Submit request using file:
+	listbannedpeers -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | listbannedpeers --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.ListBannedPeers(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerListBannedPeersClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerListBannedPeersClientCommand.Flags())
+}
+
+var _NodeHandlerGetConnectionStatsClientCommand = &cobra.Command{
+	Use:  "getconnectionstats",
+	Long: "GetConnectionStats client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getconnectionstats -p > req.json
+
+Submit request using file:
+	getconnectionstats -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getconnectionstats --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetConnectionStats(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetConnectionStatsClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetConnectionStatsClientCommand.Flags())
+}
+
+var _NodeHandlerGetNodeInfoClientCommand = &cobra.Command{
+	Use:  "getnodeinfo",
+	Long: "GetNodeInfo client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getnodeinfo -p > req.json
+
+Submit request using file:
+	getnodeinfo -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getnodeinfo --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetNodeInfo(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetNodeInfoClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetNodeInfoClientCommand.Flags())
+}
+
+var _NodeHandlerListPeersClientCommand = &cobra.Command{
+	Use:  "listpeers",
+	Long: "ListPeers client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	listpeers -p > req.json
+
+Submit request using file:
+	listpeers -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | listpeers --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.ListPeers(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerListPeersClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerListPeersClientCommand.Flags())
+}
+
+var _NodeHandlerConnectPeerClientCommand = &cobra.Command{
+	Use:  "connectpeer",
+	Long: "ConnectPeer client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	connectpeer -p > req.json
+
+Submit request using file:
+	connectpeer -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | connectpeer --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ConnectPeerRequest
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.ConnectPeer(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerConnectPeerClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerConnectPeerClientCommand.Flags())
+}
+
+var _NodeHandlerDisconnectPeerClientCommand = &cobra.Command{
+	Use:  "disconnectpeer",
+	Long: "DisconnectPeer client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	disconnectpeer -p > req.json
+
+Submit request using file:
+	disconnectpeer -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | disconnectpeer --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v PeerSpecificRequest
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.DisconnectPeer(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerDisconnectPeerClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerDisconnectPeerClientCommand.Flags())
+}
+
+var _NodeHandlerListSubscriptionsClientCommand = &cobra.Command{
+	Use:  "listsubscriptions",
+	Long: "ListSubscriptions client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	listsubscriptions -p > req.json
+
+Submit request using file:
+	listsubscriptions -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | listsubscriptions --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.ListSubscriptions(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerListSubscriptionsClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerListSubscriptionsClientCommand.Flags())
+}
+
+var _NodeHandlerGetNATStatusClientCommand = &cobra.Command{
+	Use:  "getnatstatus",
+	Long: "GetNATStatus client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getnatstatus -p > req.json
+
+Submit request using file:
+	getnatstatus -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getnatstatus --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetNATStatus(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetNATStatusClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetNATStatusClientCommand.Flags())
+}
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -53,10 +54,12 @@ func (State) EnumDescriptor() ([]byte, []int) {
 type Operation int32
 
 const (
-	Operation_CREATE Operation = 0
-	Operation_DELETE Operation = 1
-	Operation_LOCK   Operation = 2
-	Operation_UNLOCK Operation = 3
+	Operation_CREATE   Operation = 0
+	Operation_DELETE   Operation = 1
+	Operation_LOCK     Operation = 2
+	Operation_UNLOCK   Operation = 3
+	Operation_MODERATE Operation = 4
+	Operation_BATCH    Operation = 5
 )
 
 var Operation_name = map[int32]string{
@@ -64,13 +67,17 @@ var Operation_name = map[int32]string{
 	1: "DELETE",
 	2: "LOCK",
 	3: "UNLOCK",
+	4: "MODERATE",
+	5: "BATCH",
 }
 
 var Operation_value = map[string]int32{
-	"CREATE": 0,
-	"DELETE": 1,
-	"LOCK":   2,
-	"UNLOCK": 3,
+	"CREATE":   0,
+	"DELETE":   1,
+	"LOCK":     2,
+	"UNLOCK":   3,
+	"MODERATE": 4,
+	"BATCH":    5,
 }
 
 func (x Operation) String() string {
@@ -81,6 +88,177 @@ func (Operation) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_a9abbf861cc1c96d, []int{1}
 }
 
+type Compression int32
+
+const (
+	Compression_NO_COMPRESSION Compression = 0
+	Compression_SNAPPY         Compression = 1
+)
+
+var Compression_name = map[int32]string{
+	0: "NO_COMPRESSION",
+	1: "SNAPPY",
+}
+
+var Compression_value = map[string]int32{
+	"NO_COMPRESSION": 0,
+	"SNAPPY":         1,
+}
+
+func (x Compression) String() string {
+	return proto.EnumName(Compression_name, int32(x))
+}
+
+func (Compression) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{2}
+}
+
+type NegotiationType int32
+
+const (
+	NegotiationType_TAKE_REQUEST      NegotiationType = 0
+	NegotiationType_ACCEPT            NegotiationType = 1
+	NegotiationType_REJECT            NegotiationType = 2
+	NegotiationType_LOCK_CONFIRMATION NegotiationType = 3
+)
+
+var NegotiationType_name = map[int32]string{
+	0: "TAKE_REQUEST",
+	1: "ACCEPT",
+	2: "REJECT",
+	3: "LOCK_CONFIRMATION",
+}
+
+var NegotiationType_value = map[string]int32{
+	"TAKE_REQUEST":      0,
+	"ACCEPT":            1,
+	"REJECT":            2,
+	"LOCK_CONFIRMATION": 3,
+}
+
+func (x NegotiationType) String() string {
+	return proto.EnumName(NegotiationType_name, int32(x))
+}
+
+func (NegotiationType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{3}
+}
+
+type ModerationAction int32
+
+const (
+	ModerationAction_REMOVE_ORDER ModerationAction = 0
+	ModerationAction_BAN_PEER     ModerationAction = 1
+	ModerationAction_UPDATE_RULES ModerationAction = 2
+)
+
+var ModerationAction_name = map[int32]string{
+	0: "REMOVE_ORDER",
+	1: "BAN_PEER",
+	2: "UPDATE_RULES",
+}
+
+var ModerationAction_value = map[string]int32{
+	"REMOVE_ORDER": 0,
+	"BAN_PEER":     1,
+	"UPDATE_RULES": 2,
+}
+
+func (x ModerationAction) String() string {
+	return proto.EnumName(ModerationAction_name, int32(x))
+}
+
+func (ModerationAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{4}
+}
+
+type OrderSort int32
+
+const (
+	OrderSort_UNSORTED   OrderSort = 0
+	OrderSort_BY_CREATED OrderSort = 1
+	OrderSort_BY_PRICE   OrderSort = 2
+)
+
+var OrderSort_name = map[int32]string{
+	0: "UNSORTED",
+	1: "BY_CREATED",
+	2: "BY_PRICE",
+}
+
+var OrderSort_value = map[string]int32{
+	"UNSORTED":   0,
+	"BY_CREATED": 1,
+	"BY_PRICE":   2,
+}
+
+func (x OrderSort) String() string {
+	return proto.EnumName(OrderSort_name, int32(x))
+}
+
+func (OrderSort) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{5}
+}
+
+type Direction int32
+
+const (
+	Direction_DIRECTION_UNKNOWN Direction = 0
+	Direction_INBOUND           Direction = 1
+	Direction_OUTBOUND          Direction = 2
+)
+
+var Direction_name = map[int32]string{
+	0: "DIRECTION_UNKNOWN",
+	1: "INBOUND",
+	2: "OUTBOUND",
+}
+
+var Direction_value = map[string]int32{
+	"DIRECTION_UNKNOWN": 0,
+	"INBOUND":           1,
+	"OUTBOUND":          2,
+}
+
+func (x Direction) String() string {
+	return proto.EnumName(Direction_name, int32(x))
+}
+
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{6}
+}
+
+type Reachability int32
+
+const (
+	Reachability_REACHABILITY_UNKNOWN Reachability = 0
+	Reachability_PUBLIC               Reachability = 1
+	Reachability_RELAYED              Reachability = 2
+	Reachability_UNREACHABLE          Reachability = 3
+)
+
+var Reachability_name = map[int32]string{
+	0: "REACHABILITY_UNKNOWN",
+	1: "PUBLIC",
+	2: "RELAYED",
+	3: "UNREACHABLE",
+}
+
+var Reachability_value = map[string]int32{
+	"REACHABILITY_UNKNOWN": 0,
+	"PUBLIC":               1,
+	"RELAYED":              2,
+	"UNREACHABLE":          3,
+}
+
+func (x Reachability) String() string {
+	return proto.EnumName(Reachability_name, int32(x))
+}
+
+func (Reachability) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{7}
+}
+
 type Order struct {
 	Id                   []byte               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Created              *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
@@ -89,6 +267,8 @@ type Order struct {
 	Amount               uint64               `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
 	Price                float32              `protobuf:"fixed32,6,opt,name=price,proto3" json:"price,omitempty"`
 	State                State                `protobuf:"varint,7,opt,name=state,proto3,enum=pb.State" json:"state,omitempty"`
+	ChannelID            []byte               `protobuf:"bytes,8,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Creator              string               `protobuf:"bytes,9,opt,name=creator,proto3" json:"creator,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -168,6 +348,20 @@ func (m *Order) GetState() State {
 	return State_OPEN
 }
 
+func (m *Order) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *Order) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
 type Channel struct {
 	Id                   []byte          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Options              *ChannelOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
@@ -216,12 +410,14 @@ func (m *Channel) GetOptions() *ChannelOptions {
 }
 
 type WireMessage struct {
-	ChannelID            []byte    `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	Operation            Operation `protobuf:"varint,2,opt,name=operation,proto3,enum=pb.Operation" json:"operation,omitempty"`
-	Data                 []byte    `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	ChannelID            []byte      `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Operation            Operation   `protobuf:"varint,2,opt,name=operation,proto3,enum=pb.Operation" json:"operation,omitempty"`
+	Data                 []byte      `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Version              uint32      `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Compression          Compression `protobuf:"varint,5,opt,name=compression,proto3,enum=pb.Compression" json:"compression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *WireMessage) Reset()         { *m = WireMessage{} }
@@ -270,6 +466,106 @@ func (m *WireMessage) GetData() []byte {
 	return nil
 }
 
+func (m *WireMessage) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *WireMessage) GetCompression() Compression {
+	if m != nil {
+		return m.Compression
+	}
+	return Compression_NO_COMPRESSION
+}
+
+type WireBatch struct {
+	Messages             [][]byte `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WireBatch) Reset()         { *m = WireBatch{} }
+func (m *WireBatch) String() string { return proto.CompactTextString(m) }
+func (*WireBatch) ProtoMessage()    {}
+func (*WireBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{3}
+}
+
+func (m *WireBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WireBatch.Unmarshal(m, b)
+}
+func (m *WireBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WireBatch.Marshal(b, m, deterministic)
+}
+func (m *WireBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WireBatch.Merge(m, src)
+}
+func (m *WireBatch) XXX_Size() int {
+	return xxx_messageInfo_WireBatch.Size(m)
+}
+func (m *WireBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_WireBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WireBatch proto.InternalMessageInfo
+
+func (m *WireBatch) GetMessages() [][]byte {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+type Capabilities struct {
+	Versions             []uint32 `protobuf:"varint,1,rep,packed,name=versions,proto3" json:"versions,omitempty"`
+	Features             []string `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Capabilities) Reset()         { *m = Capabilities{} }
+func (m *Capabilities) String() string { return proto.CompactTextString(m) }
+func (*Capabilities) ProtoMessage()    {}
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{4}
+}
+
+func (m *Capabilities) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Capabilities.Unmarshal(m, b)
+}
+func (m *Capabilities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Capabilities.Marshal(b, m, deterministic)
+}
+func (m *Capabilities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Capabilities.Merge(m, src)
+}
+func (m *Capabilities) XXX_Size() int {
+	return xxx_messageInfo_Capabilities.Size(m)
+}
+func (m *Capabilities) XXX_DiscardUnknown() {
+	xxx_messageInfo_Capabilities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Capabilities proto.InternalMessageInfo
+
+func (m *Capabilities) GetVersions() []uint32 {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
+func (m *Capabilities) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
 type CreateRequest struct {
 	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
 	Asset                string   `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
@@ -285,7 +581,7 @@ func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
 func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRequest) ProtoMessage()    {}
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a9abbf861cc1c96d, []int{3}
+	return fileDescriptor_a9abbf861cc1c96d, []int{5}
 }
 
 func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
@@ -342,18 +638,21 @@ func (m *CreateRequest) GetPrice() float32 {
 }
 
 type JoinRequest struct {
-	Asset                string   `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
-	CounterAsset         string   `protobuf:"bytes,2,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Asset                string      `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	CounterAsset         string      `protobuf:"bytes,2,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
+	Admins               [][]byte    `protobuf:"bytes,3,rep,name=admins,proto3" json:"admins,omitempty"`
+	BatchWindow          uint32      `protobuf:"varint,4,opt,name=batchWindow,proto3" json:"batchWindow,omitempty"`
+	Compression          Compression `protobuf:"varint,5,opt,name=compression,proto3,enum=pb.Compression" json:"compression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *JoinRequest) Reset()         { *m = JoinRequest{} }
 func (m *JoinRequest) String() string { return proto.CompactTextString(m) }
 func (*JoinRequest) ProtoMessage()    {}
 func (*JoinRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a9abbf861cc1c96d, []int{4}
+	return fileDescriptor_a9abbf861cc1c96d, []int{6}
 }
 
 func (m *JoinRequest) XXX_Unmarshal(b []byte) error {
@@ -388,18 +687,43 @@ func (m *JoinRequest) GetCounterAsset() string {
 	return ""
 }
 
+func (m *JoinRequest) GetAdmins() [][]byte {
+	if m != nil {
+		return m.Admins
+	}
+	return nil
+}
+
+func (m *JoinRequest) GetBatchWindow() uint32 {
+	if m != nil {
+		return m.BatchWindow
+	}
+	return 0
+}
+
+func (m *JoinRequest) GetCompression() Compression {
+	if m != nil {
+		return m.Compression
+	}
+	return Compression_NO_COMPRESSION
+}
+
 type ChannelOptions struct {
-	AssetPair            string   `protobuf:"bytes,1,opt,name=assetPair,proto3" json:"assetPair,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	AssetPair            string        `protobuf:"bytes,1,opt,name=assetPair,proto3" json:"assetPair,omitempty"`
+	Admins               [][]byte      `protobuf:"bytes,2,rep,name=admins,proto3" json:"admins,omitempty"`
+	Rules                *ChannelRules `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"`
+	BatchWindow          uint32        `protobuf:"varint,4,opt,name=batchWindow,proto3" json:"batchWindow,omitempty"`
+	Compression          Compression   `protobuf:"varint,5,opt,name=compression,proto3,enum=pb.Compression" json:"compression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *ChannelOptions) Reset()         { *m = ChannelOptions{} }
 func (m *ChannelOptions) String() string { return proto.CompactTextString(m) }
 func (*ChannelOptions) ProtoMessage()    {}
 func (*ChannelOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a9abbf861cc1c96d, []int{5}
+	return fileDescriptor_a9abbf861cc1c96d, []int{7}
 }
 
 func (m *ChannelOptions) XXX_Unmarshal(b []byte) error {
@@ -427,891 +751,3063 @@ func (m *ChannelOptions) GetAssetPair() string {
 	return ""
 }
 
-type OrderSpecificRequest struct {
-	OrderID              []byte   `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
-	ChannelID            []byte   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+func (m *ChannelOptions) GetAdmins() [][]byte {
+	if m != nil {
+		return m.Admins
+	}
+	return nil
+}
+
+func (m *ChannelOptions) GetRules() *ChannelRules {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *ChannelOptions) GetBatchWindow() uint32 {
+	if m != nil {
+		return m.BatchWindow
+	}
+	return 0
+}
+
+func (m *ChannelOptions) GetCompression() Compression {
+	if m != nil {
+		return m.Compression
+	}
+	return Compression_NO_COMPRESSION
+}
+
+type ChannelRules struct {
+	MinAmount            uint64   `protobuf:"varint,1,opt,name=minAmount,proto3" json:"minAmount,omitempty"`
+	MaxAmount            uint64   `protobuf:"varint,2,opt,name=maxAmount,proto3" json:"maxAmount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OrderSpecificRequest) Reset()         { *m = OrderSpecificRequest{} }
-func (m *OrderSpecificRequest) String() string { return proto.CompactTextString(m) }
-func (*OrderSpecificRequest) ProtoMessage()    {}
-func (*OrderSpecificRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a9abbf861cc1c96d, []int{6}
+func (m *ChannelRules) Reset()         { *m = ChannelRules{} }
+func (m *ChannelRules) String() string { return proto.CompactTextString(m) }
+func (*ChannelRules) ProtoMessage()    {}
+func (*ChannelRules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{8}
 }
 
-func (m *OrderSpecificRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderSpecificRequest.Unmarshal(m, b)
+func (m *ChannelRules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelRules.Unmarshal(m, b)
 }
-func (m *OrderSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderSpecificRequest.Marshal(b, m, deterministic)
+func (m *ChannelRules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelRules.Marshal(b, m, deterministic)
 }
-func (m *OrderSpecificRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderSpecificRequest.Merge(m, src)
+func (m *ChannelRules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelRules.Merge(m, src)
 }
-func (m *OrderSpecificRequest) XXX_Size() int {
-	return xxx_messageInfo_OrderSpecificRequest.Size(m)
+func (m *ChannelRules) XXX_Size() int {
+	return xxx_messageInfo_ChannelRules.Size(m)
 }
-func (m *OrderSpecificRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderSpecificRequest.DiscardUnknown(m)
+func (m *ChannelRules) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelRules.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OrderSpecificRequest proto.InternalMessageInfo
+var xxx_messageInfo_ChannelRules proto.InternalMessageInfo
 
-func (m *OrderSpecificRequest) GetOrderID() []byte {
+func (m *ChannelRules) GetMinAmount() uint64 {
 	if m != nil {
-		return m.OrderID
+		return m.MinAmount
 	}
-	return nil
+	return 0
 }
 
-func (m *OrderSpecificRequest) GetChannelID() []byte {
+func (m *ChannelRules) GetMaxAmount() uint64 {
 	if m != nil {
-		return m.ChannelID
+		return m.MaxAmount
 	}
-	return nil
-}
-
-type ChannelSpecificRequest struct {
-	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	return 0
 }
 
-func (m *ChannelSpecificRequest) Reset()         { *m = ChannelSpecificRequest{} }
-func (m *ChannelSpecificRequest) String() string { return proto.CompactTextString(m) }
-func (*ChannelSpecificRequest) ProtoMessage()    {}
-func (*ChannelSpecificRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a9abbf861cc1c96d, []int{7}
+type ModerationMessage struct {
+	Action               ModerationAction `protobuf:"varint,1,opt,name=action,proto3,enum=pb.ModerationAction" json:"action,omitempty"`
+	OrderID              []byte           `protobuf:"bytes,2,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	PeerID               string           `protobuf:"bytes,3,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Rules                *ChannelRules    `protobuf:"bytes,4,opt,name=rules,proto3" json:"rules,omitempty"`
+	Admin                []byte           `protobuf:"bytes,5,opt,name=admin,proto3" json:"admin,omitempty"`
+	Signature            []byte           `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	ChannelID            []byte           `protobuf:"bytes,7,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Sequence             uint64           `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ModerationMessage) Reset()         { *m = ModerationMessage{} }
+func (m *ModerationMessage) String() string { return proto.CompactTextString(m) }
+func (*ModerationMessage) ProtoMessage()    {}
+func (*ModerationMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{9}
 }
 
-func (m *ChannelSpecificRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelSpecificRequest.Unmarshal(m, b)
+func (m *ModerationMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModerationMessage.Unmarshal(m, b)
 }
-func (m *ChannelSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelSpecificRequest.Marshal(b, m, deterministic)
+func (m *ModerationMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModerationMessage.Marshal(b, m, deterministic)
 }
-func (m *ChannelSpecificRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelSpecificRequest.Merge(m, src)
+func (m *ModerationMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModerationMessage.Merge(m, src)
 }
-func (m *ChannelSpecificRequest) XXX_Size() int {
-	return xxx_messageInfo_ChannelSpecificRequest.Size(m)
+func (m *ModerationMessage) XXX_Size() int {
+	return xxx_messageInfo_ModerationMessage.Size(m)
 }
-func (m *ChannelSpecificRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelSpecificRequest.DiscardUnknown(m)
+func (m *ModerationMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModerationMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChannelSpecificRequest proto.InternalMessageInfo
+var xxx_messageInfo_ModerationMessage proto.InternalMessageInfo
 
-func (m *ChannelSpecificRequest) GetId() []byte {
+func (m *ModerationMessage) GetAction() ModerationAction {
 	if m != nil {
-		return m.Id
+		return m.Action
+	}
+	return ModerationAction_REMOVE_ORDER
+}
+
+func (m *ModerationMessage) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
 	}
 	return nil
 }
 
-type CreateResponse struct {
-	CreatedOrder         *Order   `protobuf:"bytes,1,opt,name=createdOrder,proto3" json:"createdOrder,omitempty"`
-	Error                *Error   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ModerationMessage) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
 }
 
-func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
-func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
-func (*CreateResponse) ProtoMessage()    {}
-func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a9abbf861cc1c96d, []int{8}
+func (m *ModerationMessage) GetRules() *ChannelRules {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
 }
 
-func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
-}
-func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
-}
-func (m *CreateResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateResponse.Merge(m, src)
-}
-func (m *CreateResponse) XXX_Size() int {
-	return xxx_messageInfo_CreateResponse.Size(m)
-}
-func (m *CreateResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
+func (m *ModerationMessage) GetAdmin() []byte {
+	if m != nil {
+		return m.Admin
+	}
+	return nil
 }
 
-var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
-
-func (m *CreateResponse) GetCreatedOrder() *Order {
+func (m *ModerationMessage) GetSignature() []byte {
 	if m != nil {
-		return m.CreatedOrder
+		return m.Signature
 	}
 	return nil
 }
 
-func (m *CreateResponse) GetError() *Error {
+func (m *ModerationMessage) GetChannelID() []byte {
 	if m != nil {
-		return m.Error
+		return m.ChannelID
 	}
 	return nil
 }
 
-type OrderListResponse struct {
-	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ModerationMessage) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
 }
 
-func (m *OrderListResponse) Reset()         { *m = OrderListResponse{} }
-func (m *OrderListResponse) String() string { return proto.CompactTextString(m) }
-func (*OrderListResponse) ProtoMessage()    {}
-func (*OrderListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a9abbf861cc1c96d, []int{9}
+type ModerationRequest struct {
+	ChannelID            []byte           `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Action               ModerationAction `protobuf:"varint,2,opt,name=action,proto3,enum=pb.ModerationAction" json:"action,omitempty"`
+	OrderID              []byte           `protobuf:"bytes,3,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	PeerID               string           `protobuf:"bytes,4,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Rules                *ChannelRules    `protobuf:"bytes,5,opt,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *OrderListResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderListResponse.Unmarshal(m, b)
+func (m *ModerationRequest) Reset()         { *m = ModerationRequest{} }
+func (m *ModerationRequest) String() string { return proto.CompactTextString(m) }
+func (*ModerationRequest) ProtoMessage()    {}
+func (*ModerationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{10}
 }
-func (m *OrderListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderListResponse.Marshal(b, m, deterministic)
+
+func (m *ModerationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModerationRequest.Unmarshal(m, b)
 }
-func (m *OrderListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderListResponse.Merge(m, src)
+func (m *ModerationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModerationRequest.Marshal(b, m, deterministic)
 }
-func (m *OrderListResponse) XXX_Size() int {
-	return xxx_messageInfo_OrderListResponse.Size(m)
+func (m *ModerationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModerationRequest.Merge(m, src)
 }
-func (m *OrderListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderListResponse.DiscardUnknown(m)
+func (m *ModerationRequest) XXX_Size() int {
+	return xxx_messageInfo_ModerationRequest.Size(m)
+}
+func (m *ModerationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModerationRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OrderListResponse proto.InternalMessageInfo
+var xxx_messageInfo_ModerationRequest proto.InternalMessageInfo
 
-func (m *OrderListResponse) GetOrders() []*Order {
+func (m *ModerationRequest) GetChannelID() []byte {
 	if m != nil {
-		return m.Orders
+		return m.ChannelID
 	}
 	return nil
 }
 
-type ChannelListResponse struct {
-	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *ModerationRequest) GetAction() ModerationAction {
+	if m != nil {
+		return m.Action
+	}
+	return ModerationAction_REMOVE_ORDER
 }
 
-func (m *ChannelListResponse) Reset()         { *m = ChannelListResponse{} }
-func (m *ChannelListResponse) String() string { return proto.CompactTextString(m) }
-func (*ChannelListResponse) ProtoMessage()    {}
-func (*ChannelListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a9abbf861cc1c96d, []int{10}
+func (m *ModerationRequest) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
+	}
+	return nil
 }
 
-func (m *ChannelListResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelListResponse.Unmarshal(m, b)
-}
-func (m *ChannelListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelListResponse.Marshal(b, m, deterministic)
-}
-func (m *ChannelListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelListResponse.Merge(m, src)
-}
-func (m *ChannelListResponse) XXX_Size() int {
-	return xxx_messageInfo_ChannelListResponse.Size(m)
-}
-func (m *ChannelListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelListResponse.DiscardUnknown(m)
+func (m *ModerationRequest) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
 }
 
-var xxx_messageInfo_ChannelListResponse proto.InternalMessageInfo
-
-func (m *ChannelListResponse) GetChannels() []*Channel {
+func (m *ModerationRequest) GetRules() *ChannelRules {
 	if m != nil {
-		return m.Channels
+		return m.Rules
 	}
 	return nil
 }
 
-type JoinResponse struct {
-	JoinedChannel        *Channel `protobuf:"bytes,1,opt,name=joinedChannel,proto3" json:"joinedChannel,omitempty"`
-	Error                *Error   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type NegotiationMessage struct {
+	Type                 NegotiationType `protobuf:"varint,1,opt,name=type,proto3,enum=pb.NegotiationType" json:"type,omitempty"`
+	OrderID              []byte          `protobuf:"bytes,2,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	ChannelID            []byte          `protobuf:"bytes,3,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Amount               uint64          `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason               string          `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Order                *Order          `protobuf:"bytes,6,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *JoinResponse) Reset()         { *m = JoinResponse{} }
-func (m *JoinResponse) String() string { return proto.CompactTextString(m) }
-func (*JoinResponse) ProtoMessage()    {}
-func (*JoinResponse) Descriptor() ([]byte, []int) {
+func (m *NegotiationMessage) Reset()         { *m = NegotiationMessage{} }
+func (m *NegotiationMessage) String() string { return proto.CompactTextString(m) }
+func (*NegotiationMessage) ProtoMessage()    {}
+func (*NegotiationMessage) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a9abbf861cc1c96d, []int{11}
 }
 
-func (m *JoinResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_JoinResponse.Unmarshal(m, b)
+func (m *NegotiationMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NegotiationMessage.Unmarshal(m, b)
 }
-func (m *JoinResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_JoinResponse.Marshal(b, m, deterministic)
+func (m *NegotiationMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NegotiationMessage.Marshal(b, m, deterministic)
 }
-func (m *JoinResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_JoinResponse.Merge(m, src)
+func (m *NegotiationMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NegotiationMessage.Merge(m, src)
 }
-func (m *JoinResponse) XXX_Size() int {
-	return xxx_messageInfo_JoinResponse.Size(m)
+func (m *NegotiationMessage) XXX_Size() int {
+	return xxx_messageInfo_NegotiationMessage.Size(m)
 }
-func (m *JoinResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_JoinResponse.DiscardUnknown(m)
+func (m *NegotiationMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_NegotiationMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_JoinResponse proto.InternalMessageInfo
+var xxx_messageInfo_NegotiationMessage proto.InternalMessageInfo
 
-func (m *JoinResponse) GetJoinedChannel() *Channel {
+func (m *NegotiationMessage) GetType() NegotiationType {
 	if m != nil {
-		return m.JoinedChannel
+		return m.Type
+	}
+	return NegotiationType_TAKE_REQUEST
+}
+
+func (m *NegotiationMessage) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
 	}
 	return nil
 }
 
-func (m *JoinResponse) GetError() *Error {
+func (m *NegotiationMessage) GetChannelID() []byte {
 	if m != nil {
-		return m.Error
+		return m.ChannelID
 	}
 	return nil
 }
 
-type GenericResponse struct {
-	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+func (m *NegotiationMessage) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *NegotiationMessage) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *NegotiationMessage) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+type TakeRequest struct {
+	OrderID              []byte   `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	Amount               uint64   `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GenericResponse) Reset()         { *m = GenericResponse{} }
-func (m *GenericResponse) String() string { return proto.CompactTextString(m) }
-func (*GenericResponse) ProtoMessage()    {}
-func (*GenericResponse) Descriptor() ([]byte, []int) {
+func (m *TakeRequest) Reset()         { *m = TakeRequest{} }
+func (m *TakeRequest) String() string { return proto.CompactTextString(m) }
+func (*TakeRequest) ProtoMessage()    {}
+func (*TakeRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a9abbf861cc1c96d, []int{12}
 }
 
-func (m *GenericResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GenericResponse.Unmarshal(m, b)
+func (m *TakeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TakeRequest.Unmarshal(m, b)
 }
-func (m *GenericResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GenericResponse.Marshal(b, m, deterministic)
+func (m *TakeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TakeRequest.Marshal(b, m, deterministic)
 }
-func (m *GenericResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GenericResponse.Merge(m, src)
+func (m *TakeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TakeRequest.Merge(m, src)
 }
-func (m *GenericResponse) XXX_Size() int {
-	return xxx_messageInfo_GenericResponse.Size(m)
+func (m *TakeRequest) XXX_Size() int {
+	return xxx_messageInfo_TakeRequest.Size(m)
 }
-func (m *GenericResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GenericResponse.DiscardUnknown(m)
+func (m *TakeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TakeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GenericResponse proto.InternalMessageInfo
+var xxx_messageInfo_TakeRequest proto.InternalMessageInfo
 
-func (m *GenericResponse) GetError() *Error {
+func (m *TakeRequest) GetOrderID() []byte {
 	if m != nil {
-		return m.Error
+		return m.OrderID
 	}
 	return nil
 }
 
-type Error struct {
-	Code                 string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+func (m *TakeRequest) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type TakeResponse struct {
+	Accepted             bool     `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	LockedOrder          *Order   `protobuf:"bytes,3,opt,name=lockedOrder,proto3" json:"lockedOrder,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Error) Reset()         { *m = Error{} }
-func (m *Error) String() string { return proto.CompactTextString(m) }
-func (*Error) ProtoMessage()    {}
-func (*Error) Descriptor() ([]byte, []int) {
+func (m *TakeResponse) Reset()         { *m = TakeResponse{} }
+func (m *TakeResponse) String() string { return proto.CompactTextString(m) }
+func (*TakeResponse) ProtoMessage()    {}
+func (*TakeResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a9abbf861cc1c96d, []int{13}
 }
 
-func (m *Error) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Error.Unmarshal(m, b)
+func (m *TakeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TakeResponse.Unmarshal(m, b)
 }
-func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+func (m *TakeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TakeResponse.Marshal(b, m, deterministic)
 }
-func (m *Error) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Error.Merge(m, src)
+func (m *TakeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TakeResponse.Merge(m, src)
 }
-func (m *Error) XXX_Size() int {
-	return xxx_messageInfo_Error.Size(m)
+func (m *TakeResponse) XXX_Size() int {
+	return xxx_messageInfo_TakeResponse.Size(m)
 }
-func (m *Error) XXX_DiscardUnknown() {
-	xxx_messageInfo_Error.DiscardUnknown(m)
+func (m *TakeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TakeResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Error proto.InternalMessageInfo
+var xxx_messageInfo_TakeResponse proto.InternalMessageInfo
 
-func (m *Error) GetCode() string {
+func (m *TakeResponse) GetAccepted() bool {
 	if m != nil {
-		return m.Code
+		return m.Accepted
 	}
-	return ""
+	return false
 }
 
-func (m *Error) GetMessage() string {
+func (m *TakeResponse) GetReason() string {
 	if m != nil {
-		return m.Message
+		return m.Reason
 	}
 	return ""
 }
 
-type Empty struct {
+func (m *TakeResponse) GetLockedOrder() *Order {
+	if m != nil {
+		return m.LockedOrder
+	}
+	return nil
+}
+
+type OrderSpecificRequest struct {
+	OrderID              []byte   `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	ChannelID            []byte   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Empty) Reset()         { *m = Empty{} }
-func (m *Empty) String() string { return proto.CompactTextString(m) }
-func (*Empty) ProtoMessage()    {}
-func (*Empty) Descriptor() ([]byte, []int) {
+func (m *OrderSpecificRequest) Reset()         { *m = OrderSpecificRequest{} }
+func (m *OrderSpecificRequest) String() string { return proto.CompactTextString(m) }
+func (*OrderSpecificRequest) ProtoMessage()    {}
+func (*OrderSpecificRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a9abbf861cc1c96d, []int{14}
 }
 
-func (m *Empty) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Empty.Unmarshal(m, b)
+func (m *OrderSpecificRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderSpecificRequest.Unmarshal(m, b)
 }
-func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+func (m *OrderSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderSpecificRequest.Marshal(b, m, deterministic)
 }
-func (m *Empty) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Empty.Merge(m, src)
+func (m *OrderSpecificRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderSpecificRequest.Merge(m, src)
 }
-func (m *Empty) XXX_Size() int {
-	return xxx_messageInfo_Empty.Size(m)
+func (m *OrderSpecificRequest) XXX_Size() int {
+	return xxx_messageInfo_OrderSpecificRequest.Size(m)
 }
-func (m *Empty) XXX_DiscardUnknown() {
-	xxx_messageInfo_Empty.DiscardUnknown(m)
+func (m *OrderSpecificRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderSpecificRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Empty proto.InternalMessageInfo
+var xxx_messageInfo_OrderSpecificRequest proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("pb.State", State_name, State_value)
-	proto.RegisterEnum("pb.Operation", Operation_name, Operation_value)
-	proto.RegisterType((*Order)(nil), "pb.Order")
-	proto.RegisterType((*Channel)(nil), "pb.Channel")
-	proto.RegisterType((*WireMessage)(nil), "pb.WireMessage")
-	proto.RegisterType((*CreateRequest)(nil), "pb.CreateRequest")
-	proto.RegisterType((*JoinRequest)(nil), "pb.JoinRequest")
-	proto.RegisterType((*ChannelOptions)(nil), "pb.ChannelOptions")
-	proto.RegisterType((*OrderSpecificRequest)(nil), "pb.OrderSpecificRequest")
-	proto.RegisterType((*ChannelSpecificRequest)(nil), "pb.ChannelSpecificRequest")
-	proto.RegisterType((*CreateResponse)(nil), "pb.CreateResponse")
-	proto.RegisterType((*OrderListResponse)(nil), "pb.OrderListResponse")
-	proto.RegisterType((*ChannelListResponse)(nil), "pb.ChannelListResponse")
-	proto.RegisterType((*JoinResponse)(nil), "pb.JoinResponse")
-	proto.RegisterType((*GenericResponse)(nil), "pb.GenericResponse")
-	proto.RegisterType((*Error)(nil), "pb.Error")
-	proto.RegisterType((*Empty)(nil), "pb.Empty")
+func (m *OrderSpecificRequest) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
+	}
+	return nil
+}
+
+func (m *OrderSpecificRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+type PeerSpecificRequest struct {
+	PeerID               string   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerSpecificRequest) Reset()         { *m = PeerSpecificRequest{} }
+func (m *PeerSpecificRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerSpecificRequest) ProtoMessage()    {}
+func (*PeerSpecificRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{15}
+}
+
+func (m *PeerSpecificRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerSpecificRequest.Unmarshal(m, b)
+}
+func (m *PeerSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerSpecificRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerSpecificRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerSpecificRequest.Merge(m, src)
+}
+func (m *PeerSpecificRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerSpecificRequest.Size(m)
+}
+func (m *PeerSpecificRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerSpecificRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerSpecificRequest proto.InternalMessageInfo
+
+func (m *PeerSpecificRequest) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
+}
+
+type ChannelSpecificRequest struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelSpecificRequest) Reset()         { *m = ChannelSpecificRequest{} }
+func (m *ChannelSpecificRequest) String() string { return proto.CompactTextString(m) }
+func (*ChannelSpecificRequest) ProtoMessage()    {}
+func (*ChannelSpecificRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{16}
+}
+
+func (m *ChannelSpecificRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelSpecificRequest.Unmarshal(m, b)
+}
+func (m *ChannelSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelSpecificRequest.Marshal(b, m, deterministic)
+}
+func (m *ChannelSpecificRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelSpecificRequest.Merge(m, src)
+}
+func (m *ChannelSpecificRequest) XXX_Size() int {
+	return xxx_messageInfo_ChannelSpecificRequest.Size(m)
+}
+func (m *ChannelSpecificRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelSpecificRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelSpecificRequest proto.InternalMessageInfo
+
+func (m *ChannelSpecificRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type CreateResponse struct {
+	CreatedOrder         *Order   `protobuf:"bytes,1,opt,name=createdOrder,proto3" json:"createdOrder,omitempty"`
+	Error                *Error   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
+func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateResponse) ProtoMessage()    {}
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{17}
+}
+
+func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
+}
+func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateResponse.Merge(m, src)
+}
+func (m *CreateResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateResponse.Size(m)
+}
+func (m *CreateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
+
+func (m *CreateResponse) GetCreatedOrder() *Order {
+	if m != nil {
+		return m.CreatedOrder
+	}
+	return nil
+}
+
+func (m *CreateResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type OrderListResponse struct {
+	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderListResponse) Reset()         { *m = OrderListResponse{} }
+func (m *OrderListResponse) String() string { return proto.CompactTextString(m) }
+func (*OrderListResponse) ProtoMessage()    {}
+func (*OrderListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{18}
+}
+
+func (m *OrderListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderListResponse.Unmarshal(m, b)
+}
+func (m *OrderListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderListResponse.Marshal(b, m, deterministic)
+}
+func (m *OrderListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderListResponse.Merge(m, src)
+}
+func (m *OrderListResponse) XXX_Size() int {
+	return xxx_messageInfo_OrderListResponse.Size(m)
+}
+func (m *OrderListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderListResponse proto.InternalMessageInfo
+
+func (m *OrderListResponse) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type OrderQuery struct {
+	ChannelID            []byte               `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Asset                string               `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	CounterAsset         string               `protobuf:"bytes,3,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
+	States               []State              `protobuf:"varint,4,rep,packed,name=states,proto3,enum=pb.State" json:"states,omitempty"`
+	Creator              string               `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`
+	CreatedSince         *timestamp.Timestamp `protobuf:"bytes,6,opt,name=createdSince,proto3" json:"createdSince,omitempty"`
+	CreatedBefore        *timestamp.Timestamp `protobuf:"bytes,7,opt,name=createdBefore,proto3" json:"createdBefore,omitempty"`
+	MinPrice             float32              `protobuf:"fixed32,8,opt,name=minPrice,proto3" json:"minPrice,omitempty"`
+	MaxPrice             float32              `protobuf:"fixed32,9,opt,name=maxPrice,proto3" json:"maxPrice,omitempty"`
+	Sort                 OrderSort            `protobuf:"varint,10,opt,name=sort,proto3,enum=pb.OrderSort" json:"sort,omitempty"`
+	Descending           bool                 `protobuf:"varint,11,opt,name=descending,proto3" json:"descending,omitempty"`
+	Limit                uint32               `protobuf:"varint,12,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *OrderQuery) Reset()         { *m = OrderQuery{} }
+func (m *OrderQuery) String() string { return proto.CompactTextString(m) }
+func (*OrderQuery) ProtoMessage()    {}
+func (*OrderQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{19}
+}
+
+func (m *OrderQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderQuery.Unmarshal(m, b)
+}
+func (m *OrderQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderQuery.Marshal(b, m, deterministic)
+}
+func (m *OrderQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderQuery.Merge(m, src)
+}
+func (m *OrderQuery) XXX_Size() int {
+	return xxx_messageInfo_OrderQuery.Size(m)
+}
+func (m *OrderQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderQuery proto.InternalMessageInfo
+
+func (m *OrderQuery) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *OrderQuery) GetAsset() string {
+	if m != nil {
+		return m.Asset
+	}
+	return ""
+}
+
+func (m *OrderQuery) GetCounterAsset() string {
+	if m != nil {
+		return m.CounterAsset
+	}
+	return ""
+}
+
+func (m *OrderQuery) GetStates() []State {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+func (m *OrderQuery) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *OrderQuery) GetCreatedSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedSince
+	}
+	return nil
+}
+
+func (m *OrderQuery) GetCreatedBefore() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedBefore
+	}
+	return nil
+}
+
+func (m *OrderQuery) GetMinPrice() float32 {
+	if m != nil {
+		return m.MinPrice
+	}
+	return 0
+}
+
+func (m *OrderQuery) GetMaxPrice() float32 {
+	if m != nil {
+		return m.MaxPrice
+	}
+	return 0
+}
+
+func (m *OrderQuery) GetSort() OrderSort {
+	if m != nil {
+		return m.Sort
+	}
+	return OrderSort_UNSORTED
+}
+
+func (m *OrderQuery) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
+func (m *OrderQuery) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ChannelListResponse struct {
+	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ChannelListResponse) Reset()         { *m = ChannelListResponse{} }
+func (m *ChannelListResponse) String() string { return proto.CompactTextString(m) }
+func (*ChannelListResponse) ProtoMessage()    {}
+func (*ChannelListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{20}
+}
+
+func (m *ChannelListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelListResponse.Unmarshal(m, b)
+}
+func (m *ChannelListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelListResponse.Marshal(b, m, deterministic)
+}
+func (m *ChannelListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelListResponse.Merge(m, src)
+}
+func (m *ChannelListResponse) XXX_Size() int {
+	return xxx_messageInfo_ChannelListResponse.Size(m)
+}
+func (m *ChannelListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelListResponse proto.InternalMessageInfo
+
+func (m *ChannelListResponse) GetChannels() []*Channel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type PeerListResponse struct {
+	Peers                []string `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerListResponse) Reset()         { *m = PeerListResponse{} }
+func (m *PeerListResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerListResponse) ProtoMessage()    {}
+func (*PeerListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{21}
+}
+
+func (m *PeerListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerListResponse.Unmarshal(m, b)
+}
+func (m *PeerListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerListResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerListResponse.Merge(m, src)
+}
+func (m *PeerListResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerListResponse.Size(m)
+}
+func (m *PeerListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerListResponse proto.InternalMessageInfo
+
+func (m *PeerListResponse) GetPeers() []string {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type KnownPeer struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Addrs                []string             `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	LastSeen             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	Successes            uint32               `protobuf:"varint,4,opt,name=successes,proto3" json:"successes,omitempty"`
+	Failures             uint32               `protobuf:"varint,5,opt,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *KnownPeer) Reset()         { *m = KnownPeer{} }
+func (m *KnownPeer) String() string { return proto.CompactTextString(m) }
+func (*KnownPeer) ProtoMessage()    {}
+func (*KnownPeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{22}
+}
+
+func (m *KnownPeer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KnownPeer.Unmarshal(m, b)
+}
+func (m *KnownPeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KnownPeer.Marshal(b, m, deterministic)
+}
+func (m *KnownPeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KnownPeer.Merge(m, src)
+}
+func (m *KnownPeer) XXX_Size() int {
+	return xxx_messageInfo_KnownPeer.Size(m)
+}
+func (m *KnownPeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_KnownPeer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KnownPeer proto.InternalMessageInfo
+
+func (m *KnownPeer) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *KnownPeer) GetAddrs() []string {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+func (m *KnownPeer) GetLastSeen() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeen
+	}
+	return nil
+}
+
+func (m *KnownPeer) GetSuccesses() uint32 {
+	if m != nil {
+		return m.Successes
+	}
+	return 0
+}
+
+func (m *KnownPeer) GetFailures() uint32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+type ChannelAnnouncement struct {
+	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ChannelAnnouncement) Reset()         { *m = ChannelAnnouncement{} }
+func (m *ChannelAnnouncement) String() string { return proto.CompactTextString(m) }
+func (*ChannelAnnouncement) ProtoMessage()    {}
+func (*ChannelAnnouncement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{23}
+}
+
+func (m *ChannelAnnouncement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelAnnouncement.Unmarshal(m, b)
+}
+func (m *ChannelAnnouncement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelAnnouncement.Marshal(b, m, deterministic)
+}
+func (m *ChannelAnnouncement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelAnnouncement.Merge(m, src)
+}
+func (m *ChannelAnnouncement) XXX_Size() int {
+	return xxx_messageInfo_ChannelAnnouncement.Size(m)
+}
+func (m *ChannelAnnouncement) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelAnnouncement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelAnnouncement proto.InternalMessageInfo
+
+func (m *ChannelAnnouncement) GetChannels() []*Channel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type DirectoryEntry struct {
+	Channel              *Channel             `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Peers                uint32               `protobuf:"varint,2,opt,name=peers,proto3" json:"peers,omitempty"`
+	LastSeen             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DirectoryEntry) Reset()         { *m = DirectoryEntry{} }
+func (m *DirectoryEntry) String() string { return proto.CompactTextString(m) }
+func (*DirectoryEntry) ProtoMessage()    {}
+func (*DirectoryEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{24}
+}
+
+func (m *DirectoryEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DirectoryEntry.Unmarshal(m, b)
+}
+func (m *DirectoryEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DirectoryEntry.Marshal(b, m, deterministic)
+}
+func (m *DirectoryEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectoryEntry.Merge(m, src)
+}
+func (m *DirectoryEntry) XXX_Size() int {
+	return xxx_messageInfo_DirectoryEntry.Size(m)
+}
+func (m *DirectoryEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectoryEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DirectoryEntry proto.InternalMessageInfo
+
+func (m *DirectoryEntry) GetChannel() *Channel {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *DirectoryEntry) GetPeers() uint32 {
+	if m != nil {
+		return m.Peers
+	}
+	return 0
+}
+
+func (m *DirectoryEntry) GetLastSeen() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeen
+	}
+	return nil
+}
+
+type ChannelDirectoryResponse struct {
+	Channels             []*DirectoryEntry `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ChannelDirectoryResponse) Reset()         { *m = ChannelDirectoryResponse{} }
+func (m *ChannelDirectoryResponse) String() string { return proto.CompactTextString(m) }
+func (*ChannelDirectoryResponse) ProtoMessage()    {}
+func (*ChannelDirectoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{25}
+}
+
+func (m *ChannelDirectoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelDirectoryResponse.Unmarshal(m, b)
+}
+func (m *ChannelDirectoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelDirectoryResponse.Marshal(b, m, deterministic)
+}
+func (m *ChannelDirectoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelDirectoryResponse.Merge(m, src)
+}
+func (m *ChannelDirectoryResponse) XXX_Size() int {
+	return xxx_messageInfo_ChannelDirectoryResponse.Size(m)
+}
+func (m *ChannelDirectoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelDirectoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelDirectoryResponse proto.InternalMessageInfo
+
+func (m *ChannelDirectoryResponse) GetChannels() []*DirectoryEntry {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type NodeInfo struct {
+	PeerID               string        `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	PublicKey            []byte        `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	ListenAddrs          []string      `protobuf:"bytes,3,rep,name=listenAddrs,proto3" json:"listenAddrs,omitempty"`
+	ObservedAddrs        []string      `protobuf:"bytes,4,rep,name=observedAddrs,proto3" json:"observedAddrs,omitempty"`
+	WireVersion          uint32        `protobuf:"varint,5,opt,name=wireVersion,proto3" json:"wireVersion,omitempty"`
+	Capabilities         *Capabilities `protobuf:"bytes,6,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
+func (m *NodeInfo) String() string { return proto.CompactTextString(m) }
+func (*NodeInfo) ProtoMessage()    {}
+func (*NodeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{26}
+}
+
+func (m *NodeInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeInfo.Unmarshal(m, b)
+}
+func (m *NodeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeInfo.Marshal(b, m, deterministic)
+}
+func (m *NodeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeInfo.Merge(m, src)
+}
+func (m *NodeInfo) XXX_Size() int {
+	return xxx_messageInfo_NodeInfo.Size(m)
+}
+func (m *NodeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeInfo proto.InternalMessageInfo
+
+func (m *NodeInfo) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
+}
+
+func (m *NodeInfo) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetListenAddrs() []string {
+	if m != nil {
+		return m.ListenAddrs
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetObservedAddrs() []string {
+	if m != nil {
+		return m.ObservedAddrs
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetWireVersion() uint32 {
+	if m != nil {
+		return m.WireVersion
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetCapabilities() *Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type PeerInfo struct {
+	PeerID               string             `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Addrs                []string           `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	Latency              *duration.Duration `protobuf:"bytes,3,opt,name=latency,proto3" json:"latency,omitempty"`
+	Protocols            []string           `protobuf:"bytes,4,rep,name=protocols,proto3" json:"protocols,omitempty"`
+	Direction            Direction          `protobuf:"varint,5,opt,name=direction,proto3,enum=pb.Direction" json:"direction,omitempty"`
+	AgentVersion         string             `protobuf:"bytes,6,opt,name=agentVersion,proto3" json:"agentVersion,omitempty"`
+	Capabilities         *Capabilities      `protobuf:"bytes,7,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
+func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
+func (*PeerInfo) ProtoMessage()    {}
+func (*PeerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{27}
+}
+
+func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerInfo.Unmarshal(m, b)
+}
+func (m *PeerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerInfo.Marshal(b, m, deterministic)
+}
+func (m *PeerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerInfo.Merge(m, src)
+}
+func (m *PeerInfo) XXX_Size() int {
+	return xxx_messageInfo_PeerInfo.Size(m)
+}
+func (m *PeerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerInfo proto.InternalMessageInfo
+
+func (m *PeerInfo) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
+}
+
+func (m *PeerInfo) GetAddrs() []string {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+func (m *PeerInfo) GetLatency() *duration.Duration {
+	if m != nil {
+		return m.Latency
+	}
+	return nil
+}
+
+func (m *PeerInfo) GetProtocols() []string {
+	if m != nil {
+		return m.Protocols
+	}
+	return nil
+}
+
+func (m *PeerInfo) GetDirection() Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return Direction_DIRECTION_UNKNOWN
+}
+
+func (m *PeerInfo) GetAgentVersion() string {
+	if m != nil {
+		return m.AgentVersion
+	}
+	return ""
+}
+
+func (m *PeerInfo) GetCapabilities() *Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type PeerInfoListResponse struct {
+	Peers                []*PeerInfo `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PeerInfoListResponse) Reset()         { *m = PeerInfoListResponse{} }
+func (m *PeerInfoListResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerInfoListResponse) ProtoMessage()    {}
+func (*PeerInfoListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{28}
+}
+
+func (m *PeerInfoListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerInfoListResponse.Unmarshal(m, b)
+}
+func (m *PeerInfoListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerInfoListResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerInfoListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerInfoListResponse.Merge(m, src)
+}
+func (m *PeerInfoListResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerInfoListResponse.Size(m)
+}
+func (m *PeerInfoListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerInfoListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerInfoListResponse proto.InternalMessageInfo
+
+func (m *PeerInfoListResponse) GetPeers() []*PeerInfo {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type ConnectPeerRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectPeerRequest) Reset()         { *m = ConnectPeerRequest{} }
+func (m *ConnectPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnectPeerRequest) ProtoMessage()    {}
+func (*ConnectPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{29}
+}
+
+func (m *ConnectPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectPeerRequest.Unmarshal(m, b)
+}
+func (m *ConnectPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *ConnectPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectPeerRequest.Merge(m, src)
+}
+func (m *ConnectPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_ConnectPeerRequest.Size(m)
+}
+func (m *ConnectPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectPeerRequest proto.InternalMessageInfo
+
+func (m *ConnectPeerRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type Subscription struct {
+	Channel              *Channel             `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Since                *timestamp.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Stats                *ChannelStats        `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Subscription) Reset()         { *m = Subscription{} }
+func (m *Subscription) String() string { return proto.CompactTextString(m) }
+func (*Subscription) ProtoMessage()    {}
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{30}
+}
+
+func (m *Subscription) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Subscription.Unmarshal(m, b)
+}
+func (m *Subscription) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Subscription.Marshal(b, m, deterministic)
+}
+func (m *Subscription) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Subscription.Merge(m, src)
+}
+func (m *Subscription) XXX_Size() int {
+	return xxx_messageInfo_Subscription.Size(m)
+}
+func (m *Subscription) XXX_DiscardUnknown() {
+	xxx_messageInfo_Subscription.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Subscription proto.InternalMessageInfo
+
+func (m *Subscription) GetChannel() *Channel {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *Subscription) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *Subscription) GetStats() *ChannelStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type SubscriptionListResponse struct {
+	Subscriptions        []*Subscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SubscriptionListResponse) Reset()         { *m = SubscriptionListResponse{} }
+func (m *SubscriptionListResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscriptionListResponse) ProtoMessage()    {}
+func (*SubscriptionListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{31}
+}
+
+func (m *SubscriptionListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscriptionListResponse.Unmarshal(m, b)
+}
+func (m *SubscriptionListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscriptionListResponse.Marshal(b, m, deterministic)
+}
+func (m *SubscriptionListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriptionListResponse.Merge(m, src)
+}
+func (m *SubscriptionListResponse) XXX_Size() int {
+	return xxx_messageInfo_SubscriptionListResponse.Size(m)
+}
+func (m *SubscriptionListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriptionListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscriptionListResponse proto.InternalMessageInfo
+
+func (m *SubscriptionListResponse) GetSubscriptions() []*Subscription {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+type NATStatus struct {
+	Reachability         Reachability         `protobuf:"varint,1,opt,name=reachability,proto3,enum=pb.Reachability" json:"reachability,omitempty"`
+	PublicAddr           string               `protobuf:"bytes,2,opt,name=publicAddr,proto3" json:"publicAddr,omitempty"`
+	RelayAddrs           []string             `protobuf:"bytes,3,rep,name=relayAddrs,proto3" json:"relayAddrs,omitempty"`
+	ObservedAddrs        []string             `protobuf:"bytes,4,rep,name=observedAddrs,proto3" json:"observedAddrs,omitempty"`
+	LastChange           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastChange,proto3" json:"lastChange,omitempty"`
+	AutoNAT              bool                 `protobuf:"varint,6,opt,name=autoNAT,proto3" json:"autoNAT,omitempty"`
+	AutoNATService       bool                 `protobuf:"varint,7,opt,name=autoNATService,proto3" json:"autoNATService,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *NATStatus) Reset()         { *m = NATStatus{} }
+func (m *NATStatus) String() string { return proto.CompactTextString(m) }
+func (*NATStatus) ProtoMessage()    {}
+func (*NATStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{32}
+}
+
+func (m *NATStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NATStatus.Unmarshal(m, b)
+}
+func (m *NATStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NATStatus.Marshal(b, m, deterministic)
+}
+func (m *NATStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NATStatus.Merge(m, src)
+}
+func (m *NATStatus) XXX_Size() int {
+	return xxx_messageInfo_NATStatus.Size(m)
+}
+func (m *NATStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_NATStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NATStatus proto.InternalMessageInfo
+
+func (m *NATStatus) GetReachability() Reachability {
+	if m != nil {
+		return m.Reachability
+	}
+	return Reachability_REACHABILITY_UNKNOWN
+}
+
+func (m *NATStatus) GetPublicAddr() string {
+	if m != nil {
+		return m.PublicAddr
+	}
+	return ""
+}
+
+func (m *NATStatus) GetRelayAddrs() []string {
+	if m != nil {
+		return m.RelayAddrs
+	}
+	return nil
+}
+
+func (m *NATStatus) GetObservedAddrs() []string {
+	if m != nil {
+		return m.ObservedAddrs
+	}
+	return nil
+}
+
+func (m *NATStatus) GetLastChange() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastChange
+	}
+	return nil
+}
+
+func (m *NATStatus) GetAutoNAT() bool {
+	if m != nil {
+		return m.AutoNAT
+	}
+	return false
+}
+
+func (m *NATStatus) GetAutoNATService() bool {
+	if m != nil {
+		return m.AutoNATService
+	}
+	return false
+}
+
+type ConnectionStats struct {
+	Connections          uint32   `protobuf:"varint,1,opt,name=connections,proto3" json:"connections,omitempty"`
+	Peers                uint32   `protobuf:"varint,2,opt,name=peers,proto3" json:"peers,omitempty"`
+	Inbound              uint32   `protobuf:"varint,3,opt,name=inbound,proto3" json:"inbound,omitempty"`
+	Outbound             uint32   `protobuf:"varint,4,opt,name=outbound,proto3" json:"outbound,omitempty"`
+	ProtectedPeers       uint32   `protobuf:"varint,5,opt,name=protectedPeers,proto3" json:"protectedPeers,omitempty"`
+	LowWater             uint32   `protobuf:"varint,6,opt,name=lowWater,proto3" json:"lowWater,omitempty"`
+	HighWater            uint32   `protobuf:"varint,7,opt,name=highWater,proto3" json:"highWater,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectionStats) Reset()         { *m = ConnectionStats{} }
+func (m *ConnectionStats) String() string { return proto.CompactTextString(m) }
+func (*ConnectionStats) ProtoMessage()    {}
+func (*ConnectionStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{33}
+}
+
+func (m *ConnectionStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectionStats.Unmarshal(m, b)
+}
+func (m *ConnectionStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectionStats.Marshal(b, m, deterministic)
+}
+func (m *ConnectionStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionStats.Merge(m, src)
+}
+func (m *ConnectionStats) XXX_Size() int {
+	return xxx_messageInfo_ConnectionStats.Size(m)
+}
+func (m *ConnectionStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectionStats proto.InternalMessageInfo
+
+func (m *ConnectionStats) GetConnections() uint32 {
+	if m != nil {
+		return m.Connections
+	}
+	return 0
+}
+
+func (m *ConnectionStats) GetPeers() uint32 {
+	if m != nil {
+		return m.Peers
+	}
+	return 0
+}
+
+func (m *ConnectionStats) GetInbound() uint32 {
+	if m != nil {
+		return m.Inbound
+	}
+	return 0
+}
+
+func (m *ConnectionStats) GetOutbound() uint32 {
+	if m != nil {
+		return m.Outbound
+	}
+	return 0
+}
+
+func (m *ConnectionStats) GetProtectedPeers() uint32 {
+	if m != nil {
+		return m.ProtectedPeers
+	}
+	return 0
+}
+
+func (m *ConnectionStats) GetLowWater() uint32 {
+	if m != nil {
+		return m.LowWater
+	}
+	return 0
+}
+
+func (m *ConnectionStats) GetHighWater() uint32 {
+	if m != nil {
+		return m.HighWater
+	}
+	return 0
+}
+
+type ChannelStats struct {
+	OpenOrders           uint64               `protobuf:"varint,1,opt,name=openOrders,proto3" json:"openOrders,omitempty"`
+	OrdersReceived       uint64               `protobuf:"varint,2,opt,name=ordersReceived,proto3" json:"ordersReceived,omitempty"`
+	OrdersSent           uint64               `protobuf:"varint,3,opt,name=ordersSent,proto3" json:"ordersSent,omitempty"`
+	MessagesRejected     uint64               `protobuf:"varint,4,opt,name=messagesRejected,proto3" json:"messagesRejected,omitempty"`
+	LastMessage          *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastMessage,proto3" json:"lastMessage,omitempty"`
+	TopicPeers           uint32               `protobuf:"varint,6,opt,name=topicPeers,proto3" json:"topicPeers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ChannelStats) Reset()         { *m = ChannelStats{} }
+func (m *ChannelStats) String() string { return proto.CompactTextString(m) }
+func (*ChannelStats) ProtoMessage()    {}
+func (*ChannelStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{34}
+}
+
+func (m *ChannelStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelStats.Unmarshal(m, b)
+}
+func (m *ChannelStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelStats.Marshal(b, m, deterministic)
+}
+func (m *ChannelStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelStats.Merge(m, src)
+}
+func (m *ChannelStats) XXX_Size() int {
+	return xxx_messageInfo_ChannelStats.Size(m)
+}
+func (m *ChannelStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelStats proto.InternalMessageInfo
+
+func (m *ChannelStats) GetOpenOrders() uint64 {
+	if m != nil {
+		return m.OpenOrders
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetOrdersReceived() uint64 {
+	if m != nil {
+		return m.OrdersReceived
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetOrdersSent() uint64 {
+	if m != nil {
+		return m.OrdersSent
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetMessagesRejected() uint64 {
+	if m != nil {
+		return m.MessagesRejected
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetLastMessage() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastMessage
+	}
+	return nil
+}
+
+func (m *ChannelStats) GetTopicPeers() uint32 {
+	if m != nil {
+		return m.TopicPeers
+	}
+	return 0
+}
+
+type JoinResponse struct {
+	JoinedChannel        *Channel `protobuf:"bytes,1,opt,name=joinedChannel,proto3" json:"joinedChannel,omitempty"`
+	Error                *Error   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinResponse) Reset()         { *m = JoinResponse{} }
+func (m *JoinResponse) String() string { return proto.CompactTextString(m) }
+func (*JoinResponse) ProtoMessage()    {}
+func (*JoinResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{35}
+}
+
+func (m *JoinResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinResponse.Unmarshal(m, b)
+}
+func (m *JoinResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinResponse.Marshal(b, m, deterministic)
+}
+func (m *JoinResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinResponse.Merge(m, src)
+}
+func (m *JoinResponse) XXX_Size() int {
+	return xxx_messageInfo_JoinResponse.Size(m)
+}
+func (m *JoinResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinResponse proto.InternalMessageInfo
+
+func (m *JoinResponse) GetJoinedChannel() *Channel {
+	if m != nil {
+		return m.JoinedChannel
+	}
+	return nil
+}
+
+func (m *JoinResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type GenericResponse struct {
+	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenericResponse) Reset()         { *m = GenericResponse{} }
+func (m *GenericResponse) String() string { return proto.CompactTextString(m) }
+func (*GenericResponse) ProtoMessage()    {}
+func (*GenericResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{36}
+}
+
+func (m *GenericResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenericResponse.Unmarshal(m, b)
+}
+func (m *GenericResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenericResponse.Marshal(b, m, deterministic)
+}
+func (m *GenericResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenericResponse.Merge(m, src)
+}
+func (m *GenericResponse) XXX_Size() int {
+	return xxx_messageInfo_GenericResponse.Size(m)
+}
+func (m *GenericResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenericResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenericResponse proto.InternalMessageInfo
+
+func (m *GenericResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type Error struct {
+	Code                 string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Error) Reset()         { *m = Error{} }
+func (m *Error) String() string { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()    {}
+func (*Error) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{37}
+}
+
+func (m *Error) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Error.Unmarshal(m, b)
+}
+func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+}
+func (m *Error) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Error.Merge(m, src)
+}
+func (m *Error) XXX_Size() int {
+	return xxx_messageInfo_Error.Size(m)
+}
+func (m *Error) XXX_DiscardUnknown() {
+	xxx_messageInfo_Error.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Error proto.InternalMessageInfo
+
+func (m *Error) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *Error) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type Empty struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()    {}
+func (*Empty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9abbf861cc1c96d, []int{38}
+}
+
+func (m *Empty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Empty.Unmarshal(m, b)
+}
+func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+}
+func (m *Empty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Empty.Merge(m, src)
+}
+func (m *Empty) XXX_Size() int {
+	return xxx_messageInfo_Empty.Size(m)
+}
+func (m *Empty) XXX_DiscardUnknown() {
+	xxx_messageInfo_Empty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Empty proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("pb.State", State_name, State_value)
+	proto.RegisterEnum("pb.Operation", Operation_name, Operation_value)
+	proto.RegisterEnum("pb.Compression", Compression_name, Compression_value)
+	proto.RegisterEnum("pb.NegotiationType", NegotiationType_name, NegotiationType_value)
+	proto.RegisterEnum("pb.ModerationAction", ModerationAction_name, ModerationAction_value)
+	proto.RegisterEnum("pb.OrderSort", OrderSort_name, OrderSort_value)
+	proto.RegisterEnum("pb.Direction", Direction_name, Direction_value)
+	proto.RegisterEnum("pb.Reachability", Reachability_name, Reachability_value)
+	proto.RegisterType((*Order)(nil), "pb.Order")
+	proto.RegisterType((*Channel)(nil), "pb.Channel")
+	proto.RegisterType((*WireMessage)(nil), "pb.WireMessage")
+	proto.RegisterType((*WireBatch)(nil), "pb.WireBatch")
+	proto.RegisterType((*Capabilities)(nil), "pb.Capabilities")
+	proto.RegisterType((*CreateRequest)(nil), "pb.CreateRequest")
+	proto.RegisterType((*JoinRequest)(nil), "pb.JoinRequest")
+	proto.RegisterType((*ChannelOptions)(nil), "pb.ChannelOptions")
+	proto.RegisterType((*ChannelRules)(nil), "pb.ChannelRules")
+	proto.RegisterType((*ModerationMessage)(nil), "pb.ModerationMessage")
+	proto.RegisterType((*ModerationRequest)(nil), "pb.ModerationRequest")
+	proto.RegisterType((*NegotiationMessage)(nil), "pb.NegotiationMessage")
+	proto.RegisterType((*TakeRequest)(nil), "pb.TakeRequest")
+	proto.RegisterType((*TakeResponse)(nil), "pb.TakeResponse")
+	proto.RegisterType((*OrderSpecificRequest)(nil), "pb.OrderSpecificRequest")
+	proto.RegisterType((*PeerSpecificRequest)(nil), "pb.PeerSpecificRequest")
+	proto.RegisterType((*ChannelSpecificRequest)(nil), "pb.ChannelSpecificRequest")
+	proto.RegisterType((*CreateResponse)(nil), "pb.CreateResponse")
+	proto.RegisterType((*OrderListResponse)(nil), "pb.OrderListResponse")
+	proto.RegisterType((*OrderQuery)(nil), "pb.OrderQuery")
+	proto.RegisterType((*ChannelListResponse)(nil), "pb.ChannelListResponse")
+	proto.RegisterType((*PeerListResponse)(nil), "pb.PeerListResponse")
+	proto.RegisterType((*KnownPeer)(nil), "pb.KnownPeer")
+	proto.RegisterType((*ChannelAnnouncement)(nil), "pb.ChannelAnnouncement")
+	proto.RegisterType((*DirectoryEntry)(nil), "pb.DirectoryEntry")
+	proto.RegisterType((*ChannelDirectoryResponse)(nil), "pb.ChannelDirectoryResponse")
+	proto.RegisterType((*NodeInfo)(nil), "pb.NodeInfo")
+	proto.RegisterType((*PeerInfo)(nil), "pb.PeerInfo")
+	proto.RegisterType((*PeerInfoListResponse)(nil), "pb.PeerInfoListResponse")
+	proto.RegisterType((*ConnectPeerRequest)(nil), "pb.ConnectPeerRequest")
+	proto.RegisterType((*Subscription)(nil), "pb.Subscription")
+	proto.RegisterType((*SubscriptionListResponse)(nil), "pb.SubscriptionListResponse")
+	proto.RegisterType((*NATStatus)(nil), "pb.NATStatus")
+	proto.RegisterType((*ConnectionStats)(nil), "pb.ConnectionStats")
+	proto.RegisterType((*ChannelStats)(nil), "pb.ChannelStats")
+	proto.RegisterType((*JoinResponse)(nil), "pb.JoinResponse")
+	proto.RegisterType((*GenericResponse)(nil), "pb.GenericResponse")
+	proto.RegisterType((*Error)(nil), "pb.Error")
+	proto.RegisterType((*Empty)(nil), "pb.Empty")
+}
+
+func init() { proto.RegisterFile("pb/sprawl.proto", fileDescriptor_a9abbf861cc1c96d) }
+
+var fileDescriptor_a9abbf861cc1c96d = []byte{
+	// 2526 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcf, 0x73, 0xdb, 0xc6,
+	0xf5, 0x37, 0xc0, 0xdf, 0x8f, 0xa4, 0x04, 0xaf, 0x65, 0x07, 0x5f, 0x4d, 0xbe, 0x8e, 0x8c, 0x69,
+	0x6d, 0x45, 0x89, 0x65, 0x47, 0x71, 0xec, 0xd6, 0x4d, 0x9d, 0x52, 0x24, 0x22, 0xcb, 0x96, 0x48,
+	0x66, 0x49, 0xc6, 0xe3, 0x93, 0x0a, 0x82, 0x6b, 0x19, 0x31, 0x05, 0xb0, 0x00, 0x28, 0x47, 0xb7,
+	0x5e, 0x7a, 0xe8, 0xa5, 0x9d, 0xe9, 0x4c, 0xff, 0x82, 0x4e, 0x0f, 0x9d, 0xe9, 0x31, 0x97, 0x9e,
+	0x3b, 0xd3, 0xff, 0xa2, 0x33, 0xed, 0x7f, 0xd2, 0x79, 0xbb, 0x0b, 0x70, 0x41, 0x49, 0x94, 0xec,
+	0x99, 0xdc, 0xf0, 0x7e, 0xee, 0xdb, 0xcf, 0x7b, 0xfb, 0xf6, 0x2d, 0x60, 0x79, 0x32, 0xbc, 0x17,
+	0x4d, 0x42, 0xe7, 0xed, 0x78, 0x73, 0x12, 0x06, 0x71, 0x40, 0xf4, 0xc9, 0x70, 0xf5, 0xa3, 0xc3,
+	0x20, 0x38, 0x1c, 0xb3, 0x7b, 0x9c, 0x33, 0x9c, 0xbe, 0xba, 0x17, 0x7b, 0x47, 0x2c, 0x8a, 0x9d,
+	0xa3, 0x89, 0x50, 0x5a, 0xbd, 0x39, 0xaf, 0x30, 0x9a, 0x86, 0x4e, 0xec, 0x05, 0xbe, 0x90, 0x5b,
+	0x7f, 0xd0, 0xa1, 0xd0, 0x09, 0x47, 0x2c, 0x24, 0x4b, 0xa0, 0x7b, 0x23, 0x53, 0x5b, 0xd3, 0xd6,
+	0x6b, 0x54, 0xf7, 0x46, 0xe4, 0x01, 0x94, 0xdc, 0x90, 0x39, 0x31, 0x1b, 0x99, 0xfa, 0x9a, 0xb6,
+	0x5e, 0xdd, 0x5a, 0xdd, 0x14, 0xbe, 0x36, 0x13, 0x5f, 0x9b, 0xfd, 0x64, 0x31, 0x9a, 0xa8, 0x92,
+	0x15, 0x28, 0x38, 0x51, 0xc4, 0x62, 0x33, 0xb7, 0xa6, 0xad, 0x57, 0xa8, 0x20, 0x88, 0x05, 0x35,
+	0x37, 0x98, 0xfa, 0x31, 0x0b, 0x1b, 0x5c, 0x98, 0xe7, 0xc2, 0x0c, 0x8f, 0xdc, 0x80, 0xa2, 0x73,
+	0x84, 0x0c, 0xb3, 0xb0, 0xa6, 0xad, 0xe7, 0xa9, 0xa4, 0xd0, 0xe3, 0x24, 0xf4, 0x5c, 0x66, 0x16,
+	0xd7, 0xb4, 0x75, 0x9d, 0x0a, 0x82, 0x7c, 0x04, 0x85, 0x28, 0x76, 0x62, 0x66, 0x96, 0xd6, 0xb4,
+	0xf5, 0xa5, 0xad, 0xca, 0xe6, 0x64, 0xb8, 0xd9, 0x43, 0x06, 0x15, 0x7c, 0xf2, 0x21, 0x54, 0xdc,
+	0xd7, 0x8e, 0xef, 0xb3, 0xf1, 0x6e, 0xcb, 0x2c, 0xf3, 0x5d, 0xcd, 0x18, 0xc4, 0x94, 0x9b, 0x0b,
+	0x42, 0xb3, 0xc2, 0x63, 0x49, 0x48, 0x6b, 0x07, 0x4a, 0x4d, 0xa1, 0x76, 0x0a, 0x91, 0x4f, 0xa1,
+	0x14, 0x4c, 0x10, 0xbb, 0x48, 0x22, 0x42, 0x70, 0x55, 0xa9, 0xdd, 0x11, 0x12, 0x9a, 0xa8, 0x58,
+	0xff, 0xd0, 0xa0, 0xfa, 0xc2, 0x0b, 0xd9, 0x3e, 0x8b, 0x22, 0xe7, 0x70, 0x2e, 0x20, 0x6d, 0x3e,
+	0xa0, 0x4f, 0xa0, 0x12, 0x4c, 0x98, 0x48, 0x0d, 0xf7, 0xbe, 0xb4, 0x55, 0x47, 0xef, 0x9d, 0x84,
+	0x49, 0x67, 0x72, 0x42, 0x20, 0x3f, 0x72, 0x62, 0x87, 0x63, 0x5c, 0xa3, 0xfc, 0x1b, 0x77, 0x74,
+	0xcc, 0xc2, 0x08, 0xcd, 0x11, 0xdd, 0x3a, 0x4d, 0x48, 0xf2, 0x19, 0x54, 0xdd, 0xe0, 0x68, 0x12,
+	0xb2, 0x88, 0x4b, 0x0b, 0xdc, 0xf9, 0x32, 0x0f, 0x7d, 0xc6, 0xa6, 0xaa, 0x8e, 0x75, 0x07, 0x2a,
+	0x18, 0xfa, 0xb6, 0x13, 0xbb, 0xaf, 0xc9, 0x2a, 0x94, 0x8f, 0xc4, 0x1e, 0x22, 0x53, 0x5b, 0xcb,
+	0xad, 0xd7, 0x68, 0x4a, 0x5b, 0x5f, 0x43, 0xad, 0xe9, 0x4c, 0x9c, 0xa1, 0x37, 0xf6, 0x62, 0x8f,
+	0x45, 0xa8, 0x2b, 0x97, 0x15, 0xba, 0x75, 0x9a, 0xd2, 0x28, 0x7b, 0xc5, 0x9c, 0x78, 0x1a, 0x32,
+	0xc4, 0x2f, 0xb7, 0x5e, 0xa1, 0x29, 0x6d, 0xfd, 0x59, 0x83, 0x7a, 0x93, 0x97, 0x10, 0x65, 0xbf,
+	0x99, 0xb2, 0x28, 0xbe, 0x00, 0xae, 0xb4, 0xcc, 0xf4, 0x45, 0x65, 0x96, 0x5b, 0x58, 0x66, 0xf9,
+	0xb3, 0xcb, 0xac, 0xa0, 0x94, 0x99, 0xf5, 0x83, 0x06, 0xd5, 0x67, 0x81, 0xe7, 0x27, 0x51, 0xa5,
+	0xeb, 0x6a, 0x8b, 0xd6, 0xd5, 0xcf, 0x59, 0x77, 0x74, 0xe4, 0xf9, 0x91, 0x99, 0xe3, 0x18, 0x4a,
+	0x8a, 0xac, 0x41, 0x75, 0x88, 0x30, 0xbf, 0xf0, 0xfc, 0x51, 0xf0, 0x56, 0xe6, 0x4e, 0x65, 0xbd,
+	0x4f, 0xfe, 0xfe, 0xa9, 0xc1, 0x52, 0xb6, 0x2e, 0x11, 0x4f, 0x1e, 0x6c, 0xd7, 0xf1, 0x42, 0x19,
+	0xfd, 0x8c, 0xa1, 0x44, 0xa7, 0x67, 0xa2, 0xbb, 0x0d, 0x85, 0x70, 0x3a, 0x66, 0x11, 0x87, 0xb2,
+	0xba, 0x65, 0x28, 0x05, 0x4f, 0x91, 0x4f, 0x85, 0xf8, 0xc7, 0xd9, 0xc5, 0x33, 0xa8, 0xa9, 0x6b,
+	0xe1, 0x16, 0x8e, 0x3c, 0xbf, 0x21, 0xb2, 0xa7, 0xf1, 0xec, 0xcd, 0x18, 0x5c, 0xea, 0x7c, 0x2f,
+	0xa5, 0xba, 0x94, 0x26, 0x0c, 0xeb, 0xf7, 0x3a, 0x5c, 0xdd, 0x0f, 0x46, 0xf2, 0x04, 0x25, 0x67,
+	0xf2, 0x53, 0x28, 0x3a, 0x2e, 0x3f, 0x72, 0x1a, 0x8f, 0x67, 0x05, 0xe3, 0x99, 0xa9, 0x35, 0xb8,
+	0x8c, 0x4a, 0x1d, 0x3c, 0x62, 0x01, 0xb6, 0xca, 0xdd, 0x16, 0xf7, 0x5f, 0xa3, 0x09, 0x89, 0xf0,
+	0x4d, 0x18, 0x17, 0x88, 0x92, 0x93, 0xd4, 0x0c, 0xbe, 0xfc, 0x62, 0xf8, 0xb0, 0xac, 0x10, 0x70,
+	0x0e, 0x4b, 0x8d, 0x0a, 0x02, 0x77, 0x14, 0x79, 0x87, 0x3e, 0x3f, 0x22, 0xbc, 0xfb, 0xd5, 0xe8,
+	0x8c, 0x91, 0x3d, 0x20, 0xa5, 0xf9, 0x03, 0xb2, 0x0a, 0xe5, 0x08, 0x6b, 0xd6, 0x77, 0x19, 0xef,
+	0x7e, 0x79, 0x9a, 0xd2, 0xd8, 0x99, 0x14, 0x2c, 0x2e, 0x77, 0xe0, 0x66, 0x48, 0xe9, 0xef, 0x86,
+	0x54, 0xee, 0x3c, 0xa4, 0xf2, 0x67, 0x23, 0x55, 0x58, 0x88, 0x94, 0xf5, 0x2f, 0x0d, 0x48, 0x9b,
+	0x1d, 0x06, 0xb1, 0x97, 0x49, 0xe4, 0x1d, 0xc8, 0xc7, 0x27, 0x13, 0x26, 0xd3, 0x78, 0x0d, 0xad,
+	0x15, 0xad, 0xfe, 0xc9, 0x84, 0x51, 0xae, 0xb0, 0x20, 0x87, 0x99, 0xfd, 0xe7, 0xe6, 0xf7, 0x7f,
+	0x5e, 0xdb, 0xb8, 0x01, 0xc5, 0x90, 0x39, 0x91, 0xac, 0xe8, 0x0a, 0x95, 0x14, 0xde, 0x4f, 0xdc,
+	0x31, 0xcf, 0x5b, 0x55, 0xdc, 0x4f, 0xfc, 0x9e, 0xa5, 0x82, 0x6f, 0x7d, 0x05, 0xd5, 0xbe, 0xf3,
+	0x26, 0x6d, 0x77, 0x4a, 0x5c, 0xda, 0x29, 0xc4, 0x1c, 0xb5, 0xa8, 0x25, 0x65, 0x05, 0x50, 0x13,
+	0x0e, 0xa2, 0x49, 0xe0, 0x47, 0x0c, 0x33, 0xee, 0xb8, 0x2e, 0x9b, 0xe0, 0x85, 0x8d, 0x2e, 0xca,
+	0x34, 0xa5, 0x95, 0x28, 0xf5, 0x4c, 0x94, 0x9f, 0x40, 0x75, 0x1c, 0xb8, 0x6f, 0xd8, 0x88, 0x87,
+	0x66, 0xe6, 0xe6, 0x63, 0x55, 0xa5, 0x56, 0x1b, 0x56, 0xf8, 0x47, 0x6f, 0xc2, 0x5c, 0xef, 0x95,
+	0xe7, 0x5e, 0x1c, 0x7a, 0x06, 0x52, 0x7d, 0x0e, 0x52, 0xeb, 0x2e, 0x5c, 0xeb, 0xb2, 0xd3, 0xee,
+	0x66, 0x15, 0xa2, 0xa9, 0x15, 0x62, 0xad, 0xc3, 0x0d, 0x59, 0x10, 0xf3, 0x16, 0x73, 0xf7, 0xb4,
+	0xf5, 0x6b, 0x58, 0x4a, 0xee, 0x12, 0x89, 0xcd, 0x5d, 0xa8, 0xc9, 0x01, 0x45, 0x6c, 0x54, 0x9b,
+	0xdf, 0x68, 0x46, 0x8c, 0xc9, 0x63, 0x61, 0x18, 0x84, 0xa6, 0x3e, 0xd3, 0xb3, 0x91, 0x41, 0x05,
+	0xdf, 0x7a, 0x08, 0x57, 0xb9, 0xe6, 0x9e, 0x17, 0xc5, 0xe9, 0x22, 0xb7, 0xa0, 0xc8, 0x37, 0x2e,
+	0x6e, 0xbe, 0x8c, 0x7b, 0x29, 0xb0, 0x7e, 0xc8, 0x01, 0x70, 0xce, 0x37, 0x53, 0x16, 0x9e, 0xfc,
+	0x68, 0x77, 0xdc, 0x2d, 0x28, 0xf2, 0x21, 0x08, 0xfb, 0x4e, 0x2e, 0x3b, 0x1d, 0x49, 0x81, 0x3a,
+	0x00, 0x15, 0x32, 0x03, 0x10, 0x79, 0x92, 0x62, 0xd5, 0xf3, 0x7c, 0x39, 0x76, 0x2d, 0x1e, 0xfe,
+	0x32, 0xfa, 0xe4, 0x57, 0x50, 0x97, 0xf4, 0x36, 0x7b, 0x15, 0x84, 0x62, 0x42, 0x5b, 0xec, 0x20,
+	0x6b, 0xc0, 0x07, 0x0e, 0xcf, 0xef, 0x86, 0x9e, 0xec, 0x5d, 0x3a, 0x4d, 0x69, 0x2e, 0x73, 0xbe,
+	0x17, 0xb2, 0x8a, 0x94, 0x49, 0x9a, 0xdc, 0x82, 0x7c, 0x14, 0x84, 0xb1, 0x09, 0xca, 0xf8, 0xc4,
+	0x0b, 0x36, 0x08, 0x63, 0xca, 0x45, 0xe4, 0x26, 0xc0, 0x88, 0x45, 0x2e, 0xf3, 0x47, 0x9e, 0x7f,
+	0x68, 0x56, 0xf9, 0x31, 0x51, 0x38, 0x88, 0xf9, 0xd8, 0x3b, 0xf2, 0x62, 0xb3, 0xc6, 0x6f, 0x30,
+	0x41, 0x58, 0x4f, 0xe0, 0x9a, 0x2c, 0xbd, 0x4c, 0xc2, 0xef, 0x40, 0x59, 0x66, 0x2b, 0x49, 0x79,
+	0x55, 0x6d, 0x5b, 0xa9, 0xd0, 0x5a, 0x07, 0x03, 0x2b, 0x3d, 0x63, 0x8c, 0xf3, 0x06, 0x4b, 0x8a,
+	0xa5, 0x42, 0x05, 0x61, 0xfd, 0x45, 0x83, 0xca, 0x73, 0x3f, 0x78, 0xeb, 0xa3, 0xbe, 0x52, 0xd8,
+	0x15, 0x3e, 0x80, 0xf2, 0x6b, 0x62, 0x14, 0x26, 0xe3, 0x93, 0x20, 0xc8, 0x43, 0x28, 0x8f, 0x9d,
+	0x28, 0xee, 0x31, 0xe6, 0x9b, 0xb9, 0x0b, 0xb1, 0x4e, 0x75, 0xf9, 0xf5, 0x32, 0x75, 0x5d, 0x16,
+	0x45, 0xf2, 0x82, 0xaa, 0xd3, 0x19, 0x83, 0x4f, 0x6b, 0x8e, 0x37, 0xe6, 0xd3, 0x5a, 0x81, 0x0b,
+	0x53, 0x5a, 0xc1, 0xa3, 0xe1, 0xfb, 0xc1, 0xd4, 0x77, 0xd9, 0x11, 0xf3, 0xe3, 0xcb, 0xe3, 0xf1,
+	0x3b, 0x0d, 0x96, 0x5a, 0x5e, 0xc8, 0xdc, 0x38, 0x08, 0x4f, 0x6c, 0x3f, 0x0e, 0x4f, 0xc8, 0x4f,
+	0xa1, 0x24, 0xc5, 0xf2, 0x70, 0x66, 0x4c, 0x13, 0xd9, 0x0c, 0x35, 0x5d, 0xe4, 0x87, 0x13, 0xef,
+	0x8b, 0x80, 0xf5, 0x0c, 0x4c, 0xb9, 0x42, 0x1a, 0x4d, 0x9a, 0x9f, 0xcd, 0x53, 0x9b, 0xe1, 0xd3,
+	0x7e, 0x36, 0x6c, 0x65, 0x4f, 0xff, 0xd1, 0xa0, 0xdc, 0x0e, 0x46, 0x6c, 0xd7, 0x7f, 0x15, 0x9c,
+	0xd7, 0xc3, 0x10, 0xf2, 0xc9, 0x74, 0x38, 0xf6, 0xdc, 0xe7, 0xec, 0x24, 0x69, 0x88, 0x29, 0x03,
+	0x87, 0xa8, 0xb1, 0x17, 0xc5, 0xcc, 0x6f, 0xf0, 0x24, 0xe7, 0x78, 0x92, 0x55, 0x16, 0xf9, 0x09,
+	0xd4, 0x83, 0x61, 0xc4, 0xc2, 0x63, 0x36, 0x12, 0x3a, 0x79, 0xae, 0x93, 0x65, 0xa2, 0x9f, 0xb7,
+	0x5e, 0xc8, 0xbe, 0x95, 0xcf, 0x01, 0x91, 0x3d, 0x95, 0x45, 0x1e, 0x40, 0xcd, 0x55, 0xc6, 0x76,
+	0xb3, 0xa8, 0x5c, 0xba, 0x0a, 0x9f, 0x66, 0xb4, 0xac, 0x3f, 0xe9, 0x50, 0xc6, 0xba, 0x5c, 0xb8,
+	0xc5, 0xb3, 0x6b, 0xf4, 0x73, 0x28, 0x8d, 0x9d, 0x98, 0xf9, 0xee, 0x89, 0x4c, 0xd0, 0xff, 0x9d,
+	0x4a, 0x50, 0x4b, 0x3e, 0x4c, 0x69, 0xa2, 0xc9, 0xd1, 0x42, 0xa9, 0x1b, 0x8c, 0x93, 0x9d, 0xce,
+	0x18, 0xf8, 0x62, 0x1a, 0xf1, 0x64, 0xcc, 0xc6, 0xc9, 0xfa, 0x2c, 0x43, 0xfc, 0xc5, 0x94, 0xca,
+	0xb1, 0x6b, 0x3a, 0x87, 0xcc, 0x8f, 0x13, 0x4c, 0x8a, 0xa2, 0x6b, 0xaa, 0xbc, 0x53, 0xa0, 0x94,
+	0x2e, 0x05, 0xca, 0x63, 0x58, 0x49, 0x30, 0xc9, 0x9c, 0x6f, 0x4b, 0x3d, 0xdf, 0xd5, 0xad, 0x1a,
+	0xba, 0x49, 0x14, 0x93, 0xd3, 0xbe, 0x09, 0xa4, 0x19, 0xf8, 0x3e, 0x73, 0x63, 0x94, 0x28, 0xf7,
+	0x29, 0x82, 0xc6, 0xa2, 0x48, 0x42, 0x9b, 0x90, 0xd6, 0x1f, 0x35, 0xa8, 0xf5, 0xa6, 0xc3, 0xc8,
+	0x0d, 0x3d, 0x3e, 0xd5, 0x5f, 0xf6, 0xd4, 0xdc, 0x87, 0x42, 0xc4, 0x7b, 0xf9, 0xc5, 0x0f, 0x79,
+	0xa1, 0x88, 0xe3, 0x18, 0x5e, 0x14, 0x67, 0xcd, 0xfd, 0x78, 0x8f, 0x44, 0xe2, 0x95, 0x1d, 0x59,
+	0x14, 0x4c, 0x35, 0xa0, 0x0c, 0x02, 0x0f, 0xa1, 0x1e, 0x29, 0xb2, 0x04, 0x09, 0xee, 0x4b, 0x35,
+	0xa2, 0x59, 0x35, 0xeb, 0xaf, 0x3a, 0x54, 0xda, 0x8d, 0x3e, 0xae, 0x33, 0x8d, 0x30, 0x2b, 0x21,
+	0x73, 0xdc, 0xd7, 0x02, 0xf1, 0x13, 0x39, 0xe1, 0x71, 0x27, 0x54, 0xe1, 0xd3, 0x8c, 0x16, 0xf6,
+	0x79, 0x71, 0xae, 0xf0, 0x44, 0xc8, 0x0b, 0x54, 0xe1, 0xa0, 0x3c, 0x64, 0x63, 0xe7, 0x44, 0x3d,
+	0x69, 0x0a, 0xe7, 0x92, 0x07, 0xed, 0x31, 0x00, 0xf6, 0x12, 0x04, 0xe6, 0x90, 0x99, 0x85, 0x0b,
+	0xc1, 0x55, 0xb4, 0x79, 0x96, 0xa7, 0x71, 0xd0, 0x6e, 0xf4, 0x79, 0x31, 0x96, 0x69, 0x42, 0x92,
+	0xdb, 0xb0, 0x24, 0x3f, 0x7b, 0x2c, 0x3c, 0xc6, 0x8b, 0xae, 0xc4, 0x15, 0xe6, 0xb8, 0xd6, 0x7f,
+	0x35, 0x58, 0x96, 0xe5, 0xe3, 0x05, 0x3e, 0x4f, 0x0b, 0x1e, 0x7d, 0x37, 0x65, 0x89, 0xfa, 0xa9,
+	0x53, 0x95, 0x75, 0x4e, 0x07, 0x35, 0xa1, 0xe4, 0xf9, 0xc3, 0x60, 0xea, 0x8f, 0x78, 0xc6, 0xeb,
+	0x34, 0x21, 0xf1, 0x1e, 0x08, 0xa6, 0xb1, 0x10, 0x89, 0x4b, 0x22, 0xa5, 0x31, 0x52, 0xdc, 0x25,
+	0x73, 0x63, 0x36, 0xea, 0x72, 0xa7, 0xa2, 0xd7, 0xcc, 0x71, 0xd1, 0xc7, 0x38, 0x78, 0xfb, 0xc2,
+	0x89, 0xe5, 0x3c, 0x5c, 0xa7, 0x29, 0x8d, 0x87, 0xfc, 0xb5, 0x77, 0xf8, 0x5a, 0x08, 0x4b, 0x5c,
+	0x38, 0x63, 0x58, 0xbf, 0xd5, 0xd3, 0x37, 0xa0, 0xd8, 0xe0, 0x4d, 0x80, 0x60, 0xc2, 0xfc, 0x4e,
+	0x32, 0x68, 0xe1, 0x44, 0xac, 0x70, 0x30, 0x24, 0x31, 0x6b, 0x51, 0xe6, 0x32, 0xef, 0x58, 0xfe,
+	0xbc, 0xca, 0xd3, 0x39, 0x2e, 0xf7, 0xc3, 0x39, 0x3d, 0xe6, 0x8b, 0x21, 0x2a, 0x4f, 0x15, 0x0e,
+	0xd9, 0x00, 0x23, 0xf9, 0xc9, 0x41, 0xd9, 0x77, 0x7c, 0x2f, 0x72, 0xf2, 0x3f, 0xc5, 0x27, 0x5f,
+	0x42, 0x15, 0x13, 0x2b, 0xdf, 0x22, 0x97, 0xa8, 0x03, 0x55, 0x1d, 0x23, 0x89, 0x83, 0x89, 0xe7,
+	0x0a, 0x00, 0x05, 0x3c, 0x0a, 0xc7, 0x1a, 0x42, 0x4d, 0xfc, 0x81, 0x90, 0xc7, 0xea, 0x33, 0xa8,
+	0x7f, 0x17, 0x78, 0x3e, 0x1b, 0x35, 0xcf, 0x3f, 0xf9, 0x59, 0x8d, 0x8b, 0xe7, 0xd9, 0x2d, 0x58,
+	0xde, 0x61, 0x3e, 0x0b, 0x3d, 0x37, 0x5d, 0x26, 0xb5, 0xd1, 0xce, 0xb1, 0xf9, 0x02, 0x0a, 0x9c,
+	0xc6, 0xbf, 0x51, 0x6e, 0x30, 0x62, 0xb2, 0x59, 0xf1, 0x6f, 0xac, 0x27, 0x09, 0x93, 0x3c, 0x7c,
+	0x09, 0x69, 0x95, 0xa0, 0x60, 0x1f, 0x4d, 0xe2, 0x93, 0x8d, 0xff, 0x87, 0x02, 0x1f, 0x49, 0x49,
+	0x19, 0xf2, 0x9d, 0xae, 0xdd, 0x36, 0xae, 0x10, 0x80, 0xe2, 0x5e, 0xa7, 0xf9, 0xdc, 0x6e, 0x19,
+	0xda, 0x06, 0x85, 0x4a, 0xfa, 0xef, 0x0b, 0x05, 0x4d, 0x6a, 0x37, 0xfa, 0xb6, 0x50, 0x6a, 0xd9,
+	0x7b, 0x76, 0xdf, 0x36, 0x34, 0x34, 0x45, 0x03, 0x43, 0x47, 0xee, 0xa0, 0xcd, 0xbf, 0x73, 0xa4,
+	0x06, 0xe5, 0xfd, 0x4e, 0xcb, 0xa6, 0xa8, 0x9f, 0x27, 0x15, 0x28, 0x6c, 0x37, 0xfa, 0xcd, 0xa7,
+	0x46, 0x61, 0xe3, 0x2e, 0x54, 0x95, 0x9f, 0x0d, 0x84, 0xc0, 0x52, 0xbb, 0x73, 0xd0, 0xec, 0xec,
+	0x77, 0xa9, 0xdd, 0xeb, 0xed, 0x76, 0x64, 0x08, 0xbd, 0x76, 0xa3, 0xdb, 0x7d, 0xc9, 0x43, 0x58,
+	0x9e, 0x7b, 0x44, 0x12, 0x03, 0x6a, 0xfd, 0xc6, 0x73, 0xfb, 0x80, 0xda, 0xdf, 0x0c, 0xec, 0x5e,
+	0x5f, 0x18, 0x34, 0x9a, 0x4d, 0xbb, 0xdb, 0x37, 0x34, 0xfc, 0xa6, 0xf6, 0x33, 0xbb, 0xd9, 0x37,
+	0x74, 0x72, 0x1d, 0xae, 0x62, 0x38, 0x07, 0xcd, 0x4e, 0xfb, 0xeb, 0x5d, 0xba, 0xdf, 0xe8, 0xa3,
+	0xff, 0xdc, 0x46, 0x0b, 0x8c, 0xf9, 0x57, 0x33, 0x3a, 0xa5, 0xf6, 0x7e, 0xe7, 0x5b, 0xfb, 0xa0,
+	0x43, 0x5b, 0x36, 0x35, 0xae, 0xe0, 0x0e, 0xb6, 0x1b, 0xed, 0x83, 0xae, 0x6d, 0x53, 0x43, 0x43,
+	0xf9, 0xa0, 0xdb, 0x6a, 0xf4, 0xed, 0x03, 0x3a, 0xd8, 0xb3, 0x7b, 0x86, 0xbe, 0xf1, 0x08, 0x2a,
+	0xe9, 0x64, 0x8b, 0xca, 0x83, 0x76, 0xaf, 0x43, 0xfb, 0x76, 0xcb, 0xb8, 0x42, 0x96, 0x00, 0xb6,
+	0x5f, 0x1e, 0x08, 0xb4, 0x5a, 0x86, 0xc6, 0x5d, 0xbd, 0x3c, 0xe8, 0xd2, 0xdd, 0xa6, 0x6d, 0xe8,
+	0x1b, 0xbf, 0x84, 0x4a, 0x7a, 0x3f, 0x62, 0x88, 0xad, 0x5d, 0x6a, 0x37, 0x31, 0xb4, 0x83, 0x41,
+	0xfb, 0x79, 0xbb, 0xf3, 0x02, 0x21, 0xa8, 0x42, 0x69, 0xb7, 0xbd, 0xdd, 0x19, 0xb4, 0xa5, 0x79,
+	0x67, 0xd0, 0x17, 0x94, 0xbe, 0x41, 0xa1, 0xa6, 0x36, 0x5d, 0x62, 0xc2, 0x0a, 0xb5, 0x1b, 0xcd,
+	0xa7, 0x8d, 0xed, 0xdd, 0xbd, 0xdd, 0xfe, 0x4b, 0xc5, 0x09, 0x40, 0xb1, 0x3b, 0xd8, 0xde, 0xdb,
+	0x6d, 0x1a, 0x1a, 0x3a, 0xa4, 0xf6, 0x5e, 0xe3, 0xa5, 0xdd, 0x32, 0x74, 0xb2, 0x0c, 0xd5, 0x41,
+	0x5b, 0x1a, 0xed, 0xd9, 0x46, 0x6e, 0xeb, 0xef, 0x39, 0xa8, 0xf1, 0xcd, 0x3c, 0x75, 0xfc, 0xd1,
+	0x98, 0x85, 0xe4, 0x1e, 0x14, 0xc5, 0xf3, 0x8d, 0x5c, 0xe5, 0x35, 0xad, 0xfe, 0x16, 0x5c, 0x25,
+	0x2a, 0x4b, 0x96, 0xea, 0x23, 0x28, 0xb6, 0xd8, 0x98, 0xc5, 0x8c, 0x98, 0xb3, 0x99, 0x3f, 0xfb,
+	0x46, 0x5c, 0xe5, 0xbf, 0x04, 0xe6, 0x6b, 0xfc, 0x0b, 0xc8, 0xef, 0x05, 0xee, 0x9b, 0x77, 0x35,
+	0x7b, 0x04, 0xc5, 0x81, 0x3f, 0x7e, 0x0f, 0xc3, 0x7b, 0x50, 0xde, 0x61, 0x31, 0xd7, 0x5f, 0x60,
+	0x3a, 0x7b, 0x37, 0x92, 0xfb, 0x50, 0xdb, 0x61, 0x71, 0x63, 0x3c, 0x96, 0xdd, 0x4d, 0x9c, 0x42,
+	0x3c, 0x3e, 0xab, 0xd7, 0x53, 0xad, 0xcc, 0xa5, 0xfb, 0x00, 0xaa, 0xfc, 0x6d, 0x29, 0x0d, 0x96,
+	0x52, 0x2d, 0xce, 0x3d, 0xcf, 0xea, 0x63, 0xc8, 0xe3, 0xbf, 0x04, 0xc2, 0xff, 0xc7, 0x29, 0xbf,
+	0x25, 0x56, 0x8d, 0x19, 0x43, 0xa8, 0x6e, 0xfd, 0x3b, 0x97, 0xfe, 0x5a, 0x4c, 0x12, 0xf6, 0x31,
+	0xe4, 0xb1, 0x43, 0x09, 0x6b, 0xe5, 0x6f, 0xe9, 0xaa, 0x31, 0x63, 0xc8, 0x85, 0x7e, 0x06, 0x85,
+	0x3d, 0xe6, 0x1c, 0x33, 0xb2, 0xaa, 0x4e, 0x14, 0x97, 0xcb, 0x15, 0xec, 0xb0, 0x58, 0x5a, 0x2c,
+	0x34, 0x57, 0x3b, 0x21, 0x79, 0x00, 0x4b, 0x02, 0x41, 0xc9, 0xc8, 0x60, 0xf8, 0x81, 0xa2, 0x99,
+	0xc1, 0xa3, 0x09, 0xcb, 0xb3, 0xc5, 0xe4, 0x1d, 0xb6, 0x60, 0xc5, 0x95, 0x64, 0xb8, 0xcb, 0x38,
+	0xf9, 0x4a, 0x75, 0x22, 0x6e, 0xaf, 0x45, 0x4e, 0x4e, 0xcd, 0x58, 0xe4, 0x17, 0x60, 0xb4, 0xbc,
+	0xc8, 0x0d, 0x8e, 0x59, 0x78, 0x56, 0xf4, 0x1f, 0x2a, 0x06, 0xa7, 0xdf, 0x2f, 0x0f, 0xa1, 0x2c,
+	0x1b, 0x0d, 0x23, 0xd7, 0xb3, 0x3f, 0xeb, 0x16, 0xe1, 0xbc, 0xf5, 0xb7, 0x3c, 0x54, 0xf1, 0x1d,
+	0x93, 0x24, 0xf7, 0x11, 0x94, 0xb6, 0x1d, 0xf1, 0x1c, 0xfd, 0x20, 0xd9, 0xe6, 0xa5, 0x12, 0xf6,
+	0x73, 0xa8, 0x0c, 0xfc, 0xe1, 0x7b, 0x99, 0x6e, 0xc1, 0x32, 0x22, 0xb9, 0x8d, 0x5b, 0x93, 0x23,
+	0x84, 0xb2, 0xef, 0xb3, 0xd1, 0x7e, 0x00, 0x04, 0xd1, 0x9e, 0x9b, 0x87, 0x14, 0xb3, 0x6b, 0xe2,
+	0x5f, 0x73, 0x56, 0x7e, 0x1b, 0xaa, 0x3b, 0x2c, 0x4e, 0xdf, 0x6d, 0x8a, 0x3a, 0x1f, 0xd8, 0x53,
+	0xc1, 0x7d, 0xa8, 0xe0, 0x6a, 0xa7, 0x62, 0x31, 0xd5, 0xb1, 0x3e, 0x13, 0xcf, 0x63, 0xa8, 0xca,
+	0xc5, 0x38, 0x00, 0x37, 0x94, 0xd5, 0x95, 0x61, 0xff, 0xec, 0xfd, 0x3f, 0xc1, 0xe7, 0x71, 0xe4,
+	0x2a, 0xe6, 0xef, 0x86, 0xdf, 0x97, 0x70, 0x15, 0x63, 0x51, 0x87, 0xec, 0xd3, 0x95, 0x73, 0xee,
+	0xdc, 0xbe, 0xce, 0x9b, 0xce, 0x6c, 0x02, 0x57, 0x0c, 0xf9, 0x03, 0x2b, 0x95, 0x0c, 0x8b, 0x7c,
+	0xb6, 0xf9, 0xfc, 0x7f, 0x03, 0x00, 0xd5, 0xed, 0xf6, 0x71, 0x9b, 0x1c, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// OrderHandlerClient is the client API for OrderHandler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OrderHandlerClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error)
+	GetAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderListResponse, error)
+	QueryOrders(ctx context.Context, in *OrderQuery, opts ...grpc.CallOption) (*OrderListResponse, error)
+	Take(ctx context.Context, in *TakeRequest, opts ...grpc.CallOption) (*TakeResponse, error)
+}
+
+type orderHandlerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOrderHandlerClient(cc *grpc.ClientConn) OrderHandlerClient {
+	return &orderHandlerClient{cc}
+}
+
+func (c *orderHandlerClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Lock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Unlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) GetAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderListResponse, error) {
+	out := new(OrderListResponse)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetAllOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) QueryOrders(ctx context.Context, in *OrderQuery, opts ...grpc.CallOption) (*OrderListResponse, error) {
+	out := new(OrderListResponse)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/QueryOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Take(ctx context.Context, in *TakeRequest, opts ...grpc.CallOption) (*TakeResponse, error) {
+	out := new(TakeResponse)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Take", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderHandlerServer is the server API for OrderHandler service.
+type OrderHandlerServer interface {
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	Delete(context.Context, *OrderSpecificRequest) (*GenericResponse, error)
+	Lock(context.Context, *OrderSpecificRequest) (*GenericResponse, error)
+	Unlock(context.Context, *OrderSpecificRequest) (*GenericResponse, error)
+	GetOrder(context.Context, *OrderSpecificRequest) (*Order, error)
+	GetAllOrders(context.Context, *Empty) (*OrderListResponse, error)
+	QueryOrders(context.Context, *OrderQuery) (*OrderListResponse, error)
+	Take(context.Context, *TakeRequest) (*TakeResponse, error)
+}
+
+// UnimplementedOrderHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedOrderHandlerServer struct {
+}
+
+func (*UnimplementedOrderHandlerServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Delete(ctx context.Context, req *OrderSpecificRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Lock(ctx context.Context, req *OrderSpecificRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Unlock(ctx context.Context, req *OrderSpecificRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
+}
+func (*UnimplementedOrderHandlerServer) GetOrder(ctx context.Context, req *OrderSpecificRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (*UnimplementedOrderHandlerServer) GetAllOrders(ctx context.Context, req *Empty) (*OrderListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllOrders not implemented")
+}
+func (*UnimplementedOrderHandlerServer) QueryOrders(ctx context.Context, req *OrderQuery) (*OrderListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryOrders not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Take(ctx context.Context, req *TakeRequest) (*TakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Take not implemented")
+}
+
+func RegisterOrderHandlerServer(s *grpc.Server, srv OrderHandlerServer) {
+	s.RegisterService(&_OrderHandler_serviceDesc, srv)
+}
+
+func _OrderHandler_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Delete(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Lock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Lock(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Unlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Unlock(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).GetOrder(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_GetAllOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).GetAllOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/GetAllOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).GetAllOrders(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_QueryOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).QueryOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/QueryOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).QueryOrders(ctx, req.(*OrderQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Take_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Take(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Take",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Take(ctx, req.(*TakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OrderHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.OrderHandler",
+	HandlerType: (*OrderHandlerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _OrderHandler_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _OrderHandler_Delete_Handler,
+		},
+		{
+			MethodName: "Lock",
+			Handler:    _OrderHandler_Lock_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _OrderHandler_Unlock_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _OrderHandler_GetOrder_Handler,
+		},
+		{
+			MethodName: "GetAllOrders",
+			Handler:    _OrderHandler_GetAllOrders_Handler,
+		},
+		{
+			MethodName: "QueryOrders",
+			Handler:    _OrderHandler_QueryOrders_Handler,
+		},
+		{
+			MethodName: "Take",
+			Handler:    _OrderHandler_Take_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pb/sprawl.proto",
+}
+
+// ChannelHandlerClient is the client API for ChannelHandler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChannelHandlerClient interface {
+	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+	Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error)
+	GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelListResponse, error)
+	GetChannelPeers(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*PeerListResponse, error)
+	GetChannelStats(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*ChannelStats, error)
+	DiscoverChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelDirectoryResponse, error)
+	Moderate(ctx context.Context, in *ModerationRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+}
+
+type channelHandlerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChannelHandlerClient(cc *grpc.ClientConn) ChannelHandlerClient {
+	return &channelHandlerClient{cc}
+}
+
+func (c *channelHandlerClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
+	out := new(JoinResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Join", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func init() { proto.RegisterFile("pb/sprawl.proto", fileDescriptor_a9abbf861cc1c96d) }
+func (c *channelHandlerClient) GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error) {
+	out := new(Channel)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetChannel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-var fileDescriptor_a9abbf861cc1c96d = []byte{
-	// 790 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdd, 0x72, 0xda, 0x56,
-	0x10, 0xae, 0x04, 0x12, 0x66, 0xc1, 0x18, 0x1f, 0xbb, 0xae, 0x86, 0x69, 0xc7, 0x54, 0x37, 0xa5,
-	0x6e, 0x0b, 0x2d, 0xb5, 0xeb, 0xf6, 0xa6, 0x33, 0x1e, 0xd0, 0x90, 0x1f, 0x62, 0x3c, 0xb2, 0x3d,
-	0xb9, 0x8d, 0x90, 0xd6, 0x8e, 0x12, 0x21, 0x29, 0xd2, 0x71, 0x32, 0x79, 0x90, 0x3c, 0x55, 0xee,
-	0xf3, 0x00, 0x79, 0x92, 0xcc, 0xf9, 0x91, 0x90, 0x70, 0x8c, 0x93, 0xdc, 0x71, 0xbe, 0xdd, 0x6f,
-	0xf7, 0xec, 0xb7, 0xdf, 0x11, 0xb0, 0x15, 0xcf, 0x07, 0x69, 0x9c, 0x38, 0x6f, 0x82, 0x7e, 0x9c,
-	0x44, 0x34, 0x22, 0x6a, 0x3c, 0xef, 0xec, 0x5f, 0x47, 0xd1, 0x75, 0x80, 0x03, 0x8e, 0xcc, 0x6f,
-	0xae, 0x06, 0xd4, 0x5f, 0x60, 0x4a, 0x9d, 0x45, 0x2c, 0x92, 0xcc, 0x0f, 0x0a, 0x68, 0xb3, 0xc4,
-	0xc3, 0x84, 0xb4, 0x40, 0xf5, 0x3d, 0x43, 0xe9, 0x2a, 0xbd, 0xa6, 0xad, 0xfa, 0x1e, 0x39, 0x84,
-	0x9a, 0x9b, 0xa0, 0x43, 0xd1, 0x33, 0xd4, 0xae, 0xd2, 0x6b, 0x0c, 0x3b, 0x7d, 0x51, 0xac, 0x9f,
-	0x15, 0xeb, 0x5f, 0x64, 0xc5, 0xec, 0x2c, 0x95, 0xec, 0x82, 0xe6, 0xa4, 0x29, 0x52, 0xa3, 0xd2,
-	0x55, 0x7a, 0x75, 0x5b, 0x1c, 0x88, 0x09, 0x4d, 0x37, 0xba, 0x09, 0x29, 0x26, 0x27, 0x3c, 0x58,
-	0xe5, 0xc1, 0x12, 0x46, 0xf6, 0x40, 0x77, 0x16, 0x0c, 0x30, 0xb4, 0xae, 0xd2, 0xab, 0xda, 0xf2,
-	0xc4, 0x2a, 0xc6, 0x89, 0xef, 0xa2, 0xa1, 0x77, 0x95, 0x9e, 0x6a, 0x8b, 0x03, 0xd9, 0x07, 0x2d,
-	0xa5, 0x0e, 0x45, 0xa3, 0xd6, 0x55, 0x7a, 0xad, 0x61, 0xbd, 0x1f, 0xcf, 0xfb, 0xe7, 0x0c, 0xb0,
-	0x05, 0x6e, 0x4e, 0xa0, 0x36, 0x7a, 0xee, 0x84, 0x21, 0x06, 0xb7, 0x26, 0xfb, 0x1d, 0x6a, 0x51,
-	0x4c, 0xfd, 0x28, 0x4c, 0xe5, 0x64, 0x84, 0xb1, 0x65, 0xf6, 0x4c, 0x44, 0xec, 0x2c, 0xc5, 0x0c,
-	0xa0, 0xf1, 0xd4, 0x4f, 0xf0, 0x09, 0xa6, 0xa9, 0x73, 0x8d, 0xe4, 0x47, 0xa8, 0xbb, 0x22, 0xf3,
-	0xe1, 0x58, 0xd6, 0x5c, 0x02, 0xe4, 0x37, 0xa8, 0x47, 0x31, 0x26, 0x0e, 0xa3, 0xf2, 0xe2, 0xad,
-	0xe1, 0x26, 0x2b, 0x3e, 0xcb, 0x40, 0x7b, 0x19, 0x27, 0x04, 0xaa, 0x9e, 0x43, 0x1d, 0x2e, 0x55,
-	0xd3, 0xe6, 0xbf, 0xcd, 0x77, 0x0a, 0x6c, 0x8e, 0xb8, 0x96, 0x36, 0xbe, 0xba, 0xc1, 0x94, 0xde,
-	0xd3, 0x30, 0xd7, 0x5b, 0x5d, 0xa7, 0x77, 0x65, 0xad, 0xde, 0xd5, 0xcf, 0xeb, 0xad, 0x15, 0xf4,
-	0x36, 0x27, 0xd0, 0x78, 0x14, 0xf9, 0x61, 0x76, 0xa9, 0xbc, 0xad, 0xb2, 0xae, 0xad, 0x7a, 0xbb,
-	0xad, 0xd9, 0x87, 0x56, 0x59, 0x69, 0x36, 0x20, 0xa7, 0x9f, 0x39, 0x7e, 0x22, 0xeb, 0x2d, 0x01,
-	0xf3, 0x14, 0x76, 0xb9, 0x3f, 0xcf, 0x63, 0x74, 0xfd, 0x2b, 0xdf, 0xcd, 0x6e, 0x60, 0x40, 0x2d,
-	0x62, 0x78, 0x2e, 0x4a, 0x76, 0x2c, 0x0b, 0xa6, 0xae, 0x08, 0x66, 0xf6, 0x60, 0x4f, 0xf6, 0x5f,
-	0xad, 0xb8, 0x62, 0x13, 0xf3, 0x19, 0xb4, 0xb2, 0x4d, 0xa4, 0x71, 0x14, 0xa6, 0x48, 0xfe, 0x80,
-	0xa6, 0xf4, 0x39, 0xbf, 0x12, 0xcf, 0x6d, 0x08, 0xef, 0x71, 0xc0, 0x2e, 0x85, 0x99, 0x47, 0x31,
-	0x49, 0xa2, 0x44, 0xba, 0x8c, 0xe7, 0x59, 0x0c, 0xb0, 0x05, 0x6e, 0xfe, 0x03, 0xdb, 0x3c, 0x73,
-	0xea, 0xa7, 0x34, 0x6f, 0xf2, 0x33, 0xe8, 0x7c, 0x92, 0xd4, 0x50, 0xba, 0x95, 0x72, 0x79, 0x19,
-	0x30, 0xff, 0x87, 0x1d, 0x39, 0x43, 0x89, 0xf9, 0x0b, 0x6c, 0xc8, 0x39, 0x33, 0x6e, 0xa3, 0x60,
-	0x6c, 0x3b, 0x0f, 0x9a, 0x73, 0x68, 0x8a, 0x65, 0x4a, 0xe2, 0x5f, 0xb0, 0xf9, 0x22, 0xf2, 0x43,
-	0xf4, 0x64, 0xaa, 0x1c, 0xac, 0xc4, 0x2e, 0x67, 0xdc, 0x3f, 0xdb, 0x10, 0xb6, 0x26, 0x18, 0x62,
-	0xc2, 0xf4, 0x95, 0x6d, 0x72, 0x8e, 0x72, 0x07, 0xe7, 0x08, 0x34, 0x7e, 0x66, 0x2f, 0xc3, 0x8d,
-	0x3c, 0x94, 0x6e, 0xe0, 0xbf, 0xd9, 0xc2, 0x17, 0xe2, 0x0d, 0x4a, 0x5f, 0x65, 0x47, 0xb3, 0x06,
-	0x9a, 0xb5, 0x88, 0xe9, 0xdb, 0x83, 0x9f, 0x40, 0xe3, 0xdf, 0x00, 0xb2, 0x01, 0xd5, 0xd9, 0x99,
-	0x75, 0xda, 0xfe, 0x8e, 0x00, 0xe8, 0xd3, 0xd9, 0xe8, 0xb1, 0x35, 0x6e, 0x2b, 0x07, 0xff, 0x41,
-	0x3d, 0x7f, 0x87, 0x2c, 0x30, 0xb2, 0xad, 0x93, 0x0b, 0x4b, 0x24, 0x8d, 0xad, 0xa9, 0x75, 0x61,
-	0xb5, 0x15, 0x46, 0x65, 0x84, 0xb6, 0xca, 0xd0, 0xcb, 0x53, 0xfe, 0xbb, 0x32, 0x7c, 0xaf, 0x42,
-	0x93, 0xef, 0xe0, 0x81, 0x13, 0x7a, 0x01, 0x26, 0x64, 0x00, 0xba, 0x30, 0x07, 0xd9, 0xe6, 0x2a,
-	0x15, 0x9f, 0x6c, 0x87, 0x14, 0x21, 0x39, 0xfc, 0x31, 0xe8, 0x63, 0x0c, 0x90, 0x22, 0x31, 0xf2,
-	0x85, 0xae, 0x38, 0xb0, 0xb3, 0xc3, 0x22, 0xab, 0xaa, 0x1d, 0x41, 0x75, 0x1a, 0xb9, 0x2f, 0xbf,
-	0x96, 0x76, 0x0c, 0xfa, 0x65, 0x18, 0x7c, 0x03, 0x71, 0x00, 0x1b, 0x13, 0xa4, 0xc2, 0xc1, 0x77,
-	0x53, 0x97, 0xae, 0x24, 0x7f, 0x42, 0x73, 0x82, 0xf4, 0x24, 0x08, 0xf8, 0x31, 0x25, 0x62, 0xaf,
-	0x6c, 0x21, 0x9d, 0xef, 0xf3, 0xac, 0xa2, 0x51, 0x87, 0x1f, 0x95, 0xfc, 0x23, 0x90, 0xe9, 0xf9,
-	0x2b, 0x54, 0x99, 0x25, 0xc9, 0x16, 0x63, 0x14, 0xbe, 0x34, 0x9d, 0xf6, 0x12, 0x90, 0x17, 0xfc,
-	0x17, 0xb4, 0x29, 0x3a, 0xaf, 0x91, 0x74, 0x0a, 0xfe, 0xfc, 0x42, 0x29, 0x61, 0x82, 0x34, 0xb3,
-	0xf0, 0x3a, 0x7a, 0xd1, 0xfa, 0xe4, 0x10, 0x5a, 0x62, 0x40, 0x09, 0x94, 0x46, 0xfc, 0xa1, 0x90,
-	0x59, 0x1c, 0x72, 0xae, 0xf3, 0xbf, 0xc9, 0xbf, 0x3f, 0x05, 0x00, 0x00, 0xff, 0xff, 0x7a, 0x24,
-	0xb7, 0x55, 0x98, 0x07, 0x00, 0x00,
+func (c *channelHandlerClient) GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelListResponse, error) {
+	out := new(ChannelListResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetAllChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (c *channelHandlerClient) GetChannelPeers(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*PeerListResponse, error) {
+	out := new(PeerListResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetChannelPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (c *channelHandlerClient) GetChannelStats(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*ChannelStats, error) {
+	out := new(ChannelStats)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetChannelStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// OrderHandlerClient is the client API for OrderHandler service.
+func (c *channelHandlerClient) DiscoverChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelDirectoryResponse, error) {
+	out := new(ChannelDirectoryResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/DiscoverChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) Moderate(ctx context.Context, in *ModerationRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Moderate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChannelHandlerServer is the server API for ChannelHandler service.
+type ChannelHandlerServer interface {
+	Join(context.Context, *JoinRequest) (*JoinResponse, error)
+	Leave(context.Context, *ChannelSpecificRequest) (*GenericResponse, error)
+	GetChannel(context.Context, *ChannelSpecificRequest) (*Channel, error)
+	GetAllChannels(context.Context, *Empty) (*ChannelListResponse, error)
+	GetChannelPeers(context.Context, *ChannelSpecificRequest) (*PeerListResponse, error)
+	GetChannelStats(context.Context, *ChannelSpecificRequest) (*ChannelStats, error)
+	DiscoverChannels(context.Context, *Empty) (*ChannelDirectoryResponse, error)
+	Moderate(context.Context, *ModerationRequest) (*GenericResponse, error)
+}
+
+// UnimplementedChannelHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedChannelHandlerServer struct {
+}
+
+func (*UnimplementedChannelHandlerServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (*UnimplementedChannelHandlerServer) Leave(ctx context.Context, req *ChannelSpecificRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetChannel(ctx context.Context, req *ChannelSpecificRequest) (*Channel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannel not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetAllChannels(ctx context.Context, req *Empty) (*ChannelListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllChannels not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetChannelPeers(ctx context.Context, req *ChannelSpecificRequest) (*PeerListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannelPeers not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetChannelStats(ctx context.Context, req *ChannelSpecificRequest) (*ChannelStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannelStats not implemented")
+}
+func (*UnimplementedChannelHandlerServer) DiscoverChannels(ctx context.Context, req *Empty) (*ChannelDirectoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscoverChannels not implemented")
+}
+func (*UnimplementedChannelHandlerServer) Moderate(ctx context.Context, req *ModerationRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Moderate not implemented")
+}
+
+func RegisterChannelHandlerServer(s *grpc.Server, srv ChannelHandlerServer) {
+	s.RegisterService(&_ChannelHandler_serviceDesc, srv)
+}
+
+func _ChannelHandler_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).Join(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/Join",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).Join(ctx, req.(*JoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/Leave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).Leave(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_GetChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).GetChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/GetChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).GetChannel(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_GetAllChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).GetAllChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/GetAllChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).GetAllChannels(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_GetChannelPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).GetChannelPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/GetChannelPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).GetChannelPeers(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_GetChannelStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).GetChannelStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/GetChannelStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).GetChannelStats(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_DiscoverChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).DiscoverChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/DiscoverChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).DiscoverChannels(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_Moderate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).Moderate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/Moderate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).Moderate(ctx, req.(*ModerationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ChannelHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.ChannelHandler",
+	HandlerType: (*ChannelHandlerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Join",
+			Handler:    _ChannelHandler_Join_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _ChannelHandler_Leave_Handler,
+		},
+		{
+			MethodName: "GetChannel",
+			Handler:    _ChannelHandler_GetChannel_Handler,
+		},
+		{
+			MethodName: "GetAllChannels",
+			Handler:    _ChannelHandler_GetAllChannels_Handler,
+		},
+		{
+			MethodName: "GetChannelPeers",
+			Handler:    _ChannelHandler_GetChannelPeers_Handler,
+		},
+		{
+			MethodName: "GetChannelStats",
+			Handler:    _ChannelHandler_GetChannelStats_Handler,
+		},
+		{
+			MethodName: "DiscoverChannels",
+			Handler:    _ChannelHandler_DiscoverChannels_Handler,
+		},
+		{
+			MethodName: "Moderate",
+			Handler:    _ChannelHandler_Moderate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pb/sprawl.proto",
+}
+
+// NodeHandlerClient is the client API for NodeHandler service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type OrderHandlerClient interface {
-	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
-	Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
-	Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
-	Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
-	GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error)
-	GetAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderListResponse, error)
+type NodeHandlerClient interface {
+	BanPeer(ctx context.Context, in *PeerSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	UnbanPeer(ctx context.Context, in *PeerSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	ListBannedPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerListResponse, error)
+	GetConnectionStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ConnectionStats, error)
+	GetNodeInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NodeInfo, error)
+	ListPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerInfoListResponse, error)
+	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	DisconnectPeer(ctx context.Context, in *PeerSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	ListSubscriptions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SubscriptionListResponse, error)
+	GetNATStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NATStatus, error)
+}
+
+type nodeHandlerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNodeHandlerClient(cc *grpc.ClientConn) NodeHandlerClient {
+	return &nodeHandlerClient{cc}
+}
+
+func (c *nodeHandlerClient) BanPeer(ctx context.Context, in *PeerSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/BanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type orderHandlerClient struct {
-	cc *grpc.ClientConn
+func (c *nodeHandlerClient) UnbanPeer(ctx context.Context, in *PeerSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/UnbanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewOrderHandlerClient(cc *grpc.ClientConn) OrderHandlerClient {
-	return &orderHandlerClient{cc}
+func (c *nodeHandlerClient) ListBannedPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerListResponse, error) {
+	out := new(PeerListResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/ListBannedPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *orderHandlerClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
-	out := new(CreateResponse)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Create", in, out, opts...)
+func (c *nodeHandlerClient) GetConnectionStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ConnectionStats, error) {
+	out := new(ConnectionStats)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetConnectionStats", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
-	out := new(GenericResponse)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Delete", in, out, opts...)
+func (c *nodeHandlerClient) GetNodeInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NodeInfo, error) {
+	out := new(NodeInfo)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetNodeInfo", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+func (c *nodeHandlerClient) ListPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerInfoListResponse, error) {
+	out := new(PeerInfoListResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/ListPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
 	out := new(GenericResponse)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Lock", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/ConnectPeer", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+func (c *nodeHandlerClient) DisconnectPeer(ctx context.Context, in *PeerSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
 	out := new(GenericResponse)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Unlock", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/DisconnectPeer", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error) {
-	out := new(Order)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetOrder", in, out, opts...)
+func (c *nodeHandlerClient) ListSubscriptions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SubscriptionListResponse, error) {
+	out := new(SubscriptionListResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/ListSubscriptions", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) GetAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderListResponse, error) {
-	out := new(OrderListResponse)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetAllOrders", in, out, opts...)
+func (c *nodeHandlerClient) GetNATStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NATStatus, error) {
+	out := new(NATStatus)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetNATStatus", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// OrderHandlerServer is the server API for OrderHandler service.
-type OrderHandlerServer interface {
-	Create(context.Context, *CreateRequest) (*CreateResponse, error)
-	Delete(context.Context, *OrderSpecificRequest) (*GenericResponse, error)
-	Lock(context.Context, *OrderSpecificRequest) (*GenericResponse, error)
-	Unlock(context.Context, *OrderSpecificRequest) (*GenericResponse, error)
-	GetOrder(context.Context, *OrderSpecificRequest) (*Order, error)
-	GetAllOrders(context.Context, *Empty) (*OrderListResponse, error)
+// NodeHandlerServer is the server API for NodeHandler service.
+type NodeHandlerServer interface {
+	BanPeer(context.Context, *PeerSpecificRequest) (*GenericResponse, error)
+	UnbanPeer(context.Context, *PeerSpecificRequest) (*GenericResponse, error)
+	ListBannedPeers(context.Context, *Empty) (*PeerListResponse, error)
+	GetConnectionStats(context.Context, *Empty) (*ConnectionStats, error)
+	GetNodeInfo(context.Context, *Empty) (*NodeInfo, error)
+	ListPeers(context.Context, *Empty) (*PeerInfoListResponse, error)
+	ConnectPeer(context.Context, *ConnectPeerRequest) (*GenericResponse, error)
+	DisconnectPeer(context.Context, *PeerSpecificRequest) (*GenericResponse, error)
+	ListSubscriptions(context.Context, *Empty) (*SubscriptionListResponse, error)
+	GetNATStatus(context.Context, *Empty) (*NATStatus, error)
 }
 
-// UnimplementedOrderHandlerServer can be embedded to have forward compatible implementations.
-type UnimplementedOrderHandlerServer struct {
+// UnimplementedNodeHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedNodeHandlerServer struct {
 }
 
-func (*UnimplementedOrderHandlerServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+func (*UnimplementedNodeHandlerServer) BanPeer(ctx context.Context, req *PeerSpecificRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanPeer not implemented")
 }
-func (*UnimplementedOrderHandlerServer) Delete(ctx context.Context, req *OrderSpecificRequest) (*GenericResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+func (*UnimplementedNodeHandlerServer) UnbanPeer(ctx context.Context, req *PeerSpecificRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanPeer not implemented")
 }
-func (*UnimplementedOrderHandlerServer) Lock(ctx context.Context, req *OrderSpecificRequest) (*GenericResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
+func (*UnimplementedNodeHandlerServer) ListBannedPeers(ctx context.Context, req *Empty) (*PeerListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBannedPeers not implemented")
 }
-func (*UnimplementedOrderHandlerServer) Unlock(ctx context.Context, req *OrderSpecificRequest) (*GenericResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
+func (*UnimplementedNodeHandlerServer) GetConnectionStats(ctx context.Context, req *Empty) (*ConnectionStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnectionStats not implemented")
 }
-func (*UnimplementedOrderHandlerServer) GetOrder(ctx context.Context, req *OrderSpecificRequest) (*Order, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+func (*UnimplementedNodeHandlerServer) GetNodeInfo(ctx context.Context, req *Empty) (*NodeInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeInfo not implemented")
 }
-func (*UnimplementedOrderHandlerServer) GetAllOrders(ctx context.Context, req *Empty) (*OrderListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllOrders not implemented")
+func (*UnimplementedNodeHandlerServer) ListPeers(ctx context.Context, req *Empty) (*PeerInfoListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeers not implemented")
+}
+func (*UnimplementedNodeHandlerServer) ConnectPeer(ctx context.Context, req *ConnectPeerRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectPeer not implemented")
+}
+func (*UnimplementedNodeHandlerServer) DisconnectPeer(ctx context.Context, req *PeerSpecificRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectPeer not implemented")
+}
+func (*UnimplementedNodeHandlerServer) ListSubscriptions(ctx context.Context, req *Empty) (*SubscriptionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
+}
+func (*UnimplementedNodeHandlerServer) GetNATStatus(ctx context.Context, req *Empty) (*NATStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNATStatus not implemented")
 }
 
-func RegisterOrderHandlerServer(s *grpc.Server, srv OrderHandlerServer) {
-	s.RegisterService(&_OrderHandler_serviceDesc, srv)
+func RegisterNodeHandlerServer(s *grpc.Server, srv NodeHandlerServer) {
+	s.RegisterService(&_NodeHandler_serviceDesc, srv)
 }
 
-func _OrderHandler_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRequest)
+func _NodeHandler_BanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerSpecificRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Create(ctx, in)
+		return srv.(NodeHandlerServer).BanPeer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Create",
+		FullMethod: "/pb.NodeHandler/BanPeer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Create(ctx, req.(*CreateRequest))
+		return srv.(NodeHandlerServer).BanPeer(ctx, req.(*PeerSpecificRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _NodeHandler_UnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerSpecificRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Delete(ctx, in)
+		return srv.(NodeHandlerServer).UnbanPeer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Delete",
+		FullMethod: "/pb.NodeHandler/UnbanPeer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Delete(ctx, req.(*OrderSpecificRequest))
+		return srv.(NodeHandlerServer).UnbanPeer(ctx, req.(*PeerSpecificRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _NodeHandler_ListBannedPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Lock(ctx, in)
+		return srv.(NodeHandlerServer).ListBannedPeers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Lock",
+		FullMethod: "/pb.NodeHandler/ListBannedPeers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Lock(ctx, req.(*OrderSpecificRequest))
+		return srv.(NodeHandlerServer).ListBannedPeers(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _NodeHandler_GetConnectionStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Unlock(ctx, in)
+		return srv.(NodeHandlerServer).GetConnectionStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Unlock",
+		FullMethod: "/pb.NodeHandler/GetConnectionStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Unlock(ctx, req.(*OrderSpecificRequest))
+		return srv.(NodeHandlerServer).GetConnectionStats(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _NodeHandler_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).GetOrder(ctx, in)
+		return srv.(NodeHandlerServer).GetNodeInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/GetOrder",
+		FullMethod: "/pb.NodeHandler/GetNodeInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).GetOrder(ctx, req.(*OrderSpecificRequest))
+		return srv.(NodeHandlerServer).GetNodeInfo(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_GetAllOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _NodeHandler_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).GetAllOrders(ctx, in)
+		return srv.(NodeHandlerServer).ListPeers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/GetAllOrders",
+		FullMethod: "/pb.NodeHandler/ListPeers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).GetAllOrders(ctx, req.(*Empty))
+		return srv.(NodeHandlerServer).ListPeers(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _OrderHandler_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.OrderHandler",
-	HandlerType: (*OrderHandlerServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Create",
-			Handler:    _OrderHandler_Create_Handler,
-		},
-		{
-			MethodName: "Delete",
-			Handler:    _OrderHandler_Delete_Handler,
-		},
-		{
-			MethodName: "Lock",
-			Handler:    _OrderHandler_Lock_Handler,
-		},
-		{
-			MethodName: "Unlock",
-			Handler:    _OrderHandler_Unlock_Handler,
-		},
-		{
-			MethodName: "GetOrder",
-			Handler:    _OrderHandler_GetOrder_Handler,
-		},
-		{
-			MethodName: "GetAllOrders",
-			Handler:    _OrderHandler_GetAllOrders_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "pb/sprawl.proto",
-}
-
-// ChannelHandlerClient is the client API for ChannelHandler service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ChannelHandlerClient interface {
-	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
-	Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error)
-	GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error)
-	GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelListResponse, error)
-}
-
-type channelHandlerClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewChannelHandlerClient(cc *grpc.ClientConn) ChannelHandlerClient {
-	return &channelHandlerClient{cc}
-}
-
-func (c *channelHandlerClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
-	out := new(JoinResponse)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Join", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *channelHandlerClient) Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
-	out := new(GenericResponse)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Leave", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *channelHandlerClient) GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error) {
-	out := new(Channel)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetChannel", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *channelHandlerClient) GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelListResponse, error) {
-	out := new(ChannelListResponse)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetAllChannels", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-// ChannelHandlerServer is the server API for ChannelHandler service.
-type ChannelHandlerServer interface {
-	Join(context.Context, *JoinRequest) (*JoinResponse, error)
-	Leave(context.Context, *ChannelSpecificRequest) (*GenericResponse, error)
-	GetChannel(context.Context, *ChannelSpecificRequest) (*Channel, error)
-	GetAllChannels(context.Context, *Empty) (*ChannelListResponse, error)
-}
-
-// UnimplementedChannelHandlerServer can be embedded to have forward compatible implementations.
-type UnimplementedChannelHandlerServer struct {
-}
-
-func (*UnimplementedChannelHandlerServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
-}
-func (*UnimplementedChannelHandlerServer) Leave(ctx context.Context, req *ChannelSpecificRequest) (*GenericResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
-}
-func (*UnimplementedChannelHandlerServer) GetChannel(ctx context.Context, req *ChannelSpecificRequest) (*Channel, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetChannel not implemented")
-}
-func (*UnimplementedChannelHandlerServer) GetAllChannels(ctx context.Context, req *Empty) (*ChannelListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllChannels not implemented")
-}
-
-func RegisterChannelHandlerServer(s *grpc.Server, srv ChannelHandlerServer) {
-	s.RegisterService(&_ChannelHandler_serviceDesc, srv)
-}
-
-func _ChannelHandler_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JoinRequest)
+func _NodeHandler_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectPeerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChannelHandlerServer).Join(ctx, in)
+		return srv.(NodeHandlerServer).ConnectPeer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/Join",
+		FullMethod: "/pb.NodeHandler/ConnectPeer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).Join(ctx, req.(*JoinRequest))
+		return srv.(NodeHandlerServer).ConnectPeer(ctx, req.(*ConnectPeerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChannelHandler_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelSpecificRequest)
+func _NodeHandler_DisconnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerSpecificRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChannelHandlerServer).Leave(ctx, in)
+		return srv.(NodeHandlerServer).DisconnectPeer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/Leave",
+		FullMethod: "/pb.NodeHandler/DisconnectPeer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).Leave(ctx, req.(*ChannelSpecificRequest))
+		return srv.(NodeHandlerServer).DisconnectPeer(ctx, req.(*PeerSpecificRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChannelHandler_GetChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelSpecificRequest)
+func _NodeHandler_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChannelHandlerServer).GetChannel(ctx, in)
+		return srv.(NodeHandlerServer).ListSubscriptions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/GetChannel",
+		FullMethod: "/pb.NodeHandler/ListSubscriptions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).GetChannel(ctx, req.(*ChannelSpecificRequest))
+		return srv.(NodeHandlerServer).ListSubscriptions(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChannelHandler_GetAllChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _NodeHandler_GetNATStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChannelHandlerServer).GetAllChannels(ctx, in)
+		return srv.(NodeHandlerServer).GetNATStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/GetAllChannels",
+		FullMethod: "/pb.NodeHandler/GetNATStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).GetAllChannels(ctx, req.(*Empty))
+		return srv.(NodeHandlerServer).GetNATStatus(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ChannelHandler_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.ChannelHandler",
-	HandlerType: (*ChannelHandlerServer)(nil),
+var _NodeHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.NodeHandler",
+	HandlerType: (*NodeHandlerServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Join",
-			Handler:    _ChannelHandler_Join_Handler,
+			MethodName: "BanPeer",
+			Handler:    _NodeHandler_BanPeer_Handler,
 		},
 		{
-			MethodName: "Leave",
-			Handler:    _ChannelHandler_Leave_Handler,
+			MethodName: "UnbanPeer",
+			Handler:    _NodeHandler_UnbanPeer_Handler,
 		},
 		{
-			MethodName: "GetChannel",
-			Handler:    _ChannelHandler_GetChannel_Handler,
+			MethodName: "ListBannedPeers",
+			Handler:    _NodeHandler_ListBannedPeers_Handler,
 		},
 		{
-			MethodName: "GetAllChannels",
-			Handler:    _ChannelHandler_GetAllChannels_Handler,
+			MethodName: "GetConnectionStats",
+			Handler:    _NodeHandler_GetConnectionStats_Handler,
+		},
+		{
+			MethodName: "GetNodeInfo",
+			Handler:    _NodeHandler_GetNodeInfo_Handler,
+		},
+		{
+			MethodName: "ListPeers",
+			Handler:    _NodeHandler_ListPeers_Handler,
+		},
+		{
+			MethodName: "ConnectPeer",
+			Handler:    _NodeHandler_ConnectPeer_Handler,
+		},
+		{
+			MethodName: "DisconnectPeer",
+			Handler:    _NodeHandler_DisconnectPeer_Handler,
+		},
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _NodeHandler_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "GetNATStatus",
+			Handler:    _NodeHandler_GetNATStatus_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -40,15 +40,36 @@ func (s *ChannelService) Join(ctx context.Context, in *pb.JoinRequest) (*pb.Join
 	// Join the channel options together
 	channelOptBlob := []byte(strings.Join(assetPair[:], ","))
 
+	// Channels with admins get an ID of their own, so they can't be confused with the admin-less channel
+	admins, err := sortAdmins(in.GetAdmins())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Join"), err)
+	}
+	channelOptBlob = adminChannelID(channelOptBlob, admins)
+
 	// Create a Channel protobuf message to return to the user
-	joinedChannel := &pb.Channel{Id: channelOptBlob, Options: &pb.ChannelOptions{AssetPair: strings.Join(assetPair, "")}}
+	joinedChannel := &pb.Channel{Id: channelOptBlob, Options: &pb.ChannelOptions{
+		AssetPair:   strings.Join(assetPair, ""),
+		Admins:      admins,
+		BatchWindow: in.GetBatchWindow(),
+		Compression: in.GetCompression(),
+	}}
+
+	// Keep the rules set by the channel's admins when joining again
+	if storedChannel := getJoinedChannel(s.Storage, channelOptBlob); storedChannel != nil {
+		joinedChannel.Options.Rules = storedChannel.GetOptions().GetRules()
+	}
+
 	marshaledChannel, err := proto.Marshal(joinedChannel)
 	if !errors.IsEmpty(err) {
 		return nil, errors.E(errors.Op("Join"), err)
 	}
 
-	// Subscribe to a topic matching the options
-	s.P2p.Subscribe(joinedChannel)
+	// Subscribe to a topic matching the options, joining an already joined channel is fine
+	err = s.P2p.Subscribe(joinedChannel)
+	if !errors.IsEmpty(err) && !errors.Is(errors.AlreadyExists, err) {
+		return nil, errors.E(errors.Op("Join"), err)
+	}
 
 	// Store the joined channel in LevelDB
 	s.Storage.Put(getChannelStorageKey(channelOptBlob), marshaledChannel)
@@ -62,6 +83,12 @@ func (s *ChannelService) Join(ctx context.Context, in *pb.JoinRequest) (*pb.Join
 func (s *ChannelService) Leave(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.GenericResponse, error) {
 	channelOptBlob := in.GetId()
 
+	// Stop listening to the channel's topic
+	err := s.P2p.Unsubscribe(&pb.Channel{Id: channelOptBlob})
+	if !errors.IsEmpty(err) && !errors.Is(errors.NotFound, err) {
+		return nil, errors.E(errors.Op("Leave"), err)
+	}
+
 	// Remove the channel from LevelDB
 	s.Storage.Delete(getChannelStorageKey(channelOptBlob))
 
@@ -83,20 +110,116 @@ func (s *ChannelService) GetChannel(ctx context.Context, in *pb.ChannelSpecificR
 
 // GetAllChannels fetches all channels from the database
 func (s *ChannelService) GetAllChannels(ctx context.Context, in *pb.Empty) (*pb.ChannelListResponse, error) {
-	data, err := s.Storage.GetAllWithPrefix(string(interfaces.ChannelPrefix))
-	if !errors.IsEmpty(err) {
-		return nil, errors.E(errors.Op("Get all channels "), err)
-	}
+	iter := s.Storage.NewIterator(&interfaces.IteratorOptions{Prefix: []byte(interfaces.ChannelPrefix)})
+	defer iter.Release()
 
+	// Channels are listed in the order of their IDs, unmarshalled one at a time
 	channels := make([]*pb.Channel, 0)
-	i := 0
-	for _, value := range data {
+	for iter.Next() {
 		channel := &pb.Channel{}
-		proto.Unmarshal([]byte(value), channel)
+		proto.Unmarshal(iter.Value(), channel)
 		channels = append(channels, channel)
-		i++
+	}
+	err := iter.Error()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get all channels"), err)
 	}
 
 	channelListResponse := &pb.ChannelListResponse{Channels: channels}
 	return channelListResponse, nil
 }
+
+// GetChannelPeers lists the peers subscribed to a joined channel
+func (s *ChannelService) GetChannelPeers(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.PeerListResponse, error) {
+	channel, err := s.GetChannel(ctx, in)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel peers"), err)
+	}
+
+	return &pb.PeerListResponse{Peers: s.P2p.GetChannelPeers(channel)}, nil
+}
+
+// GetChannelStats combines the channel's message counters from p2p with its open orders in the database
+func (s *ChannelService) GetChannelStats(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.ChannelStats, error) {
+	channel, err := s.GetChannel(ctx, in)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel stats"), err)
+	}
+
+	// The open orders are found through the channel index instead of reading every order
+	orders := &OrderService{Storage: s.Storage}
+	openOrders, err := orders.QueryOrders(ctx, &pb.OrderQuery{ChannelID: channel.GetId(), States: []pb.State{pb.State_OPEN}})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel stats"), err)
+	}
+
+	stats := s.P2p.GetChannelStats(channel)
+	stats.OpenOrders = uint64(len(openOrders.GetOrders()))
+
+	return stats, nil
+}
+
+// DiscoverChannels lists the channels other nodes have announced on the network, including the ones not joined by this node
+func (s *ChannelService) DiscoverChannels(ctx context.Context, in *pb.Empty) (*pb.ChannelDirectoryResponse, error) {
+	return &pb.ChannelDirectoryResponse{Channels: s.P2p.DiscoverChannels()}, nil
+}
+
+// Moderate signs a moderation message with the node's key, applies it locally and publishes it on the channel.
+// Only the admins listed in the channel's options can moderate it.
+func (s *ChannelService) Moderate(ctx context.Context, in *pb.ModerationRequest) (*pb.GenericResponse, error) {
+	if err := checkWritable(s.P2p, errors.Op("Moderate")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	channel := getJoinedChannel(s.Storage, in.GetChannelID())
+	if channel == nil {
+		return nil, errors.E(errors.Op("Moderate"), "channel not joined")
+	}
+
+	publicKey, err := s.P2p.GetPublicKey()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Moderate"), err)
+	}
+	if !isAdmin(channel, publicKey) {
+		return nil, errors.E(errors.Op("Moderate"), "this node is not an admin of the channel")
+	}
+
+	moderation := &pb.ModerationMessage{
+		Action:    in.GetAction(),
+		OrderID:   in.GetOrderID(),
+		PeerID:    in.GetPeerID(),
+		Rules:     in.GetRules(),
+		Admin:     publicKey,
+		ChannelID: channel.GetId(),
+		Sequence:  nextModerationSequence(s.Storage, channel.GetId(), publicKey),
+	}
+	err = checkModerationScope(s.Storage, channel, moderation)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Moderate"), err)
+	}
+	payload, err := moderationPayload(moderation)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Moderate"), err)
+	}
+	moderation.Signature, err = s.P2p.Sign(payload)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Sign moderation"), err)
+	}
+
+	moderationInBytes, err := proto.Marshal(moderation)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal moderation"), err)
+	}
+	err = s.P2p.Send(&pb.WireMessage{ChannelID: channel.GetId(), Operation: pb.Operation_MODERATE, Data: moderationInBytes})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Send moderation"), err)
+	}
+
+	err = applyModeration(s.Storage, channel, moderation)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Moderate"), err)
+	}
+
+	return &pb.GenericResponse{
+		Error: nil,
+	}, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
@@ -19,7 +20,7 @@ func TestChannelStorageKeyPrefixer(t *testing.T) {
 }
 
 func TestChannelJoining(t *testing.T) {
-	createNewServerInstance()
+	createNewServerInstance(t)
 	defer p2pInstance.Close()
 	defer storage.Close()
 	defer conn.Close()
@@ -69,3 +70,89 @@ func TestChannelJoining(t *testing.T) {
 	_, err = channelClient.Leave(ctx, &pb.ChannelSpecificRequest{Id: lastChannel.GetId()})
 	assert.NoError(t, err)
 }
+
+func TestChannelPeersAndStats(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	orderService.RegisterP2p(p2pInstance)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+
+	// Register channel endpoints with the gRPC server
+	pb.RegisterChannelHandlerServer(s, channelService)
+
+	go func() {
+		if err := s.Serve(lis); !errors.IsEmpty(err) {
+			log.Fatalf("Server exited with error: %v", err)
+		}
+		defer s.Stop()
+	}()
+
+	var channelClient pb.ChannelHandlerClient = pb.NewChannelHandlerClient(conn)
+
+	testOrder := pb.CreateRequest{ChannelID: channel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice}
+	_, err := orderService.Create(ctx, &testOrder)
+	assert.NoError(t, err)
+
+	peers, err := channelClient.GetChannelPeers(ctx, &pb.ChannelSpecificRequest{Id: channel.GetId()})
+	assert.NoError(t, err)
+	assert.NotNil(t, peers)
+
+	stats, err := channelClient.GetChannelStats(ctx, &pb.ChannelSpecificRequest{Id: channel.GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), stats.GetOpenOrders())
+	assert.Equal(t, uint32(len(peers.GetPeers())), stats.GetTopicPeers())
+
+	_, err = channelClient.GetChannelStats(ctx, &pb.ChannelSpecificRequest{Id: []byte("notJoined")})
+	assert.Error(t, err)
+}
+
+func TestChannelDiscovery(t *testing.T) {
+	createNewServerInstance(t)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+
+	// The node doesn't count its own announcement of the joined channel
+	for i := 0; i < 5; i++ {
+		resp, err := channelService.DiscoverChannels(ctx, &pb.Empty{})
+		assert.NoError(t, err)
+		assert.Empty(t, resp.GetChannels())
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestBatchedChannel(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	orderService.RegisterP2p(p2pInstance)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	leaveEveryChannel()
+
+	channelService := &ChannelService{}
+	channelService.RegisterStorage(storage)
+	channelService.RegisterP2p(p2pInstance)
+
+	// The batching window and the compression are kept in the options of the joined channel
+	joined, err := channelService.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: asset2, BatchWindow: 50, Compression: pb.Compression_SNAPPY})
+	assert.NoError(t, err)
+	batchedChannel := joined.GetJoinedChannel()
+	assert.Equal(t, uint32(50), batchedChannel.GetOptions().GetBatchWindow())
+	assert.Equal(t, pb.Compression_SNAPPY, batchedChannel.GetOptions().GetCompression())
+
+	storedChannel, err := channelService.GetChannel(ctx, &pb.ChannelSpecificRequest{Id: batchedChannel.GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(50), storedChannel.GetOptions().GetBatchWindow())
+	assert.Equal(t, pb.Compression_SNAPPY, storedChannel.GetOptions().GetCompression())
+
+	// Orders are published once the batching window ends
+	_, err = orderService.Create(ctx, &pb.CreateRequest{ChannelID: batchedChannel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+
+	_, err = channelService.Leave(ctx, &pb.ChannelSpecificRequest{Id: batchedChannel.GetId()})
+	assert.NoError(t, err)
+}
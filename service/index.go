@@ -0,0 +1,247 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// Secondary indexes of orders. Every index entry is keyed by the index, the indexed value
+// and the order ID, and holds the order ID.
+const (
+	channelIndex = "channel"
+	pairIndex    = "pair"
+	stateIndex   = "state"
+	creatorIndex = "creator"
+	createdIndex = "created"
+	priceIndex   = "price"
+)
+
+// indexSeparator ends the indexed value in an index key. Values are hex encoded, so they never contain it.
+const indexSeparator = "-"
+
+// indexValueEnd sorts right after indexSeparator, making it an inclusive upper bound of an indexed value
+const indexValueEnd = "."
+
+// orderIndexVersion is the version of the secondary indexes, changed whenever their entries change
+const orderIndexVersion = "1"
+
+// orderIndexVersionKey holds the version of the stored indexes. It's outside of interfaces.OrderIndexPrefix,
+// so it's never mistaken for an index entry.
+const orderIndexVersionKey = "orderindexversion"
+
+// rebuildChunkSize is the number of entries written in a single batch while rebuilding the indexes
+const rebuildChunkSize = 1000
+
+// orderWriteLocks serializes the order writes of each storage, so the stored order read to find its old
+// index entries can't change before the batch replacing them is committed. A lock is used instead of
+// a storage transaction, since opening a LevelDB transaction flushes its memtable to disk every time.
+var orderWriteLocks = struct {
+	sync.Mutex
+	locks map[interfaces.Storage]*sync.Mutex
+}{locks: make(map[interfaces.Storage]*sync.Mutex)}
+
+// lockOrderWrites locks the order writes of a storage, returning the function unlocking them
+func lockOrderWrites(storage interfaces.Storage) func() {
+	orderWriteLocks.Lock()
+	lock, ok := orderWriteLocks.locks[storage]
+	if !ok {
+		lock = &sync.Mutex{}
+		orderWriteLocks.locks[storage] = lock
+	}
+	orderWriteLocks.Unlock()
+	lock.Lock()
+	return lock.Unlock
+}
+
+// getIndexPrefix returns the prefix of the entries of an index
+func getIndexPrefix(index string) string {
+	return string(interfaces.OrderIndexPrefix) + index + indexSeparator
+}
+
+// getIndexValuePrefix returns the prefix of the entries of an index with the same value
+func getIndexValuePrefix(index string, value string) string {
+	return getIndexPrefix(index) + value + indexSeparator
+}
+
+func encodeIndexValue(values ...string) string {
+	encoded := ""
+	for i, value := range values {
+		if i > 0 {
+			encoded += "."
+		}
+		encoded += hex.EncodeToString([]byte(value))
+	}
+	return encoded
+}
+
+// encodeState encodes a state so the entries of every state can be found by their prefix
+func encodeState(state pb.State) string {
+	return fmt.Sprintf("%08x", uint32(state))
+}
+
+// encodeCreated encodes the creation time of an order so it sorts chronologically
+func encodeCreated(seconds int64, nanos int32) string {
+	return fmt.Sprintf("%016x%08x", uint64(seconds)^(1<<63), uint32(nanos))
+}
+
+// encodePrice encodes a price so it sorts numerically, negative prices included
+func encodePrice(price float32) string {
+	bits := math.Float32bits(price)
+	if bits&(1<<31) != 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 31
+	}
+	return fmt.Sprintf("%08x", bits)
+}
+
+// getOrderIndexKeys returns the keys of every index entry of an order
+func getOrderIndexKeys(order *pb.Order) [][]byte {
+	id := hex.EncodeToString(order.GetId())
+	values := map[string]string{
+		channelIndex: encodeIndexValue(string(order.GetChannelID())),
+		pairIndex:    encodeIndexValue(order.GetAsset(), order.GetCounterAsset()),
+		stateIndex:   encodeState(order.GetState()),
+		creatorIndex: encodeIndexValue(order.GetCreator()),
+		createdIndex: encodeCreated(order.GetCreated().GetSeconds(), order.GetCreated().GetNanos()),
+		priceIndex:   encodePrice(order.GetPrice()),
+	}
+	keys := make([][]byte, 0, len(values))
+	for index, value := range values {
+		keys = append(keys, []byte(getIndexValuePrefix(index, value)+id))
+	}
+	return keys
+}
+
+// getStoredOrder returns the stored order with the ID, or nil if there's none
+func getStoredOrder(storage interfaces.Storage, orderID []byte) *pb.Order {
+	data, err := storage.Get(getOrderStorageKey(orderID))
+	if !errors.IsEmpty(err) {
+		return nil
+	}
+	order := &pb.Order{}
+	if !errors.IsEmpty(proto.Unmarshal(data, order)) {
+		return nil
+	}
+	return order
+}
+
+// putOrder stores a marshalled order together with its index entries in a single batch,
+// removing the entries of the order it replaces
+func putOrder(storage interfaces.Storage, order *pb.Order, data []byte) error {
+	defer lockOrderWrites(storage)()
+
+	batch := storage.NewBatch()
+	if stored := getStoredOrder(storage, order.GetId()); stored != nil {
+		for _, key := range getOrderIndexKeys(stored) {
+			batch.Delete(key)
+		}
+	}
+	batch.Put(getOrderStorageKey(order.GetId()), data)
+	for _, key := range getOrderIndexKeys(order) {
+		batch.Put(key, order.GetId())
+	}
+	err := batch.Commit()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put order"), err)
+	}
+	return nil
+}
+
+// deleteOrder removes an order together with its index entries in a single batch
+func deleteOrder(storage interfaces.Storage, orderID []byte) error {
+	defer lockOrderWrites(storage)()
+
+	batch := storage.NewBatch()
+	batchDeleteOrder(storage, batch, orderID)
+	err := batch.Commit()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Delete order"), err)
+	}
+	return nil
+}
+
+// batchDeleteOrder adds the removal of an order and its index entries to a batch.
+// The caller holds the order write lock until the batch is committed.
+func batchDeleteOrder(storage interfaces.Storage, batch interfaces.Batch, orderID []byte) {
+	if stored := getStoredOrder(storage, orderID); stored != nil {
+		for _, key := range getOrderIndexKeys(stored) {
+			batch.Delete(key)
+		}
+	}
+	batch.Delete(getOrderStorageKey(orderID))
+}
+
+// UpdateIndexes rebuilds the secondary indexes of the orders if they're missing or were built by an older version
+func (s *OrderService) UpdateIndexes() error {
+	version, err := s.Storage.Get([]byte(orderIndexVersionKey))
+	if errors.IsEmpty(err) && string(version) == orderIndexVersion {
+		return nil
+	}
+	if s.Logger != nil {
+		s.Logger.Infof("Rebuilding the order indexes to version %s", orderIndexVersion)
+	}
+	return s.RebuildIndexes()
+}
+
+// RebuildIndexes drops the secondary indexes of the orders and builds them again from the stored orders,
+// committing rebuildChunkSize entries at a time. The index version is stored once every order is indexed,
+// so an interrupted rebuild is started over.
+func (s *OrderService) RebuildIndexes() error {
+	defer lockOrderWrites(s.Storage)()
+
+	err := s.Storage.Delete([]byte(orderIndexVersionKey))
+	if errors.IsEmpty(err) {
+		err = writeInChunks(s.Storage, string(interfaces.OrderIndexPrefix), func(batch interfaces.Batch, key []byte, value []byte) {
+			batch.Delete(key)
+		})
+	}
+	if errors.IsEmpty(err) {
+		err = writeInChunks(s.Storage, string(interfaces.OrderPrefix), func(batch interfaces.Batch, key []byte, value []byte) {
+			order := &pb.Order{}
+			if !errors.IsEmpty(proto.Unmarshal(value, order)) {
+				return
+			}
+			for _, key := range getOrderIndexKeys(order) {
+				batch.Put(key, order.GetId())
+			}
+		})
+	}
+	if errors.IsEmpty(err) {
+		err = s.Storage.Put([]byte(orderIndexVersionKey), []byte(orderIndexVersion))
+	}
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Rebuild indexes"), err)
+	}
+	return nil
+}
+
+// writeInChunks passes every entry with the prefix to write, committing the batch every rebuildChunkSize entries
+func writeInChunks(storage interfaces.Storage, prefix string, write func(batch interfaces.Batch, key []byte, value []byte)) error {
+	iter := storage.NewIterator(&interfaces.IteratorOptions{Prefix: []byte(prefix)})
+	defer iter.Release()
+	batch := storage.NewBatch()
+	for count := 1; iter.Next(); count++ {
+		write(batch, iter.Key(), iter.Value())
+		if count%rebuildChunkSize == 0 {
+			err := batch.Commit()
+			if !errors.IsEmpty(err) {
+				return err
+			}
+			batch = storage.NewBatch()
+		}
+	}
+	err := iter.Error()
+	if !errors.IsEmpty(err) {
+		batch.Discard()
+		return err
+	}
+	return batch.Commit()
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	crypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// adminChannelIDLength is the amount of bytes of the admin key hash appended to a moderated channel's ID
+const adminChannelIDLength = 16
+
+func getBanStorageKey(channelID []byte, peerID string) []byte {
+	return []byte(strings.Join([]string{string(interfaces.BanPrefix), string(channelID), "/", peerID}, ""))
+}
+
+func getModerationSequenceKey(channelID []byte, admin []byte) []byte {
+	hash := sha256.Sum256(admin)
+	return []byte(strings.Join([]string{string(interfaces.ModerationPrefix), string(channelID), "/", hex.EncodeToString(hash[:])}, ""))
+}
+
+// getModerationSequence returns the sequence number of the last moderation message of an admin applied to a channel
+func getModerationSequence(storage interfaces.Storage, channelID []byte, admin []byte) uint64 {
+	data, err := storage.Get(getModerationSequenceKey(channelID, admin))
+	if !errors.IsEmpty(err) {
+		return 0
+	}
+	sequence, err := strconv.ParseUint(string(data), 10, 64)
+	if !errors.IsEmpty(err) {
+		return 0
+	}
+	return sequence
+}
+
+// nextModerationSequence returns the sequence number of a new moderation message of an admin.
+// It's the current time, unless the clock is behind the last message of the admin.
+func nextModerationSequence(storage interfaces.Storage, channelID []byte, admin []byte) uint64 {
+	sequence := uint64(time.Now().UnixNano())
+	if last := getModerationSequence(storage, channelID, admin); sequence <= last {
+		sequence = last + 1
+	}
+	return sequence
+}
+
+// checkModerationSequence rejects moderation messages that replay or are older than
+// the last message of the same admin applied to the channel
+func checkModerationSequence(storage interfaces.Storage, channel *pb.Channel, moderation *pb.ModerationMessage) error {
+	if moderation.GetSequence() <= getModerationSequence(storage, channel.GetId(), moderation.GetAdmin()) {
+		return errors.E(errors.Op("Check moderation sequence"), errors.AlreadyExists, "moderation message is stale or replayed")
+	}
+	return nil
+}
+
+// sortAdmins validates and sorts the admin public keys of a channel
+func sortAdmins(admins [][]byte) ([][]byte, error) {
+	sorted := make([][]byte, 0, len(admins))
+	for _, admin := range admins {
+		_, err := crypto.UnmarshalPublicKey(admin)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Unmarshal admin public key"), err)
+		}
+		sorted = append(sorted, admin)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	return sorted, nil
+}
+
+// adminChannelID separates moderated channels from the admin-less channel of the same asset pair
+func adminChannelID(channelOptBlob []byte, admins [][]byte) []byte {
+	if len(admins) == 0 {
+		return channelOptBlob
+	}
+	hash := sha256.Sum256(bytes.Join(admins, nil))
+	return []byte(strings.Join([]string{string(channelOptBlob), hex.EncodeToString(hash[:adminChannelIDLength])}, ","))
+}
+
+func isAdmin(channel *pb.Channel, publicKey []byte) bool {
+	for _, admin := range channel.GetOptions().GetAdmins() {
+		if bytes.Equal(admin, publicKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// moderationPayload returns the bytes a moderation message's signature is calculated over
+func moderationPayload(moderation *pb.ModerationMessage) ([]byte, error) {
+	unsigned := proto.Clone(moderation).(*pb.ModerationMessage)
+	unsigned.Signature = nil
+	return proto.Marshal(unsigned)
+}
+
+// verifyModeration checks that a moderation message is signed by one of the channel's admins for the channel.
+// The channel ID is part of the signed payload, so a message can't be replayed on another channel of the same admin.
+func verifyModeration(channel *pb.Channel, moderation *pb.ModerationMessage) error {
+	if len(channel.GetOptions().GetAdmins()) == 0 {
+		return errors.E(errors.Op("Verify moderation"), "channel has no admins")
+	}
+	if string(moderation.GetChannelID()) != string(channel.GetId()) {
+		return errors.E(errors.Op("Verify moderation"), "moderation message is for another channel")
+	}
+	if !isAdmin(channel, moderation.GetAdmin()) {
+		return errors.E(errors.Op("Verify moderation"), "moderation message is not from a channel admin")
+	}
+	publicKey, err := crypto.UnmarshalPublicKey(moderation.GetAdmin())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal admin public key"), err)
+	}
+	payload, err := moderationPayload(moderation)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal moderation payload"), err)
+	}
+	ok, err := publicKey.Verify(payload, moderation.GetSignature())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Verify moderation signature"), err)
+	}
+	if !ok {
+		return errors.E(errors.Op("Verify moderation"), "invalid moderation signature")
+	}
+	return nil
+}
+
+// checkModerationScope checks that a moderation message only touches orders of the channel it's published on,
+// since the admins of one channel have no say over the orders of another
+func checkModerationScope(storage interfaces.Storage, channel *pb.Channel, moderation *pb.ModerationMessage) error {
+	if moderation.GetAction() != pb.ModerationAction_REMOVE_ORDER {
+		return nil
+	}
+	order := getStoredOrder(storage, moderation.GetOrderID())
+	if order == nil {
+		return errors.E(errors.Op("Check moderation scope"), errors.NotFound, "order not found")
+	}
+	if string(order.GetChannelID()) != string(channel.GetId()) {
+		return errors.E(errors.Op("Check moderation scope"), errors.Invalid, "order belongs to another channel")
+	}
+	return nil
+}
+
+// applyModeration applies a verified moderation message to the local database, and records its sequence number.
+// The action and the sequence number are written in a single batch, so a failed write can't leave
+// the action applied without its sequence number, letting the message be replayed.
+func applyModeration(storage interfaces.Storage, channel *pb.Channel, moderation *pb.ModerationMessage) error {
+	var err error
+	batch := storage.NewBatch()
+	switch moderation.GetAction() {
+	case pb.ModerationAction_REMOVE_ORDER:
+		err = checkModerationScope(storage, channel, moderation)
+		if errors.IsEmpty(err) {
+			defer lockOrderWrites(storage)()
+			batchDeleteOrder(storage, batch, moderation.GetOrderID())
+		}
+	case pb.ModerationAction_BAN_PEER:
+		batch.Put(getBanStorageKey(channel.GetId(), moderation.GetPeerID()), []byte(moderation.GetPeerID()))
+	case pb.ModerationAction_UPDATE_RULES:
+		channel.Options.Rules = moderation.GetRules()
+		var marshaledChannel []byte
+		marshaledChannel, err = proto.Marshal(channel)
+		if errors.IsEmpty(err) {
+			batch.Put(getChannelStorageKey(channel.GetId()), marshaledChannel)
+		}
+	}
+	if errors.IsEmpty(err) {
+		sequence := strconv.FormatUint(moderation.GetSequence(), 10)
+		batch.Put(getModerationSequenceKey(channel.GetId(), moderation.GetAdmin()), []byte(sequence))
+		err = batch.Commit()
+	}
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Apply moderation"), err)
+	}
+	return nil
+}
+
+// isBanned checks whether a channel's admins have banned the peer
+func isBanned(storage interfaces.Storage, channelID []byte, peerID string) (bool, error) {
+	if peerID == "" {
+		return false, nil
+	}
+	return storage.Has(getBanStorageKey(channelID, peerID))
+}
+
+// checkRules checks an order against the rules set by the channel's admins
+func checkRules(channel *pb.Channel, order *pb.Order) error {
+	rules := channel.GetOptions().GetRules()
+	if rules.GetMinAmount() != 0 && order.GetAmount() < rules.GetMinAmount() {
+		return errors.E(errors.Op("Check channel rules"), "order amount is below the channel minimum")
+	}
+	if rules.GetMaxAmount() != 0 && order.GetAmount() > rules.GetMaxAmount() {
+		return errors.E(errors.Op("Check channel rules"), "order amount is above the channel maximum")
+	}
+	return nil
+}
+
+// getJoinedChannel fetches a joined channel from storage, returning nil if this node hasn't joined the channel
+func getJoinedChannel(storage interfaces.Storage, channelID []byte) *pb.Channel {
+	data, err := storage.Get(getChannelStorageKey(channelID))
+	if !errors.IsEmpty(err) {
+		return nil
+	}
+	channel := &pb.Channel{}
+	err = proto.Unmarshal(data, channel)
+	if !errors.IsEmpty(err) {
+		return nil
+	}
+	return channel
+}
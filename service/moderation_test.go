@@ -0,0 +1,203 @@
+package service
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	crypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const bannedPeer string = "bannedPeer"
+const otherPeer string = "otherPeer"
+
+func removeAllModeration() {
+	storage.DeleteAllWithPrefix(string(interfaces.BanPrefix))
+	storage.DeleteAllWithPrefix(string(interfaces.ModerationPrefix))
+}
+
+func joinModeratedChannel(t *testing.T) *pb.Channel {
+	publicKey, err := p2pInstance.GetPublicKey()
+	assert.NoError(t, err)
+	joinres, err := channelService.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: asset2, Admins: [][]byte{publicKey}})
+	assert.NoError(t, err)
+	return joinres.GetJoinedChannel()
+}
+
+func createWireMessage(t *testing.T, channelID []byte, amount uint64) []byte {
+	order := &pb.Order{Id: []byte(otherPeer), Asset: asset1, CounterAsset: asset2, Amount: amount, ChannelID: channelID}
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	wireMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_CREATE, Data: orderInBytes})
+	assert.NoError(t, err)
+	return wireMessage
+}
+
+func TestModeratedChannelJoin(t *testing.T) {
+	createNewServerInstance(t)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	leaveEveryChannel()
+
+	moderatedChannel := joinModeratedChannel(t)
+	assert.NotEqual(t, channel.GetId(), moderatedChannel.GetId())
+	assert.Equal(t, 1, len(moderatedChannel.GetOptions().GetAdmins()))
+
+	_, err := channelService.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: asset2, Admins: [][]byte{[]byte("notAKey")}})
+	assert.Error(t, err)
+
+	// Admin-less channels can't be moderated
+	_, err = channelService.Moderate(ctx, &pb.ModerationRequest{ChannelID: channel.GetId(), Action: pb.ModerationAction_BAN_PEER, PeerID: bannedPeer})
+	assert.Error(t, err)
+}
+
+func TestModeration(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	orderService.RegisterP2p(p2pInstance)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+	leaveEveryChannel()
+	removeAllModeration()
+
+	moderatedChannel := joinModeratedChannel(t)
+
+	// Remove an order
+	resp, err := orderService.Create(ctx, &pb.CreateRequest{ChannelID: moderatedChannel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	_, err = channelService.Moderate(ctx, &pb.ModerationRequest{ChannelID: moderatedChannel.GetId(), Action: pb.ModerationAction_REMOVE_ORDER, OrderID: resp.GetCreatedOrder().GetId()})
+	assert.NoError(t, err)
+	_, err = orderService.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: resp.GetCreatedOrder().GetId()})
+	assert.Error(t, err)
+
+	// Orders of other channels are out of reach of the channel's admins
+	resp, err = orderService.Create(ctx, &pb.CreateRequest{ChannelID: channel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	_, err = channelService.Moderate(ctx, &pb.ModerationRequest{ChannelID: moderatedChannel.GetId(), Action: pb.ModerationAction_REMOVE_ORDER, OrderID: resp.GetCreatedOrder().GetId()})
+	assert.True(t, errors.Is(errors.Invalid, err))
+	moderation := &pb.ModerationMessage{Action: pb.ModerationAction_REMOVE_ORDER, OrderID: resp.GetCreatedOrder().GetId()}
+	assert.True(t, errors.Is(errors.Invalid, applyModeration(storage, moderatedChannel, moderation)))
+	_, err = orderService.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: resp.GetCreatedOrder().GetId()})
+	assert.NoError(t, err)
+
+	// Ban a peer
+	_, err = channelService.Moderate(ctx, &pb.ModerationRequest{ChannelID: moderatedChannel.GetId(), Action: pb.ModerationAction_BAN_PEER, PeerID: bannedPeer})
+	assert.NoError(t, err)
+	assert.Error(t, orderService.Receive(createWireMessage(t, moderatedChannel.GetId(), testAmount), bannedPeer))
+	assert.NoError(t, orderService.Receive(createWireMessage(t, moderatedChannel.GetId(), testAmount), otherPeer))
+
+	// Update the rules
+	_, err = channelService.Moderate(ctx, &pb.ModerationRequest{ChannelID: moderatedChannel.GetId(), Action: pb.ModerationAction_UPDATE_RULES, Rules: &pb.ChannelRules{MinAmount: testAmount}})
+	assert.NoError(t, err)
+	storedChannel, err := channelService.GetChannel(ctx, &pb.ChannelSpecificRequest{Id: moderatedChannel.GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(testAmount), storedChannel.GetOptions().GetRules().GetMinAmount())
+	assert.Error(t, orderService.Receive(createWireMessage(t, moderatedChannel.GetId(), 1), otherPeer))
+	assert.NoError(t, orderService.Receive(createWireMessage(t, moderatedChannel.GetId(), testAmount), otherPeer))
+}
+
+// failingCommitStorage is a Storage whose batches fail to commit
+type failingCommitStorage struct {
+	interfaces.Storage
+}
+
+func (storage failingCommitStorage) NewBatch() interfaces.Batch {
+	return failingCommitBatch{storage.Storage.NewBatch()}
+}
+
+type failingCommitBatch struct {
+	interfaces.Batch
+}
+
+func (batch failingCommitBatch) Commit() error {
+	batch.Discard()
+	return errors.E(errors.Op("Commit batch"), errors.Unavailable, "disk full")
+}
+
+func TestApplyModerationAtomically(t *testing.T) {
+	memory := &inmemory.Storage{Db: make(map[string]string)}
+	moderatedChannel := &pb.Channel{Id: []byte("moderatedChannel"), Options: &pb.ChannelOptions{}}
+	moderation := &pb.ModerationMessage{Action: pb.ModerationAction_BAN_PEER, PeerID: bannedPeer, Admin: []byte("admin"), Sequence: 1}
+
+	// A failed write leaves neither the ban nor the sequence number behind
+	assert.True(t, errors.Is(errors.Unavailable, applyModeration(failingCommitStorage{memory}, moderatedChannel, moderation)))
+	assert.Empty(t, memory.Db)
+
+	assert.NoError(t, applyModeration(memory, moderatedChannel, moderation))
+	banned, err := isBanned(memory, moderatedChannel.GetId(), bannedPeer)
+	assert.NoError(t, err)
+	assert.True(t, banned)
+	sequence, err := memory.Get(getModerationSequenceKey(moderatedChannel.GetId(), moderation.GetAdmin()))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", string(sequence))
+}
+
+func TestReceiveModeration(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	leaveEveryChannel()
+	removeAllModeration()
+
+	moderatedChannel := joinModeratedChannel(t)
+
+	sign := func(signer func([]byte) ([]byte, error), admin []byte, channelID []byte, sequence uint64) []byte {
+		moderation := &pb.ModerationMessage{Action: pb.ModerationAction_BAN_PEER, PeerID: bannedPeer, Admin: admin, ChannelID: channelID, Sequence: sequence}
+		payload, err := moderationPayload(moderation)
+		assert.NoError(t, err)
+		moderation.Signature, err = signer(payload)
+		assert.NoError(t, err)
+		moderationInBytes, err := proto.Marshal(moderation)
+		assert.NoError(t, err)
+		wireMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: moderatedChannel.GetId(), Operation: pb.Operation_MODERATE, Data: moderationInBytes})
+		assert.NoError(t, err)
+		return wireMessage
+	}
+
+	otherPrivateKey, otherPublicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	otherAdmin, err := crypto.MarshalPublicKey(otherPublicKey)
+	assert.NoError(t, err)
+	admin, err := p2pInstance.GetPublicKey()
+	assert.NoError(t, err)
+
+	// Moderation by a key that isn't an admin of the channel
+	assert.Error(t, orderService.Receive(sign(otherPrivateKey.Sign, otherAdmin, moderatedChannel.GetId(), 1), otherPeer))
+	// Moderation claiming to be from an admin, but signed with another key
+	assert.Error(t, orderService.Receive(sign(otherPrivateKey.Sign, admin, moderatedChannel.GetId(), 1), otherPeer))
+	// Moderation signed for another channel of the admin
+	assert.Error(t, orderService.Receive(sign(p2pInstance.Sign, admin, channel.GetId(), 1), otherPeer))
+
+	banned, err := isBanned(storage, moderatedChannel.GetId(), bannedPeer)
+	assert.NoError(t, err)
+	assert.False(t, banned)
+
+	message := sign(p2pInstance.Sign, admin, moderatedChannel.GetId(), 5)
+	assert.NoError(t, orderService.Validate(message, otherPeer))
+	assert.NoError(t, orderService.Receive(message, otherPeer))
+	banned, err = isBanned(storage, moderatedChannel.GetId(), bannedPeer)
+	assert.NoError(t, err)
+	assert.True(t, banned)
+
+	// Replayed and older moderation messages are refused
+	err = orderService.Validate(message, otherPeer)
+	assert.True(t, errors.Is(errors.AlreadyExists, err))
+	assert.Error(t, orderService.Receive(message, otherPeer))
+	err = orderService.Validate(sign(p2pInstance.Sign, admin, moderatedChannel.GetId(), 4), otherPeer)
+	assert.True(t, errors.Is(errors.AlreadyExists, err))
+	assert.NoError(t, orderService.Validate(sign(p2pInstance.Sign, admin, moderatedChannel.GetId(), 6), otherPeer))
+
+	// Moderating through the API continues from the last sequence number
+	assert.True(t, nextModerationSequence(storage, moderatedChannel.GetId(), admin) > 5)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// Take asks the creator of an order to lock it for this node. A rejection by the creator
+// isn't an error, and is returned with the reason given by the creator.
+func (s *OrderService) Take(ctx context.Context, in *pb.TakeRequest) (*pb.TakeResponse, error) {
+	if s.P2p == nil {
+		return nil, errors.E(errors.Op("Take order"), "P2p service not registered with OrderService")
+	}
+	if err := checkWritable(s.P2p, errors.Op("Take order")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	order, err := s.getOrder(in.GetOrderID())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Take order"), err)
+	}
+	if order.GetCreator() == "" {
+		return nil, errors.E(errors.Op("Take order"), "order has no creator")
+	}
+	if order.GetCreator() == s.P2p.GetPeerID() {
+		return nil, errors.E(errors.Op("Take order"), "can't take an order created by this node")
+	}
+	if order.GetState() != pb.State_OPEN {
+		return nil, errors.E(errors.Op("Take order"), "order is locked")
+	}
+
+	amount := in.GetAmount()
+	if amount == 0 {
+		amount = order.GetAmount()
+	}
+	response, err := s.P2p.RequestTake(ctx, order.GetCreator(), &pb.NegotiationMessage{
+		OrderID:   order.GetId(),
+		ChannelID: order.GetChannelID(),
+		Amount:    amount,
+	})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Take order"), err)
+	}
+	if response.GetType() == pb.NegotiationType_REJECT {
+		return &pb.TakeResponse{Accepted: false, Reason: response.GetReason()}, nil
+	}
+
+	// The creator broadcasts the lock as well, but the taker knows about it first
+	order.State = pb.State_LOCKED
+	orderInBytes, err := proto.Marshal(order)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal order"), err)
+	}
+	err = putOrder(s.Storage, order, orderInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Put order"), err)
+	}
+	return &pb.TakeResponse{Accepted: true, LockedOrder: order}, nil
+}
+
+// Negotiate answers a take request sent by another peer. Open orders created by this node are
+// locked for the peer: the request is accepted, the lock is broadcast and then confirmed to the peer.
+// Every other request is rejected with a reason.
+func (s *OrderService) Negotiate(request *pb.NegotiationMessage, from string, respond func(*pb.NegotiationMessage) error) error {
+	reject := func(reason string) error {
+		return respond(&pb.NegotiationMessage{Type: pb.NegotiationType_REJECT, OrderID: request.GetOrderID(), Reason: reason})
+	}
+
+	if s.Storage == nil {
+		return reject("orders are not stored by this node")
+	}
+
+	// Only this order is held while the peer is answered and the lock is broadcast
+	defer s.lockOrderState(request.GetOrderID())()
+
+	order, err := s.getOwnOrder(request.GetOrderID())
+	if !errors.IsEmpty(err) {
+		return reject("order is not created by this node")
+	}
+	if order.GetState() != pb.State_OPEN {
+		return reject("order is locked")
+	}
+	if request.GetAmount() > order.GetAmount() {
+		return reject("requested amount exceeds the order amount")
+	}
+	if channel := getJoinedChannel(s.Storage, order.GetChannelID()); channel != nil {
+		banned, err := isBanned(s.Storage, channel.GetId(), from)
+		if errors.IsEmpty(err) && banned {
+			return reject("peer is banned from the channel")
+		}
+	}
+
+	err = respond(&pb.NegotiationMessage{Type: pb.NegotiationType_ACCEPT, OrderID: order.GetId(), ChannelID: order.GetChannelID(), Amount: request.GetAmount()})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Accept take request"), err)
+	}
+
+	err = s.setOrderState(order, pb.State_LOCKED)
+	if !errors.IsEmpty(err) {
+		reject("locking the order failed")
+		return errors.E(errors.Op("Lock taken order"), err)
+	}
+	if s.Logger != nil {
+		s.Logger.Infof("Locked order %x for peer %s", order.GetId(), from)
+	}
+	return respond(&pb.NegotiationMessage{Type: pb.NegotiationType_LOCK_CONFIRMATION, OrderID: order.GetId(), ChannelID: order.GetChannelID(), Amount: request.GetAmount(), Order: order})
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func negotiate(t *testing.T, orderID []byte) []*pb.NegotiationMessage {
+	responses := make([]*pb.NegotiationMessage, 0)
+	err := orderService.Negotiate(&pb.NegotiationMessage{Type: pb.NegotiationType_TAKE_REQUEST, OrderID: orderID, Amount: testAmount}, otherPeer, func(response *pb.NegotiationMessage) error {
+		responses = append(responses, response)
+		return nil
+	})
+	assert.NoError(t, err)
+	return responses
+}
+
+func TestOrderLocking(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	orderService.RegisterP2p(p2pInstance)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+
+	resp, err := orderService.Create(ctx, &pb.CreateRequest{ChannelID: channel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	orderID := resp.GetCreatedOrder().GetId()
+	assert.Equal(t, p2pInstance.GetPeerID(), resp.GetCreatedOrder().GetCreator())
+
+	_, err = orderService.Lock(ctx, &pb.OrderSpecificRequest{OrderID: orderID})
+	assert.NoError(t, err)
+	order, err := orderService.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: orderID})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, order.GetState())
+	_, err = orderService.Lock(ctx, &pb.OrderSpecificRequest{OrderID: orderID})
+	assert.Error(t, err)
+
+	_, err = orderService.Unlock(ctx, &pb.OrderSpecificRequest{OrderID: orderID})
+	assert.NoError(t, err)
+	order, err = orderService.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: orderID})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_OPEN, order.GetState())
+	_, err = orderService.Unlock(ctx, &pb.OrderSpecificRequest{OrderID: orderID})
+	assert.Error(t, err)
+
+	// Orders created by other peers can't be locked
+	assert.NoError(t, orderService.Receive(createWireMessage(t, channel.GetId(), testAmount), otherPeer))
+	_, err = orderService.Lock(ctx, &pb.OrderSpecificRequest{OrderID: []byte(otherPeer)})
+	assert.Error(t, err)
+}
+
+func TestNegotiate(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	orderService.RegisterP2p(p2pInstance)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+	removeAllModeration()
+
+	resp, err := orderService.Create(ctx, &pb.CreateRequest{ChannelID: channel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	orderID := resp.GetCreatedOrder().GetId()
+
+	responses := negotiate(t, orderID)
+	assert.Equal(t, 2, len(responses))
+	assert.Equal(t, pb.NegotiationType_ACCEPT, responses[0].GetType())
+	assert.Equal(t, pb.NegotiationType_LOCK_CONFIRMATION, responses[1].GetType())
+	assert.Equal(t, pb.State_LOCKED, responses[1].GetOrder().GetState())
+
+	// Locked orders can't be taken again
+	responses = negotiate(t, orderID)
+	assert.Equal(t, 1, len(responses))
+	assert.Equal(t, pb.NegotiationType_REJECT, responses[0].GetType())
+
+	responses = negotiate(t, []byte("unknownOrder"))
+	assert.Equal(t, pb.NegotiationType_REJECT, responses[0].GetType())
+}
+
+func TestNegotiateOrdersConcurrently(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	orderService.RegisterP2p(p2pInstance)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+	removeAllModeration()
+
+	first, err := orderService.Create(ctx, &pb.CreateRequest{ChannelID: channel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	second, err := orderService.Create(ctx, &pb.CreateRequest{ChannelID: channel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+
+	// A peer slow to read the answer about one order doesn't hold up the takers of other orders
+	accepted := make(chan bool)
+	release := make(chan bool)
+	firstTaken := make(chan error, 1)
+	go func() {
+		firstTaken <- orderService.Negotiate(&pb.NegotiationMessage{Type: pb.NegotiationType_TAKE_REQUEST, OrderID: first.GetCreatedOrder().GetId(), Amount: testAmount}, otherPeer, func(response *pb.NegotiationMessage) error {
+			if response.GetType() == pb.NegotiationType_ACCEPT {
+				accepted <- true
+				<-release
+			}
+			return nil
+		})
+	}()
+	<-accepted
+	secondTaken := make(chan []*pb.NegotiationMessage, 1)
+	go func() {
+		secondTaken <- negotiate(t, second.GetCreatedOrder().GetId())
+	}()
+	select {
+	case responses := <-secondTaken:
+		assert.Equal(t, pb.NegotiationType_LOCK_CONFIRMATION, responses[len(responses)-1].GetType())
+	case <-time.After(5 * time.Second):
+		t.Error("taking an order waited for the negotiation of another one")
+	}
+	close(release)
+	assert.NoError(t, <-firstTaken)
+}
+
+func TestTake(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	orderService.RegisterP2p(p2pInstance)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+
+	resp, err := orderService.Create(ctx, &pb.CreateRequest{ChannelID: channel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+
+	// Orders created by this node can't be taken
+	_, err = orderService.Take(ctx, &pb.TakeRequest{OrderID: resp.GetCreatedOrder().GetId()})
+	assert.Error(t, err)
+	_, err = orderService.Take(ctx, &pb.TakeRequest{OrderID: []byte("unknownOrder")})
+	assert.Error(t, err)
+}
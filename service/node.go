@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// NodeService implements the NodeHandlerServer service.proto
+type NodeService struct {
+	P2p interfaces.P2p
+}
+
+// RegisterP2p registers a p2p service
+func (s *NodeService) RegisterP2p(p2p interfaces.P2p) {
+	s.P2p = p2p
+}
+
+// BanPeer refuses all connections and messages from a peer, also after restarting the node
+func (s *NodeService) BanPeer(ctx context.Context, in *pb.PeerSpecificRequest) (*pb.GenericResponse, error) {
+	if err := checkWritable(s.P2p, errors.Op("Ban peer")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	err := s.P2p.BanPeer(in.GetPeerID())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Ban peer"), err)
+	}
+	return &pb.GenericResponse{
+		Error: nil,
+	}, nil
+}
+
+// UnbanPeer accepts connections and messages from a banned or blacklisted peer again
+func (s *NodeService) UnbanPeer(ctx context.Context, in *pb.PeerSpecificRequest) (*pb.GenericResponse, error) {
+	if err := checkWritable(s.P2p, errors.Op("Unban peer")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	err := s.P2p.UnbanPeer(in.GetPeerID())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unban peer"), err)
+	}
+	return &pb.GenericResponse{
+		Error: nil,
+	}, nil
+}
+
+// ListBannedPeers lists the IDs of the banned peers
+func (s *NodeService) ListBannedPeers(ctx context.Context, in *pb.Empty) (*pb.PeerListResponse, error) {
+	return &pb.PeerListResponse{Peers: s.P2p.GetBannedPeers()}, nil
+}
+
+// GetConnectionStats reports the open connections of the node and the limits of its connection manager
+func (s *NodeService) GetConnectionStats(ctx context.Context, in *pb.Empty) (*pb.ConnectionStats, error) {
+	return s.P2p.GetConnectionStats(), nil
+}
+
+// GetNodeInfo describes the identity, the addresses and the protocol versions of the node
+func (s *NodeService) GetNodeInfo(ctx context.Context, in *pb.Empty) (*pb.NodeInfo, error) {
+	return s.P2p.GetNodeInfo(), nil
+}
+
+// ListPeers lists the connected peers with their latency, protocols and connection direction
+func (s *NodeService) ListPeers(ctx context.Context, in *pb.Empty) (*pb.PeerInfoListResponse, error) {
+	return &pb.PeerInfoListResponse{Peers: s.P2p.GetPeers()}, nil
+}
+
+// ConnectPeer connects to a peer given its multiaddress or its ID
+func (s *NodeService) ConnectPeer(ctx context.Context, in *pb.ConnectPeerRequest) (*pb.GenericResponse, error) {
+	if err := checkWritable(s.P2p, errors.Op("Connect peer")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	err := s.P2p.ConnectPeer(ctx, in.GetAddress())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Connect peer"), err)
+	}
+	return &pb.GenericResponse{
+		Error: nil,
+	}, nil
+}
+
+// DisconnectPeer closes the connections to a peer
+func (s *NodeService) DisconnectPeer(ctx context.Context, in *pb.PeerSpecificRequest) (*pb.GenericResponse, error) {
+	if err := checkWritable(s.P2p, errors.Op("Disconnect peer")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	err := s.P2p.DisconnectPeer(in.GetPeerID())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Disconnect peer"), err)
+	}
+	return &pb.GenericResponse{
+		Error: nil,
+	}, nil
+}
+
+// ListSubscriptions lists the subscribed channels with their statistics
+func (s *NodeService) ListSubscriptions(ctx context.Context, in *pb.Empty) (*pb.SubscriptionListResponse, error) {
+	return &pb.SubscriptionListResponse{Subscriptions: s.P2p.GetSubscriptionInfo()}, nil
+}
+
+// GetNATStatus reports whether the node is reachable from the internet, and the relay and observed addresses it's reachable through
+func (s *NodeService) GetNATStatus(ctx context.Context, in *pb.Empty) (*pb.NATStatus, error) {
+	return s.P2p.GetNATStatus(), nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const bannedNode string = "QmaCpDMGvV2BGHeYERUEnRQAwe3N8SzbUtfsmvsqQLuvuJ"
+
+func TestNodeBanning(t *testing.T) {
+	nodeService := &NodeService{}
+	nodeService.RegisterP2p(p2pInstance)
+
+	_, err := nodeService.BanPeer(ctx, &pb.PeerSpecificRequest{PeerID: "notAPeerID"})
+	assert.Error(t, err)
+
+	_, err = nodeService.BanPeer(ctx, &pb.PeerSpecificRequest{PeerID: bannedNode})
+	assert.NoError(t, err)
+	resp, err := nodeService.ListBannedPeers(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Contains(t, resp.GetPeers(), bannedNode)
+
+	_, err = nodeService.UnbanPeer(ctx, &pb.PeerSpecificRequest{PeerID: bannedNode})
+	assert.NoError(t, err)
+	_, err = nodeService.UnbanPeer(ctx, &pb.PeerSpecificRequest{PeerID: bannedNode})
+	assert.Error(t, err)
+	resp, err = nodeService.ListBannedPeers(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.NotContains(t, resp.GetPeers(), bannedNode)
+}
+
+func TestNodeConnectionStats(t *testing.T) {
+	nodeService := &NodeService{}
+	nodeService.RegisterP2p(p2pInstance)
+
+	stats, err := nodeService.GetConnectionStats(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, p2pInstance.GetConnectionStats().GetHighWater(), stats.GetHighWater())
+}
+
+func TestNodeInfoAndPeers(t *testing.T) {
+	nodeService := &NodeService{}
+	nodeService.RegisterP2p(p2pInstance)
+
+	info, err := nodeService.GetNodeInfo(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, p2pInstance.GetPeerID(), info.GetPeerID())
+
+	peers, err := nodeService.ListPeers(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, len(p2pInstance.GetPeers()), len(peers.GetPeers()))
+
+	_, err = nodeService.ConnectPeer(ctx, &pb.ConnectPeerRequest{Address: "notAnAddress"})
+	assert.Error(t, err)
+	_, err = nodeService.DisconnectPeer(ctx, &pb.PeerSpecificRequest{PeerID: bannedNode})
+	assert.Error(t, err)
+
+	subscriptions, err := nodeService.ListSubscriptions(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, len(p2pInstance.GetSubscriptions()), len(subscriptions.GetSubscriptions()))
+}
+
+func TestNodeNATStatus(t *testing.T) {
+	nodeService := &NodeService{}
+	nodeService.RegisterP2p(p2pInstance)
+
+	status, err := nodeService.GetNATStatus(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, p2pInstance.GetNATStatus().GetReachability(), status.GetReachability())
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// checkWritable refuses the operations that publish, trade or manage peers when the node is a read-only observer,
+// so observers can be exposed publicly
+func checkWritable(p2p interfaces.P2p, op errors.Op) error {
+	if p2p != nil && p2p.ReadOnly() {
+		return errors.E(op, errors.Denied, "the node is a read-only observer")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const p2pModeEnvVar string = "SPRAWL_P2P_MODE"
+
+func TestObserverNode(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	orderService.RegisterP2p(p2pInstance)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+
+	// An order created before becoming an observer
+	created, err := orderService.Create(ctx, &pb.CreateRequest{ChannelID: channel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	orderRequest := &pb.OrderSpecificRequest{OrderID: created.GetCreatedOrder().GetId(), ChannelID: channel.GetId()}
+
+	os.Setenv(p2pModeEnvVar, "observer")
+	defer os.Unsetenv(p2pModeEnvVar)
+	nodeService := &NodeService{}
+	nodeService.RegisterP2p(p2pInstance)
+
+	_, err = orderService.Create(ctx, &pb.CreateRequest{ChannelID: channel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.True(t, errors.Is(errors.Denied, err))
+	_, err = orderService.Delete(ctx, orderRequest)
+	assert.True(t, errors.Is(errors.Denied, err))
+	_, err = orderService.Lock(ctx, orderRequest)
+	assert.True(t, errors.Is(errors.Denied, err))
+	_, err = orderService.Unlock(ctx, orderRequest)
+	assert.True(t, errors.Is(errors.Denied, err))
+	_, err = orderService.Take(ctx, &pb.TakeRequest{OrderID: orderRequest.GetOrderID()})
+	assert.True(t, errors.Is(errors.Denied, err))
+	_, err = channelService.Moderate(ctx, &pb.ModerationRequest{ChannelID: channel.GetId()})
+	assert.True(t, errors.Is(errors.Denied, err))
+	_, err = nodeService.BanPeer(ctx, &pb.PeerSpecificRequest{PeerID: bannedNode})
+	assert.True(t, errors.Is(errors.Denied, err))
+	err = p2pInstance.Send(&pb.WireMessage{ChannelID: channel.GetId(), Operation: pb.Operation_CREATE})
+	assert.True(t, errors.Is(errors.Denied, err))
+
+	// Queries are still served
+	order, err := orderService.GetOrder(ctx, orderRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, created.GetCreatedOrder().GetId(), order.GetId())
+	channels, err := channelService.GetAllChannels(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, channels.GetChannels())
+}
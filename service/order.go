@@ -5,6 +5,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"strings"
+	"sync"
 
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
@@ -18,6 +19,44 @@ type OrderService struct {
 	Logger  interfaces.Logger
 	Storage interfaces.Storage
 	P2p     interfaces.P2p
+	// stateLocks serialize the state changes of each order, without holding up the other orders
+	// while a change is broadcast or negotiated with a peer
+	stateLocks struct {
+		sync.Mutex
+		locks map[string]*orderStateLock
+	}
+}
+
+// orderStateLock is the lock of a single order's state, dropped once nobody holds or waits for it
+type orderStateLock struct {
+	sync.Mutex
+	users int
+}
+
+// lockOrderState locks the state of an order, returning the function unlocking it
+func (s *OrderService) lockOrderState(orderID []byte) func() {
+	s.stateLocks.Lock()
+	if s.stateLocks.locks == nil {
+		s.stateLocks.locks = make(map[string]*orderStateLock)
+	}
+	lock, ok := s.stateLocks.locks[string(orderID)]
+	if !ok {
+		lock = &orderStateLock{}
+		s.stateLocks.locks[string(orderID)] = lock
+	}
+	lock.users++
+	s.stateLocks.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		s.stateLocks.Lock()
+		lock.users--
+		if lock.users == 0 {
+			delete(s.stateLocks.locks, string(orderID))
+		}
+		s.stateLocks.Unlock()
+	}
 }
 
 func getOrderStorageKey(orderID []byte) []byte {
@@ -36,6 +75,10 @@ func (s *OrderService) RegisterP2p(p2p interfaces.P2p) {
 
 // Create creates an Order, storing it locally and broadcasts the Order to all other nodes on the channel
 func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.CreateResponse, error) {
+	if err := checkWritable(s.P2p, errors.Op("Create order")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+
 	// Get current timestamp as protobuf type
 	now := ptypes.TimestampNow()
 
@@ -60,6 +103,10 @@ func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.Cr
 		Amount:       in.Amount,
 		Price:        in.Price,
 		State:        pb.State_OPEN,
+		ChannelID:    in.GetChannelID(),
+	}
+	if s.P2p != nil {
+		order.Creator = s.P2p.GetPeerID()
 	}
 
 	// Get order as bytes
@@ -70,7 +117,7 @@ func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.Cr
 		}
 	}
 	// Save order to LevelDB locally
-	err = s.Storage.Put(getOrderStorageKey(id), orderInBytes)
+	err = putOrder(s.Storage, order, orderInBytes)
 	if !errors.IsEmpty(err) {
 		err = errors.E(errors.Op("Put order"), err)
 		
@@ -79,8 +126,13 @@ func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.Cr
 	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_CREATE, Data: orderInBytes}
 
 	if s.P2p != nil {
-		// Send the order creation by wire
-		s.P2p.Send(wireMessage)
+		// Send the order creation by wire, forgetting the order if it can't be published.
+		// Send withdraws messages it gives up on, so a failed order is never published later.
+		sendErr := s.P2p.Send(wireMessage)
+		if !errors.IsEmpty(sendErr) {
+			deleteOrder(s.Storage, id)
+			return nil, errors.E(errors.Op("Send order"), sendErr)
+		}
 	} else {
 		if s.Logger != nil {
 			s.Logger.Warn("P2p service not registered with OrderService, not publishing or receiving orders from the network!")
@@ -93,8 +145,9 @@ func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.Cr
 	}, err
 }
 
-// Receive receives a buffer from p2p and tries to unmarshal it into a struct
-func (s *OrderService) Receive(buf []byte) error {
+// Receive receives a buffer from p2p and tries to unmarshal it into a struct.
+// from is the pretty-printed ID of the peer that published the message.
+func (s *OrderService) Receive(buf []byte, from string) error {
 	wireMessage := &pb.WireMessage{}
 	err := proto.Unmarshal(buf, wireMessage)
 	if !errors.IsEmpty(err) {
@@ -106,6 +159,30 @@ func (s *OrderService) Receive(buf []byte) error {
 
 	op := wireMessage.GetOperation()
 	data := wireMessage.GetData()
+
+	if s.Storage == nil {
+		if s.Logger != nil {
+			s.Logger.Warn("Storage not registered with OrderService, not persisting Orders!")
+		}
+		return nil
+	}
+
+	// Moderated channels only accept messages from peers that aren't banned
+	channel := getJoinedChannel(s.Storage, wireMessage.GetChannelID())
+	if channel != nil {
+		banned, err := isBanned(s.Storage, channel.GetId(), from)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Check ban in Receive"), err)
+		}
+		if banned {
+			return errors.E(errors.Op("Receive"), "peer is banned from the channel")
+		}
+	}
+
+	if op == pb.Operation_MODERATE {
+		return s.receiveModeration(channel, data)
+	}
+
 	order := &pb.Order{}
 	err = proto.Unmarshal(data, order)
 	if !errors.IsEmpty(err) {
@@ -115,29 +192,71 @@ func (s *OrderService) Receive(buf []byte) error {
 		return errors.E(errors.Op("Unmarshal order proto in Receive"), err)
 	}
 
-	if s.Storage != nil {
-		switch op {
-		case pb.Operation_CREATE:
-			// Save order to LevelDB locally
-			err = s.Storage.Put(getOrderStorageKey(order.GetId()), data)
+	switch op {
+	case pb.Operation_CREATE:
+		if channel != nil {
+			err = checkRules(channel, order)
 			if !errors.IsEmpty(err) {
-				err = errors.E(errors.Op("Put order"), err)
-			}
-		case pb.Operation_DELETE:
-			err = s.Storage.Delete(getOrderStorageKey(order.GetId()))
-			if !errors.IsEmpty(err) {
-				err = errors.E(errors.Op("Put order"), err)
+				return errors.E(errors.Op("Receive"), err)
 			}
 		}
-	} else {
-		if s.Logger != nil {
-			s.Logger.Warn("Storage not registered with OrderService, not persisting Orders!")
+		if storedOrder := getStoredOrder(s.Storage, order.GetId()); storedOrder != nil && storedOrder.GetCreator() != order.GetCreator() {
+			return errors.E(errors.Op("Receive"), errors.Invalid, "order ID belongs to another creator")
+		}
+		// Save order to LevelDB locally
+		err = putOrder(s.Storage, order, data)
+		if !errors.IsEmpty(err) {
+			err = errors.E(errors.Op("Put order"), err)
+		}
+	case pb.Operation_DELETE:
+		err = deleteOrder(s.Storage, order.GetId())
+		if !errors.IsEmpty(err) {
+			err = errors.E(errors.Op("Put order"), err)
+		}
+	case pb.Operation_LOCK, pb.Operation_UNLOCK:
+		// Only the state of a known order changes, the rest of the received order isn't trusted
+		storedOrder, err := s.getOrder(order.GetId())
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Receive lock"), err)
+		}
+		storedOrder.State = pb.State_LOCKED
+		if op == pb.Operation_UNLOCK {
+			storedOrder.State = pb.State_OPEN
+		}
+		storedData, err := proto.Marshal(storedOrder)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Marshal order"), err)
+		}
+		err = putOrder(s.Storage, storedOrder, storedData)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Put order"), err)
 		}
 	}
 
 	return err
 }
 
+// receiveModeration verifies a moderation message against the channel's admin keys before applying it
+func (s *OrderService) receiveModeration(channel *pb.Channel, data []byte) error {
+	if channel == nil {
+		return errors.E(errors.Op("Receive moderation"), "channel not joined")
+	}
+	moderation := &pb.ModerationMessage{}
+	err := proto.Unmarshal(data, moderation)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal moderation proto in Receive"), err)
+	}
+	err = verifyModeration(channel, moderation)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Receive moderation"), err)
+	}
+	err = checkModerationSequence(s.Storage, channel, moderation)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Receive moderation"), err)
+	}
+	return applyModeration(s.Storage, channel, moderation)
+}
+
 // GetOrder fetches a single order from the database
 func (s *OrderService) GetOrder(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Order, error) {
 	data, err := s.Storage.Get(getOrderStorageKey(in.GetOrderID()))
@@ -151,18 +270,19 @@ func (s *OrderService) GetOrder(ctx context.Context, in *pb.OrderSpecificRequest
 
 // GetAllOrders fetches all orders from the database
 func (s *OrderService) GetAllOrders(ctx context.Context, in *pb.Empty) (*pb.OrderListResponse, error) {
-	data, err := s.Storage.GetAllWithPrefix(string(interfaces.OrderPrefix))
-	if !errors.IsEmpty(err) {
-		return nil, errors.E(errors.Op("Get all orders"), err)
-	}
+	iter := s.Storage.NewIterator(&interfaces.IteratorOptions{Prefix: []byte(interfaces.OrderPrefix)})
+	defer iter.Release()
 
+	// Orders are listed in the order of their keys, unmarshalled one at a time
 	orders := make([]*pb.Order, 0)
-	i := 0
-	for _, value := range data {
+	for iter.Next() {
 		order := &pb.Order{}
-		proto.Unmarshal([]byte(value), order)
+		proto.Unmarshal(iter.Value(), order)
 		orders = append(orders, order)
-		i++
+	}
+	err := iter.Error()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get all orders"), err)
 	}
 
 	orderListResponse := &pb.OrderListResponse{Orders: orders}
@@ -171,6 +291,9 @@ func (s *OrderService) GetAllOrders(ctx context.Context, in *pb.Empty) (*pb.Orde
 
 // Delete removes the Order with the specified ID locally, and broadcasts the same request to all other nodes on the channel
 func (s *OrderService) Delete(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.GenericResponse, error) {
+	if err := checkWritable(s.P2p, errors.Op("Delete order")); !errors.IsEmpty(err) {
+		return nil, err
+	}
 	orderInBytes, err := s.Storage.Get(getOrderStorageKey(in.GetOrderID()))
 	if !errors.IsEmpty(err) {
 		return nil, errors.E(errors.Op("Delete order"), err)
@@ -180,8 +303,11 @@ func (s *OrderService) Delete(ctx context.Context, in *pb.OrderSpecificRequest)
 	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_DELETE, Data: orderInBytes}
 
 	if s.P2p != nil {
-		// Send the order creation by wire
-		s.P2p.Send(wireMessage)
+		// Send the order deletion by wire, keeping the order if it can't be published
+		err = s.P2p.Send(wireMessage)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Send order deletion"), err)
+		}
 	} else {
 		if s.Logger != nil {
 			s.Logger.Warn("P2p service not registered with OrderService, not publishing or receiving orders from the network!")
@@ -189,7 +315,7 @@ func (s *OrderService) Delete(ctx context.Context, in *pb.OrderSpecificRequest)
 	}
 
 	// Try to delete the Order from LevelDB with specified ID
-	err = s.Storage.Delete(getOrderStorageKey(in.GetOrderID()))
+	err = deleteOrder(s.Storage, in.GetOrderID())
 	if !errors.IsEmpty(err){
 		err = errors.E(errors.Op("Delete order"), err)
 	}
@@ -199,10 +325,78 @@ func (s *OrderService) Delete(ctx context.Context, in *pb.OrderSpecificRequest)
 	}, err
 }
 
+// getOrder fetches and unmarshals a single order from the database
+func (s *OrderService) getOrder(orderID []byte) (*pb.Order, error) {
+	data, err := s.Storage.Get(getOrderStorageKey(orderID))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get order"), errors.NotFound, err)
+	}
+	order := &pb.Order{}
+	err = proto.Unmarshal(data, order)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal order"), err)
+	}
+	return order, nil
+}
+
+// getOwnOrder fetches an order, failing if it wasn't created by this node
+func (s *OrderService) getOwnOrder(orderID []byte) (*pb.Order, error) {
+	if s.P2p == nil {
+		return nil, errors.E(errors.Op("Get own order"), "P2p service not registered with OrderService")
+	}
+	order, err := s.getOrder(orderID)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	if order.GetCreator() != s.P2p.GetPeerID() {
+		return nil, errors.E(errors.Op("Get own order"), "order is not created by this node")
+	}
+	return order, nil
+}
+
+// setOrderState broadcasts the new state of an order created by this node to the channel and stores it.
+// The state is left unchanged if the broadcast fails.
+func (s *OrderService) setOrderState(order *pb.Order, state pb.State) error {
+	operation := pb.Operation_LOCK
+	if state == pb.State_OPEN {
+		operation = pb.Operation_UNLOCK
+	}
+	changed := proto.Clone(order).(*pb.Order)
+	changed.State = state
+	orderInBytes, err := proto.Marshal(changed)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal order"), err)
+	}
+	err = s.P2p.Send(&pb.WireMessage{ChannelID: order.GetChannelID(), Operation: operation, Data: orderInBytes})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Send order state"), err)
+	}
+	err = putOrder(s.Storage, changed, orderInBytes)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put order"), err)
+	}
+	order.State = state
+	return nil
+}
+
 // Lock locks the given Order if the Order is created by this node, broadcasts the lock to other nodes on the channel.
 func (s *OrderService) Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.GenericResponse, error) {
+	if err := checkWritable(s.P2p, errors.Op("Lock order")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	defer s.lockOrderState(in.GetOrderID())()
 
-	// TODO: Add Order locking logic
+	order, err := s.getOwnOrder(in.GetOrderID())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Lock order"), err)
+	}
+	if order.GetState() == pb.State_LOCKED {
+		return nil, errors.E(errors.Op("Lock order"), "order is already locked")
+	}
+	err = s.setOrderState(order, pb.State_LOCKED)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Lock order"), err)
+	}
 
 	return &pb.GenericResponse{
 		Error: nil,
@@ -211,8 +405,22 @@ func (s *OrderService) Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*
 
 // Unlock unlocks the given Order if it's created by this node, broadcasts the unlocking operation to other nodes on the channel.
 func (s *OrderService) Unlock(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.GenericResponse, error) {
+	if err := checkWritable(s.P2p, errors.Op("Unlock order")); !errors.IsEmpty(err) {
+		return nil, err
+	}
+	defer s.lockOrderState(in.GetOrderID())()
 
-	// TODO: Add Order unlocking logic
+	order, err := s.getOwnOrder(in.GetOrderID())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unlock order"), err)
+	}
+	if order.GetState() == pb.State_OPEN {
+		return nil, errors.E(errors.Op("Unlock order"), "order is not locked")
+	}
+	err = s.setOrderState(order, pb.State_OPEN)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unlock order"), err)
+	}
 
 	return &pb.GenericResponse{
 		Error: nil,
@@ -16,6 +16,7 @@ import (
 	"github.com/sprawl/sprawl/p2p"
 	"github.com/sprawl/sprawl/pb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"google.golang.org/grpc"
@@ -50,14 +51,14 @@ func init() {
 	logger = zap.NewNop()
 	log = logger.Sugar()
 	testConfig = &config.Config{Logger: log}
+	testConfig.ReadConfig(testConfigPath)
 	privateKey, publicKey, _ := identity.GenerateKeyPair(rand.Reader)
 	p2pInstance = p2p.NewP2p(log, testConfig, privateKey, publicKey)
-	testConfig.ReadConfig(testConfigPath)
 	storage.SetDbPath(testConfig.GetString(dbPathVar))
 }
 
-func createNewServerInstance() {
-	p2pInstance.Run()
+func createNewServerInstance(t testing.TB) {
+	require.NoError(t, p2pInstance.Run())
 	storage.Run()
 
 	ctx = context.Background()
@@ -82,6 +83,7 @@ func createNewServerInstance() {
 
 func removeAllOrders() {
 	storage.DeleteAllWithPrefix(string(interfaces.OrderPrefix))
+	storage.DeleteAllWithPrefix(string(interfaces.OrderIndexPrefix))
 }
 
 func BufDialer(string, time.Duration) (net.Conn, error) {
@@ -94,7 +96,7 @@ func TestOrderStorageKeyPrefixer(t *testing.T) {
 }
 
 func TestOrderCreation(t *testing.T) {
-	createNewServerInstance()
+	createNewServerInstance(t)
 	orderService.RegisterStorage(storage)
 	orderService.RegisterP2p(p2pInstance)
 	defer p2pInstance.Close()
@@ -133,7 +135,7 @@ func TestOrderCreation(t *testing.T) {
 }
 
 func TestOrderReceive(t *testing.T) {
-	createNewServerInstance()
+	createNewServerInstance(t)
 	orderService.RegisterStorage(storage)
 	defer p2pInstance.Close()
 	defer storage.Close()
@@ -155,7 +157,7 @@ func TestOrderReceive(t *testing.T) {
 	order, err := orderService.Create(ctx, &testOrder)
 	marshaledOrder, err := proto.Marshal(order)
 
-	err = orderService.Receive(marshaledOrder)
+	err = orderService.Receive(marshaledOrder, "")
 	assert.NoError(t, err)
 
 	storedOrder, err := orderClient.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: order.GetCreatedOrder().GetId()})
@@ -164,7 +166,7 @@ func TestOrderReceive(t *testing.T) {
 }
 
 func TestOrderGetAll(t *testing.T) {
-	createNewServerInstance()
+	createNewServerInstance(t)
 	orderService.RegisterStorage(storage)
 	defer p2pInstance.Close()
 	defer storage.Close()
@@ -196,7 +198,7 @@ func TestOrderGetAll(t *testing.T) {
 }
 
 func BenchmarkOrderReceive(b *testing.B) {
-	createNewServerInstance()
+	createNewServerInstance(b)
 	orderService.RegisterStorage(storage)
 	orderService.RegisterP2p(p2pInstance)
 	defer p2pInstance.Close()
@@ -220,7 +222,7 @@ func BenchmarkOrderReceive(b *testing.B) {
 	for i := 1; i < b.N; i++ {
 		order, _ := orderService.Create(ctx, &testOrder)
 		marshaledOrder, _ := proto.Marshal(order)
-		orderService.Receive(marshaledOrder)
+		orderService.Receive(marshaledOrder, "")
 		orderClient.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: order.GetCreatedOrder().GetId()})
 	}
 }
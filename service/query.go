@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// fullScan names the plan that reads every stored order instead of an index
+const fullScan = "orders"
+
+// queryPlan describes how a query is answered: the index used and the ranges of it that are scanned.
+// Scans of the full scan plan return orders, and scans of indexes return order IDs.
+type queryPlan struct {
+	index  string
+	scans  []*interfaces.IteratorOptions
+	sorted bool
+}
+
+// indexScan scans the entries of an index with the same value
+func indexScan(index string, value string) *interfaces.IteratorOptions {
+	return &interfaces.IteratorOptions{Prefix: []byte(getIndexValuePrefix(index, value))}
+}
+
+// createdScan scans the creation time index between the bounds of a query
+func createdScan(query *pb.OrderQuery) *interfaces.IteratorOptions {
+	prefix := getIndexPrefix(createdIndex)
+	scan := &interfaces.IteratorOptions{Prefix: []byte(prefix), Reverse: query.GetDescending()}
+	if since := query.GetCreatedSince(); since != nil {
+		scan.Start = []byte(prefix + encodeCreated(since.GetSeconds(), since.GetNanos()))
+	}
+	if before := query.GetCreatedBefore(); before != nil {
+		scan.End = []byte(prefix + encodeCreated(before.GetSeconds(), before.GetNanos()))
+	}
+	return scan
+}
+
+// priceScan scans the price index between the bounds of a query
+func priceScan(query *pb.OrderQuery) *interfaces.IteratorOptions {
+	prefix := getIndexPrefix(priceIndex)
+	scan := &interfaces.IteratorOptions{Prefix: []byte(prefix), Reverse: query.GetDescending()}
+	if query.GetMinPrice() != 0 {
+		scan.Start = []byte(prefix + encodePrice(query.GetMinPrice()))
+	}
+	if query.GetMaxPrice() != 0 {
+		scan.End = []byte(prefix + encodePrice(query.GetMaxPrice()) + indexValueEnd)
+	}
+	return scan
+}
+
+// planOrderQuery picks the index a query is answered with. Sorted queries walk the index of the sort key,
+// so they can stop at the limit. Other queries use the most selective index matching their filters:
+// the creator, the channel, the asset pair, the states, and then the creation time and price ranges.
+// Queries without filters read every order.
+func planOrderQuery(query *pb.OrderQuery) *queryPlan {
+	switch query.GetSort() {
+	case pb.OrderSort_BY_CREATED:
+		return &queryPlan{index: createdIndex, scans: []*interfaces.IteratorOptions{createdScan(query)}, sorted: true}
+	case pb.OrderSort_BY_PRICE:
+		return &queryPlan{index: priceIndex, scans: []*interfaces.IteratorOptions{priceScan(query)}, sorted: true}
+	}
+
+	switch {
+	case query.GetCreator() != "":
+		return &queryPlan{index: creatorIndex, scans: []*interfaces.IteratorOptions{indexScan(creatorIndex, encodeIndexValue(query.GetCreator()))}}
+	case len(query.GetChannelID()) > 0:
+		return &queryPlan{index: channelIndex, scans: []*interfaces.IteratorOptions{indexScan(channelIndex, encodeIndexValue(string(query.GetChannelID())))}}
+	case query.GetAsset() != "" && query.GetCounterAsset() != "":
+		return &queryPlan{index: pairIndex, scans: []*interfaces.IteratorOptions{indexScan(pairIndex, encodeIndexValue(query.GetAsset(), query.GetCounterAsset()))}}
+	case len(query.GetStates()) > 0:
+		plan := &queryPlan{index: stateIndex}
+		for _, state := range query.GetStates() {
+			plan.scans = append(plan.scans, indexScan(stateIndex, encodeState(state)))
+		}
+		return plan
+	case query.GetCreatedSince() != nil || query.GetCreatedBefore() != nil:
+		return &queryPlan{index: createdIndex, scans: []*interfaces.IteratorOptions{createdScan(query)}}
+	case query.GetMinPrice() != 0 || query.GetMaxPrice() != 0:
+		return &queryPlan{index: priceIndex, scans: []*interfaces.IteratorOptions{priceScan(query)}}
+	}
+	return &queryPlan{index: fullScan, scans: []*interfaces.IteratorOptions{{Prefix: []byte(interfaces.OrderPrefix)}}}
+}
+
+// matchesQuery checks an order against every filter of a query. Orders found through an index are
+// checked too, since the index only narrows down the orders by one of the filters.
+func matchesQuery(query *pb.OrderQuery, order *pb.Order) bool {
+	if len(query.GetChannelID()) > 0 && string(query.GetChannelID()) != string(order.GetChannelID()) {
+		return false
+	}
+	if query.GetAsset() != "" && query.GetAsset() != order.GetAsset() {
+		return false
+	}
+	if query.GetCounterAsset() != "" && query.GetCounterAsset() != order.GetCounterAsset() {
+		return false
+	}
+	if query.GetCreator() != "" && query.GetCreator() != order.GetCreator() {
+		return false
+	}
+	if len(query.GetStates()) > 0 {
+		found := false
+		for _, state := range query.GetStates() {
+			found = found || state == order.GetState()
+		}
+		if !found {
+			return false
+		}
+	}
+	created := encodeCreated(order.GetCreated().GetSeconds(), order.GetCreated().GetNanos())
+	if since := query.GetCreatedSince(); since != nil && created < encodeCreated(since.GetSeconds(), since.GetNanos()) {
+		return false
+	}
+	if before := query.GetCreatedBefore(); before != nil && created >= encodeCreated(before.GetSeconds(), before.GetNanos()) {
+		return false
+	}
+	if query.GetMinPrice() != 0 && order.GetPrice() < query.GetMinPrice() {
+		return false
+	}
+	if query.GetMaxPrice() != 0 && order.GetPrice() > query.GetMaxPrice() {
+		return false
+	}
+	return true
+}
+
+// QueryOrders finds the stored orders matching every filter of the query, using the secondary indexes
+func (s *OrderService) QueryOrders(ctx context.Context, in *pb.OrderQuery) (*pb.OrderListResponse, error) {
+	plan := planOrderQuery(in)
+	if s.Logger != nil {
+		s.Logger.Debugf("Querying orders using the %s index", plan.index)
+	}
+
+	orders := make([]*pb.Order, 0)
+	seen := make(map[string]bool)
+	for _, scan := range plan.scans {
+		done, err := s.scanOrders(plan, scan, func(order *pb.Order) bool {
+			if seen[string(order.GetId())] || !matchesQuery(in, order) {
+				return false
+			}
+			seen[string(order.GetId())] = true
+			orders = append(orders, order)
+			return in.GetLimit() > 0 && len(orders) >= int(in.GetLimit())
+		})
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Query orders"), err)
+		}
+		if done {
+			break
+		}
+	}
+	return &pb.OrderListResponse{Orders: orders}, nil
+}
+
+// scanOrders passes the orders found by a scan of a plan to found until it returns true.
+// Index entries of orders that no longer exist are skipped.
+func (s *OrderService) scanOrders(plan *queryPlan, scan *interfaces.IteratorOptions, found func(*pb.Order) bool) (bool, error) {
+	iter := s.Storage.NewIterator(scan)
+	defer iter.Release()
+	for iter.Next() {
+		var order *pb.Order
+		if plan.index == fullScan {
+			order = &pb.Order{}
+			if !errors.IsEmpty(proto.Unmarshal(iter.Value(), order)) {
+				continue
+			}
+		} else if order = getStoredOrder(s.Storage, iter.Value()); order == nil {
+			continue
+		}
+		if found(order) {
+			return true, nil
+		}
+	}
+	return false, iter.Error()
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func storeTestOrder(t *testing.T, order *pb.Order) {
+	data, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	assert.NoError(t, putOrder(storage, order, data))
+}
+
+func queryOrderIDs(t *testing.T, orderService *OrderService, query *pb.OrderQuery) []string {
+	response, err := orderService.QueryOrders(ctx, query)
+	assert.NoError(t, err)
+	ids := []string{}
+	for _, order := range response.GetOrders() {
+		ids = append(ids, string(order.GetId()))
+	}
+	return ids
+}
+
+func TestIndexEncoding(t *testing.T) {
+	prices := []float32{-10.5, -0.1, 0, 0.1, 1, 2.5, 1000}
+	for i := 1; i < len(prices); i++ {
+		assert.True(t, encodePrice(prices[i-1]) < encodePrice(prices[i]))
+	}
+	assert.True(t, encodeCreated(-1, 0) < encodeCreated(0, 0))
+	assert.True(t, encodeCreated(10, 5) < encodeCreated(10, 6))
+	assert.True(t, encodeCreated(10, 999999999) < encodeCreated(11, 0))
+}
+
+func TestOrderWriteLocks(t *testing.T) {
+	first := &inmemory.Storage{Db: make(map[string]string)}
+	second := &inmemory.Storage{Db: make(map[string]string)}
+	order := &pb.Order{Id: []byte("lockedOrder"), Asset: asset1, CounterAsset: asset2}
+	data, err := proto.Marshal(order)
+	assert.NoError(t, err)
+
+	// Writing the orders of one storage doesn't wait for the writes of another
+	unlock := lockOrderWrites(first)
+	assert.NoError(t, putOrder(second, order, data))
+	written := make(chan error, 1)
+	go func() {
+		written <- putOrder(first, order, data)
+	}()
+	select {
+	case <-written:
+		t.Error("order was written while the storage was locked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	assert.NoError(t, <-written)
+}
+
+func TestOrderIndexes(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	removeAllOrders()
+
+	order := &pb.Order{Id: []byte("indexedOrder"), Asset: asset1, CounterAsset: asset2, Price: testPrice, ChannelID: []byte("indexedChannel"), Creator: "creator"}
+	storeTestOrder(t, order)
+	entries, err := storage.GetAllWithPrefix(string(interfaces.OrderIndexPrefix))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(getOrderIndexKeys(order)), len(entries))
+
+	// Replacing an order moves its index entries
+	order.State = pb.State_LOCKED
+	storeTestOrder(t, order)
+	entries, err = storage.GetAllWithPrefix(getIndexPrefix(stateIndex))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, map[string]string{getIndexValuePrefix(stateIndex, encodeState(pb.State_LOCKED)) + "696e64657865644f72646572": "indexedOrder"}, entries)
+
+	// Deleting an order removes its index entries
+	assert.NoError(t, deleteOrder(storage, order.GetId()))
+	entries, err = storage.GetAllWithPrefix(string(interfaces.OrderIndexPrefix))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Empty(t, entries)
+
+	// Orders stored without indexes are indexed by rebuilding
+	data, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	storage.Put(getOrderStorageKey(order.GetId()), data)
+	orderService := &OrderService{Storage: storage}
+	assert.NoError(t, orderService.RebuildIndexes())
+	entries, err = storage.GetAllWithPrefix(string(interfaces.OrderIndexPrefix))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(getOrderIndexKeys(order)), len(entries))
+
+	// Up to date indexes aren't rebuilt, missing or older ones are
+	assert.NoError(t, deleteOrder(storage, order.GetId()))
+	storage.Put(getOrderStorageKey(order.GetId()), data)
+	assert.NoError(t, orderService.UpdateIndexes())
+	entries, err = storage.GetAllWithPrefix(string(interfaces.OrderIndexPrefix))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Empty(t, entries)
+	storage.Put([]byte(orderIndexVersionKey), []byte("0"))
+	assert.NoError(t, orderService.UpdateIndexes())
+	entries, err = storage.GetAllWithPrefix(string(interfaces.OrderIndexPrefix))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(getOrderIndexKeys(order)), len(entries))
+	version, err := storage.Get([]byte(orderIndexVersionKey))
+	assert.NoError(t, err)
+	assert.Equal(t, orderIndexVersion, string(version))
+	removeAllOrders()
+}
+
+func TestRebuildIndexesInChunks(t *testing.T) {
+	memory := &inmemory.Storage{Db: make(map[string]string)}
+	orderService := &OrderService{Storage: memory}
+	for i := 0; i < rebuildChunkSize+1; i++ {
+		order := &pb.Order{Id: []byte(fmt.Sprintf("order%d", i)), Asset: asset1, CounterAsset: asset2}
+		data, err := proto.Marshal(order)
+		assert.NoError(t, err)
+		memory.Put(getOrderStorageKey(order.GetId()), data)
+	}
+	memory.Put([]byte(getIndexValuePrefix(creatorIndex, "stale")+"00"), []byte("stale"))
+
+	assert.NoError(t, orderService.UpdateIndexes())
+	entries, err := memory.GetAllWithPrefix(string(interfaces.OrderIndexPrefix))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Len(t, entries, (rebuildChunkSize+1)*len(getOrderIndexKeys(&pb.Order{})))
+}
+
+func TestQueryPlanner(t *testing.T) {
+	assert.Equal(t, fullScan, planOrderQuery(&pb.OrderQuery{}).index)
+	assert.Equal(t, creatorIndex, planOrderQuery(&pb.OrderQuery{Creator: "creator", ChannelID: []byte("channel")}).index)
+	assert.Equal(t, channelIndex, planOrderQuery(&pb.OrderQuery{ChannelID: []byte("channel"), Asset: asset1, CounterAsset: asset2}).index)
+	assert.Equal(t, pairIndex, planOrderQuery(&pb.OrderQuery{Asset: asset1, CounterAsset: asset2, States: []pb.State{pb.State_OPEN}}).index)
+	assert.Equal(t, stateIndex, planOrderQuery(&pb.OrderQuery{Asset: asset1, States: []pb.State{pb.State_OPEN}}).index)
+	assert.Len(t, planOrderQuery(&pb.OrderQuery{States: []pb.State{pb.State_OPEN, pb.State_LOCKED}}).scans, 2)
+	assert.Equal(t, createdIndex, planOrderQuery(&pb.OrderQuery{CreatedSince: &timestamp.Timestamp{Seconds: 1}}).index)
+	assert.Equal(t, priceIndex, planOrderQuery(&pb.OrderQuery{MaxPrice: 1}).index)
+
+	// Sorted queries walk the index of the sort key
+	plan := planOrderQuery(&pb.OrderQuery{Creator: "creator", Sort: pb.OrderSort_BY_PRICE, Descending: true})
+	assert.Equal(t, priceIndex, plan.index)
+	assert.True(t, plan.sorted)
+	assert.True(t, plan.scans[0].Reverse)
+}
+
+func TestQueryOrders(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	removeAllOrders()
+	orderService := &OrderService{Storage: storage}
+
+	orders := []*pb.Order{
+		{Id: []byte("a"), Created: &timestamp.Timestamp{Seconds: 30}, Asset: asset1, CounterAsset: asset2, Price: 3, State: pb.State_OPEN, ChannelID: []byte("channel1"), Creator: "creator1"},
+		{Id: []byte("b"), Created: &timestamp.Timestamp{Seconds: 10}, Asset: asset1, CounterAsset: asset2, Price: 1, State: pb.State_LOCKED, ChannelID: []byte("channel1"), Creator: "creator2"},
+		{Id: []byte("c"), Created: &timestamp.Timestamp{Seconds: 20}, Asset: asset2, CounterAsset: asset1, Price: 2, State: pb.State_OPEN, ChannelID: []byte("channel2"), Creator: "creator1"},
+	}
+	for _, order := range orders {
+		storeTestOrder(t, order)
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, queryOrderIDs(t, orderService, &pb.OrderQuery{}))
+	assert.Equal(t, []string{"a", "c"}, queryOrderIDs(t, orderService, &pb.OrderQuery{Creator: "creator1"}))
+	assert.Equal(t, []string{"a"}, queryOrderIDs(t, orderService, &pb.OrderQuery{ChannelID: []byte("channel1"), States: []pb.State{pb.State_OPEN}}))
+	assert.Equal(t, []string{"c"}, queryOrderIDs(t, orderService, &pb.OrderQuery{Asset: asset2, CounterAsset: asset1}))
+	assert.Equal(t, []string{"b"}, queryOrderIDs(t, orderService, &pb.OrderQuery{States: []pb.State{pb.State_LOCKED}}))
+	assert.Equal(t, []string{"c", "a"}, queryOrderIDs(t, orderService, &pb.OrderQuery{CreatedSince: &timestamp.Timestamp{Seconds: 20}, Sort: pb.OrderSort_BY_CREATED}))
+	assert.Equal(t, []string{"b"}, queryOrderIDs(t, orderService, &pb.OrderQuery{CreatedBefore: &timestamp.Timestamp{Seconds: 20}}))
+	assert.Equal(t, []string{"b", "c"}, queryOrderIDs(t, orderService, &pb.OrderQuery{MinPrice: 1, MaxPrice: 2}))
+	assert.Equal(t, []string{"a", "c"}, queryOrderIDs(t, orderService, &pb.OrderQuery{Sort: pb.OrderSort_BY_PRICE, Descending: true, Limit: 2}))
+	assert.Equal(t, []string{"c"}, queryOrderIDs(t, orderService, &pb.OrderQuery{Creator: "creator1", Sort: pb.OrderSort_BY_PRICE, Limit: 1}))
+
+	// Index entries of orders removed without updating the indexes are skipped
+	storage.Delete(getOrderStorageKey([]byte("a")))
+	assert.Equal(t, []string{"c"}, queryOrderIDs(t, orderService, &pb.OrderQuery{Creator: "creator1"}))
+	removeAllOrders()
+}
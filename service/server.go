@@ -10,10 +10,11 @@ import (
 	"google.golang.org/grpc"
 )
 
-// Server contains services for both Orders and Channels
+// Server contains services for Orders, Channels and the Node itself
 type Server struct {
 	Orders   *OrderService
 	Channels *ChannelService
+	Nodes    *NodeService
 	Logger   interfaces.Logger
 	grpc     *grpc.Server
 }
@@ -32,6 +33,18 @@ func NewServer(log interfaces.Logger, storage interfaces.Storage, p2p interfaces
 	server.Channels.RegisterStorage(storage)
 	server.Channels.RegisterP2p(p2p)
 
+	// Create a NodeService that defines the operations on the node's connections
+	server.Nodes = &NodeService{}
+	server.Nodes.RegisterP2p(p2p)
+
+	return server
+}
+
+// NewNodeServer returns a server for infrastructure nodes that don't trade. It only serves the operations on the node itself.
+func NewNodeServer(log interfaces.Logger, p2p interfaces.P2p) *Server {
+	server := &Server{Logger: log}
+	server.Nodes = &NodeService{}
+	server.Nodes.RegisterP2p(p2p)
 	return server
 }
 
@@ -48,8 +61,13 @@ func (server *Server) Run(port uint) {
 	server.grpc = grpc.NewServer(opts...)
 
 	// Register the Services with the RPC server
-	pb.RegisterOrderHandlerServer(server.grpc, server.Orders)
-	pb.RegisterChannelHandlerServer(server.grpc, server.Channels)
+	if server.Orders != nil {
+		pb.RegisterOrderHandlerServer(server.grpc, server.Orders)
+	}
+	if server.Channels != nil {
+		pb.RegisterChannelHandlerServer(server.grpc, server.Channels)
+	}
+	pb.RegisterNodeHandlerServer(server.grpc, server.Nodes)
 
 	// Run the server
 	server.grpc.Serve(lis)
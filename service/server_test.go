@@ -15,7 +15,7 @@ const apiPort uint = 1337
 const serverAddr string = "localhost:1337"
 
 func TestServerCreation(t *testing.T) {
-	p2pInstance.Run()
+	assert.NoError(t, p2pInstance.Run())
 	storage.Run()
 	defer storage.Close()
 	defer p2pInstance.Close()
@@ -35,7 +35,7 @@ func TestServerCreation(t *testing.T) {
 }
 
 func TestServerRun(t *testing.T) {
-	p2pInstance.Run()
+	assert.NoError(t, p2pInstance.Run())
 	storage.Run()
 	defer storage.Close()
 	defer p2pInstance.Close()
@@ -53,3 +53,10 @@ func TestServerRun(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 }
+
+func TestNodeServerCreation(t *testing.T) {
+	server := NewNodeServer(log, p2pInstance)
+	assert.NotNil(t, server.Nodes)
+	assert.Nil(t, server.Orders)
+	assert.Nil(t, server.Channels)
+}
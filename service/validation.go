@@ -0,0 +1,175 @@
+package service
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// validateOrderSchema checks that an order received from the network has every required field
+func validateOrderSchema(order *pb.Order) error {
+	switch {
+	case len(order.GetId()) == 0:
+		return errors.E(errors.Op("Validate order"), errors.Invalid, "order has no ID")
+	case order.GetAsset() == "" || order.GetCounterAsset() == "":
+		return errors.E(errors.Op("Validate order"), errors.Invalid, "order has no asset pair")
+	case order.GetAmount() == 0:
+		return errors.E(errors.Op("Validate order"), errors.Invalid, "order has no amount")
+	case order.GetCreator() == "":
+		return errors.E(errors.Op("Validate order"), errors.Invalid, "order has no creator")
+	}
+	return nil
+}
+
+// orderLookup finds the stored order a message refers to
+type orderLookup func(orderID []byte) (*pb.Order, error)
+
+// Validate checks a message received from the network before it's passed on to other peers.
+// from is the pretty-printed ID of the peer that signed the message.
+// Malformed, unauthorized and forged messages return an Invalid error.
+// Messages that refer to orders unknown to this node return a NotFound error, since they can't be checked.
+func (s *OrderService) Validate(buf []byte, from string) error {
+	return s.validate(buf, from, s.getOrder)
+}
+
+// ValidateBatch checks the messages of a batch envelope in order, each of them as if the messages
+// before it had been applied, so a batch can create an order and change it in the same window.
+// The error of each message is returned in its place, nil for the valid ones.
+func (s *OrderService) ValidateBatch(messages [][]byte, from string) []error {
+	applied := make(map[string]*pb.Order)
+	lookup := func(orderID []byte) (*pb.Order, error) {
+		order, ok := applied[string(orderID)]
+		if !ok {
+			return s.getOrder(orderID)
+		}
+		if order == nil {
+			return nil, errors.E(errors.Op("Get order"), errors.NotFound, "order was deleted earlier in the batch")
+		}
+		return order, nil
+	}
+
+	errs := make([]error, len(messages))
+	for i, buf := range messages {
+		errs[i] = s.validate(buf, from, lookup)
+		if errors.IsEmpty(errs[i]) {
+			applyToBatch(applied, buf, lookup)
+		}
+	}
+	return errs
+}
+
+// applyToBatch records the effect of a valid message on the orders of a batch
+func applyToBatch(applied map[string]*pb.Order, buf []byte, lookup orderLookup) {
+	wireMessage := &pb.WireMessage{}
+	order := &pb.Order{}
+	if !errors.IsEmpty(proto.Unmarshal(buf, wireMessage)) || !errors.IsEmpty(proto.Unmarshal(wireMessage.GetData(), order)) {
+		return
+	}
+	switch wireMessage.GetOperation() {
+	case pb.Operation_CREATE:
+		applied[string(order.GetId())] = order
+	case pb.Operation_DELETE:
+		applied[string(order.GetId())] = nil
+	case pb.Operation_LOCK, pb.Operation_UNLOCK:
+		storedOrder, err := lookup(order.GetId())
+		if !errors.IsEmpty(err) {
+			return
+		}
+		storedOrder = proto.Clone(storedOrder).(*pb.Order)
+		storedOrder.State = pb.State_LOCKED
+		if wireMessage.GetOperation() == pb.Operation_UNLOCK {
+			storedOrder.State = pb.State_OPEN
+		}
+		applied[string(order.GetId())] = storedOrder
+	}
+}
+
+// validate checks a message, finding the orders it refers to with lookup
+func (s *OrderService) validate(buf []byte, from string, lookup orderLookup) error {
+	wireMessage := &pb.WireMessage{}
+	err := proto.Unmarshal(buf, wireMessage)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal wiremessage proto in Validate"), errors.Invalid, err)
+	}
+
+	if s.Storage == nil {
+		return nil
+	}
+
+	channel := getJoinedChannel(s.Storage, wireMessage.GetChannelID())
+	if channel != nil {
+		banned, err := isBanned(s.Storage, channel.GetId(), from)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Check ban in Validate"), err)
+		}
+		if banned {
+			return errors.E(errors.Op("Validate"), errors.Invalid, "peer is banned from the channel")
+		}
+	}
+
+	data := wireMessage.GetData()
+	switch wireMessage.GetOperation() {
+	case pb.Operation_MODERATE:
+		if channel == nil {
+			return errors.E(errors.Op("Validate moderation"), errors.NotFound, "channel not joined")
+		}
+		moderation := &pb.ModerationMessage{}
+		err = proto.Unmarshal(data, moderation)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Unmarshal moderation proto in Validate"), errors.Invalid, err)
+		}
+		err = verifyModeration(channel, moderation)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Validate moderation"), errors.Invalid, err)
+		}
+		err = checkModerationScope(s.Storage, channel, moderation)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Validate moderation"), err)
+		}
+		err = checkModerationSequence(s.Storage, channel, moderation)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Validate moderation"), err)
+		}
+		return nil
+	case pb.Operation_CREATE, pb.Operation_DELETE, pb.Operation_LOCK, pb.Operation_UNLOCK:
+	default:
+		return errors.E(errors.Op("Validate"), errors.Invalid, "unknown operation "+wireMessage.GetOperation().String())
+	}
+
+	order := &pb.Order{}
+	err = proto.Unmarshal(data, order)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal order proto in Validate"), errors.Invalid, err)
+	}
+	err = validateOrderSchema(order)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	switch wireMessage.GetOperation() {
+	case pb.Operation_CREATE:
+		if order.GetCreator() != from {
+			return errors.E(errors.Op("Validate order"), errors.Invalid, "order is not signed by its creator")
+		}
+		// An order ID can't be taken over by recreating the order under another creator
+		if storedOrder, err := lookup(order.GetId()); errors.IsEmpty(err) && storedOrder.GetCreator() != order.GetCreator() {
+			return errors.E(errors.Op("Validate order"), errors.Invalid, "order ID belongs to another creator")
+		}
+		if channel != nil {
+			err = checkRules(channel, order)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Validate order"), errors.Invalid, err)
+			}
+		}
+	case pb.Operation_DELETE, pb.Operation_LOCK, pb.Operation_UNLOCK:
+		// Only the creator of the stored order may change it, the order in the message can't be trusted
+		storedOrder, err := lookup(order.GetId())
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Validate order"), err)
+		}
+		if storedOrder.GetCreator() != from {
+			return errors.E(errors.Op("Validate order"), errors.Invalid, "order is not changed by its creator")
+		}
+	}
+	return nil
+}
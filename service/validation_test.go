@@ -0,0 +1,101 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func createOrderMessage(t *testing.T, operation pb.Operation, order *pb.Order) []byte {
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	wireMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: order.GetChannelID(), Operation: operation, Data: orderInBytes})
+	assert.NoError(t, err)
+	return wireMessage
+}
+
+func TestOrderValidate(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+	removeAllModeration()
+
+	order := &pb.Order{Id: []byte(otherPeer), Asset: asset1, CounterAsset: asset2, Amount: testAmount, ChannelID: channel.GetId(), Creator: otherPeer}
+
+	err := orderService.Validate([]byte("garbage"), otherPeer)
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	assert.NoError(t, orderService.Validate(createOrderMessage(t, pb.Operation_CREATE, order), otherPeer))
+	// Orders can only be created by the peer signing the message
+	err = orderService.Validate(createOrderMessage(t, pb.Operation_CREATE, order), bannedPeer)
+	assert.True(t, errors.Is(errors.Invalid, err))
+	// Orders have to have every required field
+	err = orderService.Validate(createOrderMessage(t, pb.Operation_CREATE, &pb.Order{Id: order.GetId(), ChannelID: channel.GetId(), Creator: otherPeer}), otherPeer)
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	// Deleting an order this node doesn't know of can't be checked
+	err = orderService.Validate(createOrderMessage(t, pb.Operation_DELETE, order), otherPeer)
+	assert.True(t, errors.Is(errors.NotFound, err))
+
+	assert.NoError(t, orderService.Receive(createOrderMessage(t, pb.Operation_CREATE, order), otherPeer))
+	assert.NoError(t, orderService.Validate(createOrderMessage(t, pb.Operation_DELETE, order), otherPeer))
+	// Only the creator can delete its orders, even if the message claims otherwise
+	forged := proto.Clone(order).(*pb.Order)
+	forged.Creator = bannedPeer
+	err = orderService.Validate(createOrderMessage(t, pb.Operation_DELETE, forged), bannedPeer)
+	assert.True(t, errors.Is(errors.Invalid, err))
+	// nor take over its ID by creating the order again
+	err = orderService.Validate(createOrderMessage(t, pb.Operation_CREATE, forged), bannedPeer)
+	assert.True(t, errors.Is(errors.Invalid, err))
+	err = orderService.Receive(createOrderMessage(t, pb.Operation_CREATE, forged), bannedPeer)
+	assert.True(t, errors.Is(errors.Invalid, err))
+	stored, err := orderService.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: order.GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, otherPeer, stored.GetCreator())
+	// The creator can still publish its order again
+	assert.NoError(t, orderService.Validate(createOrderMessage(t, pb.Operation_CREATE, order), otherPeer))
+}
+
+func TestOrderValidateBatch(t *testing.T) {
+	createNewServerInstance(t)
+	orderService.RegisterStorage(storage)
+	defer p2pInstance.Close()
+	defer storage.Close()
+	defer conn.Close()
+	removeAllOrders()
+	removeAllModeration()
+
+	order := &pb.Order{Id: []byte(otherPeer), Asset: asset1, CounterAsset: asset2, Amount: testAmount, ChannelID: channel.GetId(), Creator: otherPeer}
+	create := createOrderMessage(t, pb.Operation_CREATE, order)
+	lock := createOrderMessage(t, pb.Operation_LOCK, order)
+	unlock := createOrderMessage(t, pb.Operation_UNLOCK, order)
+	remove := createOrderMessage(t, pb.Operation_DELETE, order)
+
+	// Each message is checked as if the ones before it had been applied
+	errs := orderService.(*OrderService).ValidateBatch([][]byte{create, lock, unlock, remove}, otherPeer)
+	for _, err := range errs {
+		assert.True(t, errors.IsEmpty(err))
+	}
+	errs = orderService.(*OrderService).ValidateBatch([][]byte{create, remove, lock}, otherPeer)
+	assert.True(t, errors.IsEmpty(errs[0]))
+	assert.True(t, errors.IsEmpty(errs[1]))
+	assert.True(t, errors.Is(errors.NotFound, errs[2]))
+
+	// Only the failing message is refused
+	errs = orderService.(*OrderService).ValidateBatch([][]byte{lock, create}, otherPeer)
+	assert.True(t, errors.Is(errors.NotFound, errs[0]))
+	assert.True(t, errors.IsEmpty(errs[1]))
+	errs = orderService.(*OrderService).ValidateBatch([][]byte{create, lock}, bannedPeer)
+	assert.True(t, errors.Is(errors.Invalid, errs[0]))
+	assert.True(t, errors.Is(errors.NotFound, errs[1]))
+
+	// Nothing is stored while validating
+	_, err := storage.Get(getOrderStorageKey(order.GetId()))
+	assert.Error(t, err)
+}
@@ -0,0 +1,156 @@
+// Package testnet runs networks of full Sprawl nodes inside a single process.
+// The nodes talk to each other over a libp2p mocknet, so tests never touch the real network.
+package testnet
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/p2p"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/service"
+)
+
+// pollInterval defines how often WaitFor checks its condition
+const pollInterval = 10 * time.Millisecond
+
+// Node is a single Sprawl node of a test network
+type Node struct {
+	P2p     *p2p.P2p
+	Server  *service.Server
+	Storage *inmemory.Storage
+}
+
+// Network is a set of Sprawl nodes connected to each other over a mocknet
+type Network struct {
+	Nodes   []*Node
+	mocknet mocknet.Mocknet
+	cancel  context.CancelFunc
+}
+
+// New starts n Sprawl nodes with in-memory storage and connects every node to every other node
+func New(n int, config interfaces.Config, logger interfaces.Logger) (*Network, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	network := &Network{mocknet: mocknet.New(ctx), cancel: cancel}
+
+	for i := 0; i < n; i++ {
+		node, err := network.addNode(i, config, logger)
+		if !errors.IsEmpty(err) {
+			network.Close()
+			return nil, errors.E(errors.Op("Add test node"), err)
+		}
+		network.Nodes = append(network.Nodes, node)
+	}
+
+	// Connect only after every node runs pubsub, so they exchange their subscriptions right away
+	err := network.mocknet.LinkAll()
+	if errors.IsEmpty(err) {
+		err = network.mocknet.ConnectAllButSelf()
+	}
+	if !errors.IsEmpty(err) {
+		network.Close()
+		return nil, errors.E(errors.Op("Connect test nodes"), err)
+	}
+	return network, nil
+}
+
+func (network *Network) addNode(i int, config interfaces.Config, logger interfaces.Logger) (*Node, error) {
+	privateKey, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", 4001+i))
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	h, err := network.mocknet.AddPeer(privateKey, addr)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+
+	node := &Node{Storage: &inmemory.Storage{Db: make(map[string]string)}}
+	node.P2p = p2p.NewP2p(logger, config, privateKey, publicKey)
+	node.Server = service.NewServer(logger, node.Storage, node.P2p)
+	node.P2p.RegisterOrderService(node.Server.Orders)
+	node.P2p.RegisterChannelService(node.Server.Channels)
+	node.P2p.RegisterStorage(node.Storage)
+	err = node.P2p.RunLocal(h)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return node, nil
+}
+
+// Close shuts down every node and the mocknet
+func (network *Network) Close() {
+	for _, node := range network.Nodes {
+		node.P2p.Close()
+	}
+	network.cancel()
+}
+
+// WaitFor polls condition until it's true, returning an error if it's still false after timeout
+func WaitFor(timeout time.Duration, condition func() bool) error {
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			return errors.E(errors.Op("Wait for condition"), fmt.Sprintf("condition not met in %s", timeout))
+		}
+		time.Sleep(pollInterval)
+	}
+	return nil
+}
+
+// JoinAll joins every node to the channel of an asset pair. It returns once every node
+// knows every other node on the channel and the gossipsub meshes have had a heartbeat to form.
+func (network *Network) JoinAll(ctx context.Context, asset string, counterAsset string, timeout time.Duration) (*pb.Channel, error) {
+	var channel *pb.Channel
+	for _, node := range network.Nodes {
+		joinres, err := node.Server.Channels.Join(ctx, &pb.JoinRequest{Asset: asset, CounterAsset: counterAsset})
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Join test channel"), err)
+		}
+		channel = joinres.GetJoinedChannel()
+	}
+
+	err := WaitFor(timeout, func() bool {
+		for _, node := range network.Nodes {
+			if len(node.P2p.GetChannelPeers(channel)) < len(network.Nodes)-1 {
+				return false
+			}
+		}
+		return true
+	})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Join test channel"), err)
+	}
+	time.Sleep(2 * pubsub.GossipSubHeartbeatInterval)
+	return channel, nil
+}
+
+// HasOrder tells whether every node has (or, with present set to false, doesn't have) an order stored
+func (network *Network) HasOrder(orderID []byte, present bool) bool {
+	for _, node := range network.Nodes {
+		_, err := node.Server.Orders.GetOrder(context.Background(), &pb.OrderSpecificRequest{OrderID: orderID})
+		if errors.IsEmpty(err) != present {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitForOrder waits until every node has the order stored, or with present set to false, has deleted it
+func (network *Network) WaitForOrder(orderID []byte, present bool, timeout time.Duration) error {
+	return WaitFor(timeout, func() bool {
+		return network.HasOrder(orderID, present)
+	})
+}
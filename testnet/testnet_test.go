@@ -0,0 +1,94 @@
+package testnet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const testConfigPath string = "../config/test"
+const testNodes int = 3
+const testTimeout = 10 * time.Second
+const asset1 string = "ETH"
+const asset2 string = "BTC"
+const testAmount = 52617562718
+const testPrice = 0.1
+
+func newTestNetwork(t *testing.T) (*Network, *pb.Channel) {
+	log := zap.NewNop().Sugar()
+	testConfig := &config.Config{Logger: log}
+	testConfig.ReadConfig(testConfigPath)
+
+	network, err := New(testNodes, testConfig, log)
+	assert.NoError(t, err)
+	channel, err := network.JoinAll(context.Background(), asset1, asset2, testTimeout)
+	assert.NoError(t, err)
+	return network, channel
+}
+
+func createOrder(t *testing.T, node *Node, channel *pb.Channel) *pb.Order {
+	resp, err := node.Server.Orders.Create(context.Background(), &pb.CreateRequest{ChannelID: channel.GetId(), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	return resp.GetCreatedOrder()
+}
+
+func TestOrderPropagation(t *testing.T) {
+	network, channel := newTestNetwork(t)
+	defer network.Close()
+
+	order := createOrder(t, network.Nodes[0], channel)
+	assert.NoError(t, network.WaitForOrder(order.GetId(), true, testTimeout))
+
+	_, err := network.Nodes[0].Server.Orders.Delete(context.Background(), &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: channel.GetId()})
+	assert.NoError(t, err)
+	assert.NoError(t, network.WaitForOrder(order.GetId(), false, testTimeout))
+}
+
+func TestChannelLeave(t *testing.T) {
+	network, channel := newTestNetwork(t)
+	defer network.Close()
+
+	leaver := network.Nodes[testNodes-1]
+	_, err := leaver.Server.Channels.Leave(context.Background(), &pb.ChannelSpecificRequest{Id: channel.GetId()})
+	assert.NoError(t, err)
+	assert.NoError(t, WaitFor(testTimeout, func() bool {
+		return len(network.Nodes[0].P2p.GetChannelPeers(channel)) == testNodes-2
+	}))
+
+	order := createOrder(t, network.Nodes[0], channel)
+	assert.NoError(t, WaitFor(testTimeout, func() bool {
+		_, err := network.Nodes[1].Server.Orders.GetOrder(context.Background(), &pb.OrderSpecificRequest{OrderID: order.GetId()})
+		return err == nil
+	}))
+	_, err = leaver.Server.Orders.GetOrder(context.Background(), &pb.OrderSpecificRequest{OrderID: order.GetId()})
+	assert.Error(t, err)
+}
+
+func TestTakeOrder(t *testing.T) {
+	network, channel := newTestNetwork(t)
+	defer network.Close()
+
+	maker, taker, observer := network.Nodes[0], network.Nodes[1], network.Nodes[2]
+	order := createOrder(t, maker, channel)
+	assert.NoError(t, network.WaitForOrder(order.GetId(), true, testTimeout))
+
+	resp, err := taker.Server.Orders.Take(context.Background(), &pb.TakeRequest{OrderID: order.GetId()})
+	assert.NoError(t, err)
+	assert.True(t, resp.GetAccepted())
+	assert.Equal(t, pb.State_LOCKED, resp.GetLockedOrder().GetState())
+
+	// The lock is broadcast to the rest of the channel
+	assert.NoError(t, WaitFor(testTimeout, func() bool {
+		lockedOrder, err := observer.Server.Orders.GetOrder(context.Background(), &pb.OrderSpecificRequest{OrderID: order.GetId()})
+		return err == nil && lockedOrder.GetState() == pb.State_LOCKED
+	}))
+
+	// Locked orders can't be taken again
+	resp, err = observer.Server.Orders.Take(context.Background(), &pb.TakeRequest{OrderID: order.GetId()})
+	assert.Error(t, err)
+}